@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/internal/pii"
+	"go-template/pkg/fieldcrypt"
+	"go-template/pkg/logger"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateBatchSize int
+	rotateDryRun    bool
+)
+
+// keysCmd represents the keys command
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Manage the keys encrypting personal data",
+	Long:  `Manage the keyring whose keys encrypt personal data columns, set by encryption.keyring_file in config`,
+}
+
+// keysGenerateCmd represents the keys generate command
+var keysGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Add a new primary key to the keyring",
+	Long: `Add a new primary key to the keyring, creating the keyring file if it does not exist.
+New values are encrypted with the new key; run "keys rotate" to re-encrypt existing rows with it.
+Older keys stay in the keyring to decrypt values not rotated yet.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runKeysGenerate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// keysRotateCmd represents the keys rotate command
+var keysRotateCmd = &cobra.Command{
+	Use:   "rotate",
+	Short: "Re-encrypt personal data with the primary key",
+	Long: `Re-encrypt the personal data of all users with the primary key of the keyring, in batches.
+Values stored in plaintext are encrypted and blind indexes recomputed, so run it after enabling encryption too.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runKeysRotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	keysRotateCmd.Flags().IntVar(&rotateBatchSize, "batch-size", 500, "number of users re-encrypted per transaction")
+	keysRotateCmd.Flags().BoolVar(&rotateDryRun, "dry-run", false, "only report how many users would be re-encrypted")
+	keysCmd.AddCommand(keysGenerateCmd, keysRotateCmd)
+	rootCmd.AddCommand(keysCmd)
+}
+
+func runKeysGenerate() error {
+	path := cfg.Encryption.KeyringFile
+	if path == "" {
+		return errors.New("encryption.keyring_file is not set in config")
+	}
+
+	ring, err := fieldcrypt.LoadKeyring(path)
+	if errors.Is(err, os.ErrNotExist) {
+		ring, err = fieldcrypt.NewKeyring()
+	}
+	if err != nil {
+		return err
+	}
+
+	id, err := ring.AddPrimary()
+	if err != nil {
+		return err
+	}
+	if err := ring.Save(path); err != nil {
+		return err
+	}
+	logger.Infof("Added primary key %s to keyring %s", id, path)
+	return nil
+}
+
+func runKeysRotate() error {
+	if !fieldcrypt.Enabled() {
+		return errors.New("encryption is disabled, set encryption.keyring_file in config")
+	}
+
+	res, err := pii.Rotate(context.Background(), dbClient, rotateBatchSize, rotateDryRun)
+	if err != nil {
+		return err
+	}
+
+	if rotateDryRun {
+		logger.Infof("Dry run: would re-encrypt %d of %d users", res.Updated, res.Scanned)
+		return nil
+	}
+	logger.Infof("Re-encrypted %d of %d users", res.Updated, res.Scanned)
+	return nil
+}
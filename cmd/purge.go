@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"go-template/internal/purge"
+	"go-template/pkg/logger"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	purgeRetention time.Duration
+	purgeDryRun    bool
+)
+
+// purgeCmd represents the purge command
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently delete soft-deleted records",
+	Long:  `Permanently delete users and roles that were soft-deleted longer ago than the retention period`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runPurge(cmd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	purgeCmd.Flags().DurationVar(&purgeRetention, "retention", 0, "retention period (default from purge.retention in config)")
+	purgeCmd.Flags().BoolVar(&purgeDryRun, "dry-run", false, "only report what would be purged")
+	rootCmd.AddCommand(purgeCmd)
+}
+
+func runPurge(cmd *cobra.Command) error {
+	retention := cfg.Purge.Retention
+	if cmd.Flags().Changed("retention") {
+		retention = purgeRetention
+	}
+	if retention <= 0 {
+		return fmt.Errorf("retention period must be positive, got %s", retention)
+	}
+
+	cutoff := time.Now().Add(-retention)
+	res, err := purge.Run(context.Background(), dbClient, cutoff, purgeDryRun)
+	if err != nil {
+		return err
+	}
+
+	if purgeDryRun {
+		logger.Infof("Dry run: would purge %d users and %d roles deleted before %s", res.Users, res.Roles, cutoff.Format(time.RFC3339))
+		return nil
+	}
+	logger.Infof("Purged %d users and %d roles deleted before %s", res.Users, res.Roles, cutoff.Format(time.RFC3339))
+	return nil
+}
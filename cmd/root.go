@@ -4,12 +4,29 @@ import (
 	"fmt"
 	"go-template/internal/config"
 	"go-template/internal/database"
+	"go-template/pkg/fieldcrypt"
 	"go-template/pkg/logger"
+	"go-template/pkg/mailer"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
 	"os"
 
 	"github.com/spf13/cobra"
 )
 
+// databaseCommands lists the commands that need a database connection
+var databaseCommands = map[string]bool{
+	"daemon": true,
+	"purge":  true,
+	"rotate": true,
+}
+
+// keyringCommands lists the commands that manage the keyring file themselves,
+// so it may not exist yet when they run
+var keyringCommands = map[string]bool{
+	"generate": true,
+}
+
 var (
 	cfgFile  string
 	cfg      *config.Config
@@ -40,8 +57,30 @@ var rootCmd = &cobra.Command{
 		}
 		logger.Infof("Logger initialized with level: %s", logger.Level())
 
+		// Initialize password hashing and policy
+		if err := password.Init(&cfg.Password); err != nil {
+			return fmt.Errorf("failed to initialize password hasher: %w", err)
+		}
+
+		// Initialize public identifier encoding
+		if err := publicid.Init(cfg.PublicID); err != nil {
+			return fmt.Errorf("failed to initialize public ids: %w", err)
+		}
+
+		// Initialize mail delivery
+		if err := mailer.Init(cfg.Mail); err != nil {
+			return fmt.Errorf("failed to initialize mailer: %w", err)
+		}
+
+		// Initialize field encryption, before reading or writing any rows
+		if !keyringCommands[cmd.Name()] {
+			if err := fieldcrypt.Init(cfg.Encryption); err != nil {
+				return fmt.Errorf("failed to initialize field encryption: %w", err)
+			}
+		}
+
 		// Initialize database connection
-		if cmd.Name() == "daemon" {
+		if databaseCommands[cmd.Name()] {
 			dbClient, err = database.New(&cfg.Database)
 			if err != nil {
 				return fmt.Errorf("failed to connect to database: %w", err)
@@ -15,27 +15,34 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
+        "/admin/sql": {
             "post": {
-                "description": "Authenticate a user and return JWT token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Run a single SELECT statement on the allowed tables in a read-only transaction, bounded in time and rows (admin only). The query is saved in the admin's history, refused queries included.\nIn JSON the result holds the columns and the rows. CSV starts with a header of the column names; NDJSON starts with a line holding the columns, followed by an object per row. Both are streamed, with the X-Row-Count and X-Truncated trailers.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "User login",
+                "summary": "Run a SQL query",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "credentials",
+                        "description": "Query",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.LoginInput"
+                            "$ref": "#/definitions/handler.SQLQueryInput"
                         }
                     }
                 ],
@@ -51,7 +58,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.LoginResponse"
+                                            "$ref": "#/definitions/handler.SQLQueryResult"
                                         }
                                     }
                                 }
@@ -59,7 +66,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params ｜ user.login.error ｜ user.disabled",
+                        "description": "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden | sql.rejected | sql.failed",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -67,14 +74,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/me": {
+        "/admin/sql/history": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns information about the currently authenticated user",
+                "description": "List the console queries of the current admin, newest first, with their outcome (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -82,9 +89,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
+                ],
+                "summary": "List SQL query history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user info",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -97,7 +120,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.UserInfo"
+                                            "$ref": "#/definitions/handler.SQLHistoryResult"
                                         }
                                     }
                                 }
@@ -105,7 +128,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found",
+                        "description": "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -113,9 +136,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
+        "/admin/users/{id}/impersonate": {
             "post": {
-                "description": "Refresh JWT token using a refresh token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a short-lived token to act as the given user. The token carries the admin as actor and cannot be refreshed.",
                 "consumes": [
                     "application/json"
                 ],
@@ -123,17 +151,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Refresh token",
+                "summary": "Impersonate a user",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "refresh",
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Impersonation reason",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RefreshInput"
+                            "$ref": "#/definitions/handler.ImpersonateInput"
                         }
                     }
                 ],
@@ -149,7 +184,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/handler.LoginResponse"
+                                            "$ref": "#/definitions/handler.ImpersonationResponse"
                                         }
                                     }
                                 }
@@ -157,7 +192,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params ｜ auth.token.expired | auth.token.invalid | user.not_found | user.disabled",
+                        "description": "server.error ｜ invalid.params ｜ user.not_found | user.disabled | auth.access.denied",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -165,51 +200,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Register a new user account",
-                "consumes": [
-                    "application/json"
-                ],
+        "/auth/exports/download": {
+            "get": {
+                "description": "Serves the archive of a data export. The signed token comes from the export's download link and expires with the archive.",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Register new user",
+                "summary": "Download a data export",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.RegisterInput"
-                        }
+                        "type": "string",
+                        "description": "Signed download token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "zip archive",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handler.UserInfo"
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params ｜ user.register.error",
+                        "description": "server.error ｜ export.invalid",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -217,14 +235,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/raw/data-structures": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Shows how to handle complex JSON data returned from raw SQL",
+        "/auth/invitations/accept": {
+            "post": {
+                "description": "Join the inviting organization, registering an account for the invited email if none exists",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,9 +245,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "raw-queries"
+                    "auth"
+                ],
+                "summary": "Accept an invitation",
+                "parameters": [
+                    {
+                        "description": "Invitation token and optional account data",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.InvitationAcceptInput"
+                        }
+                    }
                 ],
-                "summary": "Complex JSON data example",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -247,8 +271,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": true
+                                            "$ref": "#/definitions/handler.InvitationAcceptResponse"
                                         }
                                     }
                                 }
@@ -256,7 +279,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error",
+                        "description": "server.error ｜ invalid.params ｜ invitation.invalid ｜ user.disabled ｜ user.password.weak",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -264,14 +287,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/raw/execute-update": {
+        "/auth/login": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Example of executing a raw SQL update query",
+                "description": "Authenticate a user and return JWT token",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,16 +297,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "raw-queries"
+                    "auth"
                 ],
-                "summary": "Execute update example",
+                "summary": "User login",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Role name to update users for",
-                        "name": "role_name",
-                        "in": "query",
-                        "required": true
+                        "description": "Login credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.LoginInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -303,10 +323,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "integer"
-                                            }
+                                            "$ref": "#/definitions/handler.LoginResponse"
                                         }
                                     }
                                 }
@@ -314,7 +331,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error | invalid.params",
+                        "description": "server.error ｜ invalid.params ｜ user.login.error ｜ user.disabled | org.not_found | org.access.denied",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -322,14 +339,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/raw/role-user-counts": {
+        "/auth/me": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get counts of users per role using raw SQL",
+                "description": "Returns information about the currently authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -337,9 +354,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "raw-queries"
+                    "auth"
                 ],
-                "summary": "Get role user counts",
+                "summary": "Get current user info",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -352,10 +369,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/handler.RoleUserCountDTO"
-                                            }
+                                            "$ref": "#/definitions/handler.UserInfo"
                                         }
                                     }
                                 }
@@ -363,22 +377,20 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/raw/user-stats": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get user statistics using raw SQL queries",
+                "description": "Deletes the current user's account after re-entering the password",
                 "consumes": [
                     "application/json"
                 ],
@@ -386,45 +398,42 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "raw-queries"
+                    "auth"
+                ],
+                "summary": "Delete own account",
+                "parameters": [
+                    {
+                        "description": "Password confirmation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.AccountDeleteInput"
+                        }
+                    }
                 ],
-                "summary": "Get user statistics",
                 "responses": {
                     "200": {
                         "description": "ok",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/handler.UserStatsDTO"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
-                        "description": "server.error",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            }
-        },
-        "/roles": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a list of roles",
+                "description": "Updates profile fields of the currently authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -432,15 +441,18 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "auth"
                 ],
-                "summary": "List Roles",
+                "summary": "Update current user profile",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Include users information",
-                        "name": "with_users",
-                        "in": "query"
+                        "description": "Profile fields",
+                        "name": "profile",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ProfileUpdateInput"
+                        }
                     }
                 ],
                 "responses": {
@@ -455,10 +467,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/ent.Role"
-                                            }
+                                            "$ref": "#/definitions/handler.UserInfo"
                                         }
                                     }
                                 }
@@ -466,20 +475,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.email.taken | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/me/erase": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "create a new role",
+                "description": "Anonymizes the current user's name, email and password and closes the account after re-entering the password.\nThe account record is kept so references to it stay valid, and audit records are kept. Sessions, invitations to the user's email and data exports are deleted.",
                 "consumes": [
                     "application/json"
                 ],
@@ -487,17 +498,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "auth"
                 ],
-                "summary": "Create a role",
+                "summary": "Erase own personal data",
                 "parameters": [
                     {
-                        "description": "Role Info",
-                        "name": "role",
+                        "description": "Password confirmation",
+                        "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.RoleCreateInput"
+                            "$ref": "#/definitions/handler.AccountEraseInput"
                         }
                     }
                 ],
@@ -505,23 +516,11 @@ const docTemplate = `{
                     "200": {
                         "description": "ok",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/response.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/ent.Role"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/response.Response"
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -529,14 +528,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/roles/{id}": {
-            "get": {
+        "/auth/me/export": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a role by ID",
+                "description": "Starts building a zip archive of JSON files with the data linked to the current user: profile, role, sessions, memberships and audit entries.\nThe archive is built in the background; poll the export until it is ready to get a signed download link.\nWhile an export is being built, requesting another returns it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -544,24 +543,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
-                ],
-                "summary": "Get a role",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Include users information",
-                        "name": "with_users",
-                        "in": "query"
-                    }
+                    "auth"
                 ],
+                "summary": "Export own data",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -574,7 +558,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/ent.Role"
+                                            "$ref": "#/definitions/handler.ExportInfo"
                                         }
                                     }
                                 }
@@ -582,20 +566,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error | invalid.params | role.not_found",
+                        "description": "server.error ｜ user.unauthorized | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/me/exports/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "update an existing role",
+                "description": "Returns the status of a data export of the current user, with a signed download link once it is ready",
                 "consumes": [
                     "application/json"
                 ],
@@ -603,25 +589,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "auth"
                 ],
-                "summary": "Update a role",
+                "summary": "Get own data export",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Role ID",
+                        "type": "string",
+                        "description": "Export ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Role Info",
-                        "name": "role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.RoleUpdateInput"
-                        }
                     }
                 ],
                 "responses": {
@@ -636,7 +613,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/ent.Role"
+                                            "$ref": "#/definitions/handler.ExportInfo"
                                         }
                                     }
                                 }
@@ -644,20 +621,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | export.not_found",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/me/password": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete a role by ID",
+                "description": "Changes the current user's password and signs out all other sessions",
                 "consumes": [
                     "application/json"
                 ],
@@ -665,27 +644,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "auth"
                 ],
-                "summary": "Delete a role",
+                "summary": "Change password",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Current and new password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PasswordChangeInput"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "ok",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "integer"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params | role.not_found | role.in_use",
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | user.password.weak | auth.impersonation.forbidden",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -693,14 +689,61 @@ const docTemplate = `{
                 }
             }
         },
-        "/roles/{id}/users": {
-            "get": {
-                "security": [
+        "/auth/refresh": {
+            "post": {
+                "description": "Refresh JWT token using a refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh token",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RefreshInput"
+                        }
                     }
                 ],
-                "description": "Get a list of users with a specific role",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params ｜ auth.token.expired | auth.token.invalid | auth.session.invalid | user.not_found | user.disabled | org.not_found | org.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Register a new user account",
                 "consumes": [
                     "application/json"
                 ],
@@ -708,18 +751,66 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "roles"
+                    "auth"
                 ],
-                "summary": "Get Users with a specific role",
+                "summary": "Register new user",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Role ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RegisterInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params ｜ user.register.error ｜ user.password.weak",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Returns the active login sessions of the current user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List active sessions",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -734,7 +825,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/ent.User"
+                                                "$ref": "#/definitions/handler.SessionInfo"
                                             }
                                         }
                                     }
@@ -743,7 +834,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "description": "server.error ｜ user.unauthorized",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -751,14 +842,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes one of the current user's sessions, signing that device out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params ｜ user.unauthorized | session.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get user list",
+                "description": "Get the organizations the current user is a member of",
                 "consumes": [
                     "application/json"
                 ],
@@ -766,9 +900,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "organizations"
                 ],
-                "summary": "List users",
+                "summary": "List my organizations",
                 "responses": {
                     "200": {
                         "description": "ok",
@@ -783,7 +917,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/ent.User"
+                                                "$ref": "#/definitions/handler.OrganizationInfo"
                                             }
                                         }
                                     }
@@ -805,7 +939,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "create a new user",
+                "description": "Create an organization with the current user as its admin",
                 "consumes": [
                     "application/json"
                 ],
@@ -813,17 +947,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "organizations"
                 ],
-                "summary": "Create a user",
+                "summary": "Create an organization",
                 "parameters": [
                     {
-                        "description": "User Info",
-                        "name": "user",
+                        "description": "Organization Info",
+                        "name": "org",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UserCreateInput"
+                            "$ref": "#/definitions/handler.OrganizationCreateInput"
                         }
                     }
                 ],
@@ -839,7 +973,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/ent.User"
+                                            "$ref": "#/definitions/handler.OrganizationInfo"
                                         }
                                     }
                                 }
@@ -847,7 +981,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params",
+                        "description": "server.error ｜ invalid.params | org.slug.taken",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
@@ -855,14 +989,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/orgs/current/invitations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "get user by ID",
+                "description": "Get the invitations of the organization selected with X-Org-ID, pending ones by default",
                 "consumes": [
                     "application/json"
                 ],
@@ -870,16 +1004,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "invitations"
                 ],
-                "summary": "Get a user",
+                "summary": "List invitations",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -894,7 +1038,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/ent.User"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.InvitationInfo"
+                                            }
                                         }
                                     }
                                 }
@@ -902,20 +1049,20 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params ｜ user.not_found",
+                        "description": "server.error | invalid.params",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "update an existing user",
+                "description": "Email a signed, expiring invitation to join the organization selected with X-Org-ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -923,24 +1070,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "invitations"
                 ],
-                "summary": "Update a user",
+                "summary": "Invite by email",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
                         "required": true
                     },
                     {
-                        "description": "User Info",
-                        "name": "user",
+                        "description": "Invitation Info",
+                        "name": "invitation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UserUpdateInput"
+                            "$ref": "#/definitions/handler.InvitationCreateInput"
                         }
                     }
                 ],
@@ -956,7 +1103,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/ent.User"
+                                            "$ref": "#/definitions/handler.InvitationInfo"
                                         }
                                     }
                                 }
@@ -964,20 +1111,22 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params | user.not_found | role.not_found",
+                        "description": "server.error | invalid.params | role.not_found | team.not_found | org.member.exists | invitation.pending",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/orgs/current/invitations/{id}": {
             "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "delete a user by ID",
+                "description": "Revoke a pending invitation so its link can no longer be accepted",
                 "consumes": [
                     "application/json"
                 ],
@@ -985,13 +1134,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "invitations"
                 ],
-                "summary": "Delete a user",
+                "summary": "Revoke an invitation",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "User ID",
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1005,163 +1161,2673 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "server.error ｜ invalid.params | user.not_found",
+                        "description": "server.error | invalid.params | invitation.not_found | invitation.invalid",
                         "schema": {
                             "$ref": "#/definitions/response.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "ent.Role": {
-            "type": "object",
-            "properties": {
-                "description": {
-                    "description": "Description holds the value of the \"description\" field.",
-                    "type": "string"
-                },
-                "edges": {
-                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the RoleQuery when eager-loading is set.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/ent.RoleEdges"
-                        }
-                    ]
-                },
-                "id": {
-                    "description": "ID of the ent.",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "Name holds the value of the \"name\" field.",
+        },
+        "/orgs/current/invitations/{id}/resend": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Email a new link for an invitation, extending its expiry and invalidating earlier links",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "invitations"
+                ],
+                "summary": "Resend an invitation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Invitation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.InvitationInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | invitation.not_found | invitation.invalid",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the members of the organization selected with X-Org-ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "List organization members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.MemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | org.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from the organization selected with X-Org-ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organizations"
+                ],
+                "summary": "Remove an organization member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | org.member.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/teams": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the teams of the organization selected with X-Org-ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "List teams",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeamInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | org.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a team in the organization selected with X-Org-ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Create a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Team Info",
+                        "name": "team",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeamCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeamInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | role.not_found | team.name.taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/teams/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a team of the organization selected with X-Org-ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Delete a team",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | team.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/teams/{id}/members": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the members of a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "List team members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.TeamMemberInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | team.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a member of the organization to one of its teams",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Add a team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Member Info",
+                        "name": "member",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeamMemberAddInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | team.not_found | org.member.not_found | team.member.exists",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/teams/{id}/members/{user_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a user from a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Remove a team member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | team.not_found | team.member.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orgs/current/teams/{id}/roles": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the roles granted to the members of a team",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teams"
+                ],
+                "summary": "Set team roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization ID",
+                        "name": "X-Org-ID",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Team ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role IDs",
+                        "name": "roles",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TeamRolesInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.TeamInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | team.not_found | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/role-user-counts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the users of each shared role, most used first (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get role user counts",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.RoleUserCountDTO"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | auth.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/role-users/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Activate the disabled users of a shared role (admin only). The action is audited.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Activate the users of a role",
+                "parameters": [
+                    {
+                        "description": "Role",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ActivateRoleUsersInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.ActivateRoleUsersDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/user-stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Count the users by status and role (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reports"
+                ],
+                "summary": "Get user statistics",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/report.UserStats"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | auth.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "List Roles",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include users information",
+                        "name": "with_users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted roles",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.RoleInfo"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Create a role",
+                "parameters": [
+                    {
+                        "description": "Role Info",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RoleCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.RoleInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a role by ID. Without with_users, the ETag header carries the role's version;\nsend it in If-None-Match to get 304 while the role is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Get a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include users information",
+                        "name": "with_users",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted roles",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.RoleInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "not modified"
+                    },
+                    "500": {
+                        "description": "server.error | invalid.params | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update an existing role. With If-Match, the update is rejected with 412 if the role changed since it was read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Update a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Role Info",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RoleUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.RoleInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "soft-delete a role by ID. The role can be restored until it is purged.\nWith If-Match, the deletion is rejected with 412 if the role changed since it was read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Delete a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | role.not_found | role.in_use",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "partially update a role with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).\nAll changes are applied at once or not at all. With If-Match, the patch is rejected with 412 if the role changed since it was read.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Patch a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being patched",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch, or an array of JSON Patch operations on these fields",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.RolePatchInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.RoleInfo"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "restore a soft-deleted role by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Restore a role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/roles/{id}/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a list of users with a specific role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Get Users with a specific role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.UserDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | role.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get user list. Admins receive full records (UserDetail); other users receive their own record as UserView (id, name, email, created_at, updated_at) and others as PublicUserView (id, name).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted users (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/handler.UserDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error | auth.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create a new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a user",
+                "parameters": [
+                    {
+                        "description": "User Info",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserCreateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params ｜ role.not_found | user.password.weak | user.email.taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "stream the user list, with the list filters applied, as CSV or newline-delimited JSON",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "File format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted users",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/userbulk.ExportRow"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "create or update users from a CSV file with a header row or from newline-delimited JSON.\nColumns: name, email, password, role (name of a shared role, \"user\" by default) and status; other columns are ignored.\nInvalid rows are reported and skipped. Valid rows are written in batches, each in a transaction; a failed write fails its whole batch.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Import users",
+                "parameters": [
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson"
+                        ],
+                        "type": "string",
+                        "description": "File format, by default from the Content-Type",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "create",
+                            "upsert"
+                        ],
+                        "type": "string",
+                        "description": "create fails rows of existing emails, upsert updates those users",
+                        "name": "mode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Validate and report without writing",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Rows per transaction, 0 for a single transaction",
+                        "name": "batch_size",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Import file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/userbulk.Report"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "find users by partial name or email, most relevant first. Misspelled queries match by trigram similarity when the database supports it. While personal data is encrypted, only exact email matches are found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Search users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, at most 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted users",
+                        "name": "include_deleted",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserSearchResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "get user by ID. Admins receive the full record (UserDetail); other users receive their own record as UserView (id, name, email, created_at, updated_at) or the public PublicUserView (id, name).\nThe ETag header carries the user's version; send it in If-None-Match to get 304 while the user is unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Include soft-deleted users (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a previous response",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "not modified"
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params ｜ user.not_found | auth.access.denied",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "update an existing user. With If-Match, the update is rejected with 412 if the user changed since it was read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being updated",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User Info",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserUpdateInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | user.not_found | role.not_found | user.password.weak | user.email.taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "soft-delete a user by ID. The user can be restored until it is purged.\nWith If-Match, the deletion is rejected with 412 if the user changed since it was read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | user.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "partially update a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).\nAn explicit null, or a remove operation, clears role_id. All changes are applied at once or not at all.\nWith If-Match, the patch is rejected with 412 if the user changed since it was read.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Patch a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being patched",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Merge patch, or an array of JSON Patch operations on these fields",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserPatchInput"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/response.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/handler.UserDetail"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "412": {
+                        "description": "resource.modified",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "415": {
+                        "description": "invalid.params",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | user.not_found | role.not_found | user.password.weak | user.email.taken",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "restore a soft-deleted user by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Restore a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "server.error ｜ invalid.params | user.not_found",
+                        "schema": {
+                            "$ref": "#/definitions/response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "database.Column": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "nullable": {
+                    "description": "Unset when the driver does not tell",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "Database type, e.g. \"INT8\", empty when unknown",
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AccountDeleteInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "handler.AccountEraseInput": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "handler.ActivateRoleUsersDTO": {
+            "type": "object",
+            "properties": {
+                "activated": {
+                    "type": "integer"
+                },
+                "active_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ActivateRoleUsersInput": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "user"
+                }
+            }
+        },
+        "handler.ExportInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "download_url": {
+                    "description": "Signed link, set once the export is ready",
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "exp_8d2kq0vhx3m7a"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "ready",
+                        "failed"
+                    ]
+                }
+            }
+        },
+        "handler.ImpersonateInput": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Debugging ticket #1234"
+                }
+            }
+        },
+        "handler.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/handler.UserInfo"
+                }
+            }
+        },
+        "handler.InvitationAcceptInput": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.InvitationAcceptResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Whether a new account was registered",
+                    "type": "boolean"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "org_8d2fk5w0nq1ye"
+                },
+                "user": {
+                    "$ref": "#/definitions/handler.UserInfo"
+                }
+            }
+        },
+        "handler.InvitationCreateInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "role_id"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "jane@example.com"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "team_id": {
+                    "description": "Optional team to join on acceptance",
+                    "type": "string",
+                    "example": "team_0c6yb4r8sx2fn"
+                }
+            }
+        },
+        "handler.InvitationInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "inv_7pz2me4k9wq0b"
+                },
+                "invited_by": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "expired",
+                        "accepted",
+                        "revoked"
+                    ]
+                },
+                "team_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.LoginInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "organization_id": {
+                    "description": "Optional organization to sign in to",
+                    "type": "string",
+                    "example": "org_8d2fk5w0nq1ye"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "handler.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "org_role": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string",
+                    "example": "org_8d2fk5w0nq1ye"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/handler.UserInfo"
+                }
+            }
+        },
+        "handler.MemberInfo": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "joined_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
+                }
+            }
+        },
+        "handler.OrganizationCreateInput": {
+            "type": "object",
+            "required": [
+                "name",
+                "slug"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Acme Inc."
+                },
+                "slug": {
+                    "type": "string",
+                    "example": "acme"
+                }
+            }
+        },
+        "handler.OrganizationInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "org_8d2fk5w0nq1ye"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "slug": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PasswordChangeInput": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "example": "password123"
+                },
+                "new_password": {
+                    "type": "string",
+                    "example": "newPassword456"
+                }
+            }
+        },
+        "handler.ProfileUpdateInput": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "John Doe"
+                }
+            }
+        },
+        "handler.RefreshInput": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "handler.RegisterInput": {
+            "type": "object",
+            "required": [
+                "email",
+                "name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "password": {
+                    "type": "string",
+                    "example": "password123"
+                }
+            }
+        },
+        "handler.RoleCreateInput": {
+            "type": "object",
+            "required": [
+                "description",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "ent.RoleEdges": {
+        "handler.RoleInfo": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "Empty for roles shared by all organizations",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
                 "users": {
-                    "description": "Users holds the value of the users edge.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/ent.User"
+                        "$ref": "#/definitions/handler.UserDetail"
+                    }
+                },
+                "version": {
+                    "description": "Also sent as the ETag header of single-role responses",
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.RolePatchInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "minLength": 1
+                },
+                "name": {
+                    "type": "string",
+                    "minLength": 1
+                }
+            }
+        },
+        "handler.RoleUpdateInput": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.RoleUserCountDTO": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "role_name": {
+                    "type": "string"
+                },
+                "user_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.SQLHistoryResult": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.SQLQueryEntry"
                     }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "ent.User": {
+        "handler.SQLQueryEntry": {
             "type": "object",
             "properties": {
                 "created_at": {
-                    "description": "CreatedAt holds the value of the \"created_at\" field.",
                     "type": "string"
                 },
-                "edges": {
-                    "description": "Edges holds the relations/edges for other nodes in the graph.\nThe values are being populated by the UserQuery when eager-loading is set.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/ent.UserEdges"
-                        }
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "format": {
+                    "type": "string",
+                    "example": "json"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "sql_4nd8w2k0qe6ry"
+                },
+                "query": {
+                    "type": "string"
+                },
+                "row_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "succeeded"
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.SQLQueryInput": {
+            "type": "object",
+            "required": [
+                "query"
+            ],
+            "properties": {
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "csv",
+                        "ndjson"
+                    ],
+                    "example": "json"
+                },
+                "max_rows": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "SELECT name, COUNT(*) FROM roles GROUP BY name"
+                }
+            }
+        },
+        "handler.SQLQueryResult": {
+            "type": "object",
+            "properties": {
+                "columns": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/database.Column"
+                    }
+                },
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "sql_4nd8w2k0qe6ry"
+                },
+                "row_count": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "truncated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "handler.SessionInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "ses_5w1hq9c3tz0pd"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.TeamCreateInput": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "Product engineering team"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Engineering"
+                },
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "rol_3kq8x0v1mz7ta"
                     ]
+                }
+            }
+        },
+        "handler.TeamInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
                 },
-                "email": {
-                    "description": "Email holds the value of the \"email\" field.",
+                "created_by": {
+                    "type": "string"
+                },
+                "description": {
                     "type": "string"
                 },
                 "id": {
-                    "description": "ID of the ent.",
-                    "type": "integer"
+                    "type": "string",
+                    "example": "team_0c6yb4r8sx2fn"
                 },
                 "name": {
-                    "description": "Name holds the value of the \"name\" field.",
                     "type": "string"
                 },
-                "role_id": {
-                    "description": "RoleID holds the value of the \"role_id\" field.",
-                    "type": "integer"
-                },
-                "status": {
-                    "description": "Status holds the value of the \"status\" field.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/user.Status"
-                        }
-                    ]
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.TeamRoleInfo"
+                    }
                 },
                 "updated_at": {
-                    "description": "UpdatedAt holds the value of the \"updated_at\" field.",
+                    "type": "string"
+                },
+                "updated_by": {
                     "type": "string"
                 }
             }
         },
-        "ent.UserEdges": {
+        "handler.TeamMemberAddInput": {
             "type": "object",
+            "required": [
+                "user_id"
+            ],
             "properties": {
-                "role": {
-                    "description": "Role holds the value of the role edge.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/ent.Role"
-                        }
-                    ]
+                "user_id": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
                 }
             }
         },
-        "handler.LoginInput": {
+        "handler.TeamMemberInfo": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
                 "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                    "type": "string"
                 },
-                "password": {
+                "name": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string",
-                    "example": "password123"
+                    "example": "usr_q7vm9zp2hc54e"
                 }
             }
         },
-        "handler.LoginResponse": {
+        "handler.TeamRoleInfo": {
             "type": "object",
             "properties": {
-                "expires_at": {
-                    "type": "string"
-                },
-                "refresh_token": {
-                    "type": "string"
+                "id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
                 },
-                "token": {
+                "name": {
                     "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/handler.UserInfo"
                 }
             }
         },
-        "handler.RefreshInput": {
+        "handler.TeamRolesInput": {
             "type": "object",
-            "required": [
-                "refresh_token"
-            ],
             "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                "role_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "rol_3kq8x0v1mz7ta"
+                    ]
                 }
             }
         },
-        "handler.RegisterInput": {
+        "handler.UserCreateInput": {
             "type": "object",
             "required": [
                 "email",
                 "name",
-                "password"
+                "password",
+                "role_id"
             ],
             "properties": {
                 "email": {
@@ -1174,62 +3840,86 @@ const docTemplate = `{
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "password123"
+                    "example": "secret123"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/user.Status"
+                        }
+                    ],
+                    "example": "active"
                 }
             }
         },
-        "handler.RoleCreateInput": {
+        "handler.UserDetail": {
             "type": "object",
-            "required": [
-                "description",
-                "name"
-            ],
             "properties": {
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
+                "created_by": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.RoleUpdateInput": {
-            "type": "object",
-            "properties": {
-                "description": {
+                },
+                "deleted_at": {
                     "type": "string"
                 },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
+                },
                 "name": {
                     "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/handler.RoleInfo"
+                },
+                "role_id": {
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
+                },
+                "status": {
+                    "$ref": "#/definitions/user.Status"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Also sent as the ETag header of single-user responses",
+                    "type": "integer"
                 }
             }
         },
-        "handler.RoleUserCountDTO": {
+        "handler.UserInfo": {
             "type": "object",
             "properties": {
-                "description": {
+                "email": {
                     "type": "string"
                 },
-                "role_id": {
-                    "type": "integer"
+                "id": {
+                    "type": "string",
+                    "example": "usr_q7vm9zp2hc54e"
                 },
-                "role_name": {
+                "name": {
                     "type": "string"
                 },
-                "user_count": {
-                    "type": "integer"
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "handler.UserCreateInput": {
+        "handler.UserPatchInput": {
             "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password",
-                "role_id"
-            ],
             "properties": {
                 "email": {
                     "type": "string",
@@ -1237,18 +3927,22 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
+                    "minLength": 1,
                     "example": "John Doe"
                 },
                 "password": {
                     "type": "string",
-                    "minLength": 6,
-                    "example": "secret123"
+                    "example": "newsecret123"
                 },
                 "role_id": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
                 },
                 "status": {
+                    "enum": [
+                        "active",
+                        "disabled"
+                    ],
                     "allOf": [
                         {
                             "$ref": "#/definitions/user.Status"
@@ -1258,40 +3952,41 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.UserInfo": {
+        "handler.UserSearchHit": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "name": {
-                    "type": "string"
+                "highlights": {
+                    "description": "Matched fields with the matches wrapped in \u003cmark\u003e tags, HTML-escaped",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    },
+                    "example": {
+                        "name": "\u003cmark\u003eJo\u003c/mark\u003ehn Doe"
+                    }
                 },
-                "role": {
-                    "type": "string"
+                "user": {
+                    "$ref": "#/definitions/handler.UserDetail"
                 }
             }
         },
-        "handler.UserStatsDTO": {
+        "handler.UserSearchResult": {
             "type": "object",
             "properties": {
-                "active_users": {
-                    "type": "integer"
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handler.UserSearchHit"
+                    }
                 },
-                "disabled_users": {
+                "page": {
                     "type": "integer"
                 },
-                "newest_user_date": {
-                    "type": "string"
-                },
-                "total_users": {
+                "page_size": {
                     "type": "integer"
                 },
-                "users_per_role_json": {
-                    "type": "string"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
@@ -1311,8 +4006,9 @@ const docTemplate = `{
                     "example": "newsecret123"
                 },
                 "role_id": {
-                    "type": "integer",
-                    "example": 2
+                    "description": "An empty string clears the role",
+                    "type": "string",
+                    "example": "rol_3kq8x0v1mz7ta"
                 },
                 "status": {
                     "allOf": [
@@ -1324,6 +4020,40 @@ const docTemplate = `{
                 }
             }
         },
+        "report.RoleCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "report.UserStats": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "type": "integer"
+                },
+                "disabled_users": {
+                    "type": "integer"
+                },
+                "newest_user_date": {
+                    "type": "string"
+                },
+                "total_users": {
+                    "type": "integer"
+                },
+                "users_per_role": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.RoleCount"
+                    }
+                }
+            }
+        },
         "response.Response": {
             "type": "object",
             "properties": {
@@ -1360,6 +4090,84 @@ const docTemplate = `{
                 "StatusActive",
                 "StatusDisabled"
             ]
+        },
+        "userbulk.ExportRow": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "userbulk.Report": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/userbulk.RowResult"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "userbulk.RowResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "error"
+                    ]
+                },
+                "email": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "line": {
+                    "description": "Line of the row in the file",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -0,0 +1,199 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-template/ent/auditlog"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// AuditLog is the model entity for the AuditLog schema.
+type AuditLog struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// ActorID holds the value of the "actor_id" field.
+	ActorID int `json:"actor_id,omitempty"`
+	// Action holds the value of the "action" field.
+	Action string `json:"action,omitempty"`
+	// TargetType holds the value of the "target_type" field.
+	TargetType string `json:"target_type,omitempty"`
+	// TargetID holds the value of the "target_id" field.
+	TargetID string `json:"target_id,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// UserAgent holds the value of the "user_agent" field.
+	UserAgent string `json:"user_agent,omitempty"`
+	// RequestID holds the value of the "request_id" field.
+	RequestID string `json:"request_id,omitempty"`
+	// Metadata holds the value of the "metadata" field.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuditLog) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case auditlog.FieldMetadata:
+			values[i] = new([]byte)
+		case auditlog.FieldID, auditlog.FieldActorID:
+			values[i] = new(sql.NullInt64)
+		case auditlog.FieldAction, auditlog.FieldTargetType, auditlog.FieldTargetID, auditlog.FieldIP, auditlog.FieldUserAgent, auditlog.FieldRequestID:
+			values[i] = new(sql.NullString)
+		case auditlog.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuditLog fields.
+func (al *AuditLog) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case auditlog.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			al.ID = int(value.Int64)
+		case auditlog.FieldActorID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field actor_id", values[i])
+			} else if value.Valid {
+				al.ActorID = int(value.Int64)
+			}
+		case auditlog.FieldAction:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field action", values[i])
+			} else if value.Valid {
+				al.Action = value.String
+			}
+		case auditlog.FieldTargetType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target_type", values[i])
+			} else if value.Valid {
+				al.TargetType = value.String
+			}
+		case auditlog.FieldTargetID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field target_id", values[i])
+			} else if value.Valid {
+				al.TargetID = value.String
+			}
+		case auditlog.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				al.IP = value.String
+			}
+		case auditlog.FieldUserAgent:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field user_agent", values[i])
+			} else if value.Valid {
+				al.UserAgent = value.String
+			}
+		case auditlog.FieldRequestID:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field request_id", values[i])
+			} else if value.Valid {
+				al.RequestID = value.String
+			}
+		case auditlog.FieldMetadata:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field metadata", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &al.Metadata); err != nil {
+					return fmt.Errorf("unmarshal field metadata: %w", err)
+				}
+			}
+		case auditlog.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				al.CreatedAt = value.Time
+			}
+		default:
+			al.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuditLog.
+// This includes values selected through modifiers, order, etc.
+func (al *AuditLog) Value(name string) (ent.Value, error) {
+	return al.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this AuditLog.
+// Note that you need to call AuditLog.Unwrap() before calling this method if this AuditLog
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (al *AuditLog) Update() *AuditLogUpdateOne {
+	return NewAuditLogClient(al.config).UpdateOne(al)
+}
+
+// Unwrap unwraps the AuditLog entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (al *AuditLog) Unwrap() *AuditLog {
+	_tx, ok := al.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuditLog is not a transactional entity")
+	}
+	al.config.driver = _tx.drv
+	return al
+}
+
+// String implements the fmt.Stringer.
+func (al *AuditLog) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuditLog(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", al.ID))
+	builder.WriteString("actor_id=")
+	builder.WriteString(fmt.Sprintf("%v", al.ActorID))
+	builder.WriteString(", ")
+	builder.WriteString("action=")
+	builder.WriteString(al.Action)
+	builder.WriteString(", ")
+	builder.WriteString("target_type=")
+	builder.WriteString(al.TargetType)
+	builder.WriteString(", ")
+	builder.WriteString("target_id=")
+	builder.WriteString(al.TargetID)
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(al.IP)
+	builder.WriteString(", ")
+	builder.WriteString("user_agent=")
+	builder.WriteString(al.UserAgent)
+	builder.WriteString(", ")
+	builder.WriteString("request_id=")
+	builder.WriteString(al.RequestID)
+	builder.WriteString(", ")
+	builder.WriteString("metadata=")
+	builder.WriteString(fmt.Sprintf("%v", al.Metadata))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(al.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuditLogs is a parsable slice of AuditLog.
+type AuditLogs []*AuditLog
@@ -0,0 +1,373 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/auditlog"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuditLogCreate is the builder for creating a AuditLog entity.
+type AuditLogCreate struct {
+	config
+	mutation *AuditLogMutation
+	hooks    []Hook
+}
+
+// SetActorID sets the "actor_id" field.
+func (alc *AuditLogCreate) SetActorID(i int) *AuditLogCreate {
+	alc.mutation.SetActorID(i)
+	return alc
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableActorID(i *int) *AuditLogCreate {
+	if i != nil {
+		alc.SetActorID(*i)
+	}
+	return alc
+}
+
+// SetAction sets the "action" field.
+func (alc *AuditLogCreate) SetAction(s string) *AuditLogCreate {
+	alc.mutation.SetAction(s)
+	return alc
+}
+
+// SetTargetType sets the "target_type" field.
+func (alc *AuditLogCreate) SetTargetType(s string) *AuditLogCreate {
+	alc.mutation.SetTargetType(s)
+	return alc
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableTargetType(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetTargetType(*s)
+	}
+	return alc
+}
+
+// SetTargetID sets the "target_id" field.
+func (alc *AuditLogCreate) SetTargetID(s string) *AuditLogCreate {
+	alc.mutation.SetTargetID(s)
+	return alc
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableTargetID(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetTargetID(*s)
+	}
+	return alc
+}
+
+// SetIP sets the "ip" field.
+func (alc *AuditLogCreate) SetIP(s string) *AuditLogCreate {
+	alc.mutation.SetIP(s)
+	return alc
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableIP(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetIP(*s)
+	}
+	return alc
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (alc *AuditLogCreate) SetUserAgent(s string) *AuditLogCreate {
+	alc.mutation.SetUserAgent(s)
+	return alc
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableUserAgent(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetUserAgent(*s)
+	}
+	return alc
+}
+
+// SetRequestID sets the "request_id" field.
+func (alc *AuditLogCreate) SetRequestID(s string) *AuditLogCreate {
+	alc.mutation.SetRequestID(s)
+	return alc
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableRequestID(s *string) *AuditLogCreate {
+	if s != nil {
+		alc.SetRequestID(*s)
+	}
+	return alc
+}
+
+// SetMetadata sets the "metadata" field.
+func (alc *AuditLogCreate) SetMetadata(m map[string]interface{}) *AuditLogCreate {
+	alc.mutation.SetMetadata(m)
+	return alc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (alc *AuditLogCreate) SetCreatedAt(t time.Time) *AuditLogCreate {
+	alc.mutation.SetCreatedAt(t)
+	return alc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (alc *AuditLogCreate) SetNillableCreatedAt(t *time.Time) *AuditLogCreate {
+	if t != nil {
+		alc.SetCreatedAt(*t)
+	}
+	return alc
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alc *AuditLogCreate) Mutation() *AuditLogMutation {
+	return alc.mutation
+}
+
+// Save creates the AuditLog in the database.
+func (alc *AuditLogCreate) Save(ctx context.Context) (*AuditLog, error) {
+	alc.defaults()
+	return withHooks(ctx, alc.sqlSave, alc.mutation, alc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (alc *AuditLogCreate) SaveX(ctx context.Context) *AuditLog {
+	v, err := alc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (alc *AuditLogCreate) Exec(ctx context.Context) error {
+	_, err := alc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alc *AuditLogCreate) ExecX(ctx context.Context) {
+	if err := alc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (alc *AuditLogCreate) defaults() {
+	if _, ok := alc.mutation.TargetType(); !ok {
+		v := auditlog.DefaultTargetType
+		alc.mutation.SetTargetType(v)
+	}
+	if _, ok := alc.mutation.TargetID(); !ok {
+		v := auditlog.DefaultTargetID
+		alc.mutation.SetTargetID(v)
+	}
+	if _, ok := alc.mutation.IP(); !ok {
+		v := auditlog.DefaultIP
+		alc.mutation.SetIP(v)
+	}
+	if _, ok := alc.mutation.UserAgent(); !ok {
+		v := auditlog.DefaultUserAgent
+		alc.mutation.SetUserAgent(v)
+	}
+	if _, ok := alc.mutation.RequestID(); !ok {
+		v := auditlog.DefaultRequestID
+		alc.mutation.SetRequestID(v)
+	}
+	if _, ok := alc.mutation.CreatedAt(); !ok {
+		v := auditlog.DefaultCreatedAt()
+		alc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (alc *AuditLogCreate) check() error {
+	if _, ok := alc.mutation.Action(); !ok {
+		return &ValidationError{Name: "action", err: errors.New(`ent: missing required field "AuditLog.action"`)}
+	}
+	if v, ok := alc.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	if _, ok := alc.mutation.TargetType(); !ok {
+		return &ValidationError{Name: "target_type", err: errors.New(`ent: missing required field "AuditLog.target_type"`)}
+	}
+	if _, ok := alc.mutation.TargetID(); !ok {
+		return &ValidationError{Name: "target_id", err: errors.New(`ent: missing required field "AuditLog.target_id"`)}
+	}
+	if _, ok := alc.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`ent: missing required field "AuditLog.ip"`)}
+	}
+	if _, ok := alc.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "AuditLog.user_agent"`)}
+	}
+	if _, ok := alc.mutation.RequestID(); !ok {
+		return &ValidationError{Name: "request_id", err: errors.New(`ent: missing required field "AuditLog.request_id"`)}
+	}
+	if _, ok := alc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuditLog.created_at"`)}
+	}
+	return nil
+}
+
+func (alc *AuditLogCreate) sqlSave(ctx context.Context) (*AuditLog, error) {
+	if err := alc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := alc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, alc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	alc.mutation.id = &_node.ID
+	alc.mutation.done = true
+	return _node, nil
+}
+
+func (alc *AuditLogCreate) createSpec() (*AuditLog, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuditLog{config: alc.config}
+		_spec = sqlgraph.NewCreateSpec(auditlog.Table, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	)
+	if value, ok := alc.mutation.ActorID(); ok {
+		_spec.SetField(auditlog.FieldActorID, field.TypeInt, value)
+		_node.ActorID = value
+	}
+	if value, ok := alc.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+		_node.Action = value
+	}
+	if value, ok := alc.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+		_node.TargetType = value
+	}
+	if value, ok := alc.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeString, value)
+		_node.TargetID = value
+	}
+	if value, ok := alc.mutation.IP(); ok {
+		_spec.SetField(auditlog.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := alc.mutation.UserAgent(); ok {
+		_spec.SetField(auditlog.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := alc.mutation.RequestID(); ok {
+		_spec.SetField(auditlog.FieldRequestID, field.TypeString, value)
+		_node.RequestID = value
+	}
+	if value, ok := alc.mutation.Metadata(); ok {
+		_spec.SetField(auditlog.FieldMetadata, field.TypeJSON, value)
+		_node.Metadata = value
+	}
+	if value, ok := alc.mutation.CreatedAt(); ok {
+		_spec.SetField(auditlog.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// AuditLogCreateBulk is the builder for creating many AuditLog entities in bulk.
+type AuditLogCreateBulk struct {
+	config
+	err      error
+	builders []*AuditLogCreate
+}
+
+// Save creates the AuditLog entities in the database.
+func (alcb *AuditLogCreateBulk) Save(ctx context.Context) ([]*AuditLog, error) {
+	if alcb.err != nil {
+		return nil, alcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(alcb.builders))
+	nodes := make([]*AuditLog, len(alcb.builders))
+	mutators := make([]Mutator, len(alcb.builders))
+	for i := range alcb.builders {
+		func(i int, root context.Context) {
+			builder := alcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuditLogMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, alcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, alcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, alcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (alcb *AuditLogCreateBulk) SaveX(ctx context.Context) []*AuditLog {
+	v, err := alcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (alcb *AuditLogCreateBulk) Exec(ctx context.Context) error {
+	_, err := alcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alcb *AuditLogCreateBulk) ExecX(ctx context.Context) {
+	if err := alcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
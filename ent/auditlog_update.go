@@ -0,0 +1,513 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/auditlog"
+	"go-template/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuditLogUpdate is the builder for updating AuditLog entities.
+type AuditLogUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (alu *AuditLogUpdate) Where(ps ...predicate.AuditLog) *AuditLogUpdate {
+	alu.mutation.Where(ps...)
+	return alu
+}
+
+// SetActorID sets the "actor_id" field.
+func (alu *AuditLogUpdate) SetActorID(i int) *AuditLogUpdate {
+	alu.mutation.ResetActorID()
+	alu.mutation.SetActorID(i)
+	return alu
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableActorID(i *int) *AuditLogUpdate {
+	if i != nil {
+		alu.SetActorID(*i)
+	}
+	return alu
+}
+
+// AddActorID adds i to the "actor_id" field.
+func (alu *AuditLogUpdate) AddActorID(i int) *AuditLogUpdate {
+	alu.mutation.AddActorID(i)
+	return alu
+}
+
+// ClearActorID clears the value of the "actor_id" field.
+func (alu *AuditLogUpdate) ClearActorID() *AuditLogUpdate {
+	alu.mutation.ClearActorID()
+	return alu
+}
+
+// SetAction sets the "action" field.
+func (alu *AuditLogUpdate) SetAction(s string) *AuditLogUpdate {
+	alu.mutation.SetAction(s)
+	return alu
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableAction(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetAction(*s)
+	}
+	return alu
+}
+
+// SetTargetType sets the "target_type" field.
+func (alu *AuditLogUpdate) SetTargetType(s string) *AuditLogUpdate {
+	alu.mutation.SetTargetType(s)
+	return alu
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableTargetType(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetTargetType(*s)
+	}
+	return alu
+}
+
+// SetTargetID sets the "target_id" field.
+func (alu *AuditLogUpdate) SetTargetID(s string) *AuditLogUpdate {
+	alu.mutation.SetTargetID(s)
+	return alu
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableTargetID(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetTargetID(*s)
+	}
+	return alu
+}
+
+// SetIP sets the "ip" field.
+func (alu *AuditLogUpdate) SetIP(s string) *AuditLogUpdate {
+	alu.mutation.SetIP(s)
+	return alu
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableIP(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetIP(*s)
+	}
+	return alu
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (alu *AuditLogUpdate) SetUserAgent(s string) *AuditLogUpdate {
+	alu.mutation.SetUserAgent(s)
+	return alu
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableUserAgent(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetUserAgent(*s)
+	}
+	return alu
+}
+
+// SetRequestID sets the "request_id" field.
+func (alu *AuditLogUpdate) SetRequestID(s string) *AuditLogUpdate {
+	alu.mutation.SetRequestID(s)
+	return alu
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (alu *AuditLogUpdate) SetNillableRequestID(s *string) *AuditLogUpdate {
+	if s != nil {
+		alu.SetRequestID(*s)
+	}
+	return alu
+}
+
+// SetMetadata sets the "metadata" field.
+func (alu *AuditLogUpdate) SetMetadata(m map[string]interface{}) *AuditLogUpdate {
+	alu.mutation.SetMetadata(m)
+	return alu
+}
+
+// ClearMetadata clears the value of the "metadata" field.
+func (alu *AuditLogUpdate) ClearMetadata() *AuditLogUpdate {
+	alu.mutation.ClearMetadata()
+	return alu
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (alu *AuditLogUpdate) Mutation() *AuditLogMutation {
+	return alu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (alu *AuditLogUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, alu.sqlSave, alu.mutation, alu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (alu *AuditLogUpdate) SaveX(ctx context.Context) int {
+	affected, err := alu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (alu *AuditLogUpdate) Exec(ctx context.Context) error {
+	_, err := alu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (alu *AuditLogUpdate) ExecX(ctx context.Context) {
+	if err := alu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (alu *AuditLogUpdate) check() error {
+	if v, ok := alu.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (alu *AuditLogUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := alu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	if ps := alu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := alu.mutation.ActorID(); ok {
+		_spec.SetField(auditlog.FieldActorID, field.TypeInt, value)
+	}
+	if value, ok := alu.mutation.AddedActorID(); ok {
+		_spec.AddField(auditlog.FieldActorID, field.TypeInt, value)
+	}
+	if alu.mutation.ActorIDCleared() {
+		_spec.ClearField(auditlog.FieldActorID, field.TypeInt)
+	}
+	if value, ok := alu.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.IP(); ok {
+		_spec.SetField(auditlog.FieldIP, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.UserAgent(); ok {
+		_spec.SetField(auditlog.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.RequestID(); ok {
+		_spec.SetField(auditlog.FieldRequestID, field.TypeString, value)
+	}
+	if value, ok := alu.mutation.Metadata(); ok {
+		_spec.SetField(auditlog.FieldMetadata, field.TypeJSON, value)
+	}
+	if alu.mutation.MetadataCleared() {
+		_spec.ClearField(auditlog.FieldMetadata, field.TypeJSON)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, alu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	alu.mutation.done = true
+	return n, nil
+}
+
+// AuditLogUpdateOne is the builder for updating a single AuditLog entity.
+type AuditLogUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuditLogMutation
+}
+
+// SetActorID sets the "actor_id" field.
+func (aluo *AuditLogUpdateOne) SetActorID(i int) *AuditLogUpdateOne {
+	aluo.mutation.ResetActorID()
+	aluo.mutation.SetActorID(i)
+	return aluo
+}
+
+// SetNillableActorID sets the "actor_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableActorID(i *int) *AuditLogUpdateOne {
+	if i != nil {
+		aluo.SetActorID(*i)
+	}
+	return aluo
+}
+
+// AddActorID adds i to the "actor_id" field.
+func (aluo *AuditLogUpdateOne) AddActorID(i int) *AuditLogUpdateOne {
+	aluo.mutation.AddActorID(i)
+	return aluo
+}
+
+// ClearActorID clears the value of the "actor_id" field.
+func (aluo *AuditLogUpdateOne) ClearActorID() *AuditLogUpdateOne {
+	aluo.mutation.ClearActorID()
+	return aluo
+}
+
+// SetAction sets the "action" field.
+func (aluo *AuditLogUpdateOne) SetAction(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetAction(s)
+	return aluo
+}
+
+// SetNillableAction sets the "action" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableAction(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetAction(*s)
+	}
+	return aluo
+}
+
+// SetTargetType sets the "target_type" field.
+func (aluo *AuditLogUpdateOne) SetTargetType(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetTargetType(s)
+	return aluo
+}
+
+// SetNillableTargetType sets the "target_type" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableTargetType(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetTargetType(*s)
+	}
+	return aluo
+}
+
+// SetTargetID sets the "target_id" field.
+func (aluo *AuditLogUpdateOne) SetTargetID(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetTargetID(s)
+	return aluo
+}
+
+// SetNillableTargetID sets the "target_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableTargetID(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetTargetID(*s)
+	}
+	return aluo
+}
+
+// SetIP sets the "ip" field.
+func (aluo *AuditLogUpdateOne) SetIP(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetIP(s)
+	return aluo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableIP(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetIP(*s)
+	}
+	return aluo
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (aluo *AuditLogUpdateOne) SetUserAgent(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetUserAgent(s)
+	return aluo
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableUserAgent(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetUserAgent(*s)
+	}
+	return aluo
+}
+
+// SetRequestID sets the "request_id" field.
+func (aluo *AuditLogUpdateOne) SetRequestID(s string) *AuditLogUpdateOne {
+	aluo.mutation.SetRequestID(s)
+	return aluo
+}
+
+// SetNillableRequestID sets the "request_id" field if the given value is not nil.
+func (aluo *AuditLogUpdateOne) SetNillableRequestID(s *string) *AuditLogUpdateOne {
+	if s != nil {
+		aluo.SetRequestID(*s)
+	}
+	return aluo
+}
+
+// SetMetadata sets the "metadata" field.
+func (aluo *AuditLogUpdateOne) SetMetadata(m map[string]interface{}) *AuditLogUpdateOne {
+	aluo.mutation.SetMetadata(m)
+	return aluo
+}
+
+// ClearMetadata clears the value of the "metadata" field.
+func (aluo *AuditLogUpdateOne) ClearMetadata() *AuditLogUpdateOne {
+	aluo.mutation.ClearMetadata()
+	return aluo
+}
+
+// Mutation returns the AuditLogMutation object of the builder.
+func (aluo *AuditLogUpdateOne) Mutation() *AuditLogMutation {
+	return aluo.mutation
+}
+
+// Where appends a list predicates to the AuditLogUpdate builder.
+func (aluo *AuditLogUpdateOne) Where(ps ...predicate.AuditLog) *AuditLogUpdateOne {
+	aluo.mutation.Where(ps...)
+	return aluo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (aluo *AuditLogUpdateOne) Select(field string, fields ...string) *AuditLogUpdateOne {
+	aluo.fields = append([]string{field}, fields...)
+	return aluo
+}
+
+// Save executes the query and returns the updated AuditLog entity.
+func (aluo *AuditLogUpdateOne) Save(ctx context.Context) (*AuditLog, error) {
+	return withHooks(ctx, aluo.sqlSave, aluo.mutation, aluo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) SaveX(ctx context.Context) *AuditLog {
+	node, err := aluo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (aluo *AuditLogUpdateOne) Exec(ctx context.Context) error {
+	_, err := aluo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (aluo *AuditLogUpdateOne) ExecX(ctx context.Context) {
+	if err := aluo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (aluo *AuditLogUpdateOne) check() error {
+	if v, ok := aluo.mutation.Action(); ok {
+		if err := auditlog.ActionValidator(v); err != nil {
+			return &ValidationError{Name: "action", err: fmt.Errorf(`ent: validator failed for field "AuditLog.action": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (aluo *AuditLogUpdateOne) sqlSave(ctx context.Context) (_node *AuditLog, err error) {
+	if err := aluo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(auditlog.Table, auditlog.Columns, sqlgraph.NewFieldSpec(auditlog.FieldID, field.TypeInt))
+	id, ok := aluo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuditLog.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := aluo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, auditlog.FieldID)
+		for _, f := range fields {
+			if !auditlog.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != auditlog.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := aluo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := aluo.mutation.ActorID(); ok {
+		_spec.SetField(auditlog.FieldActorID, field.TypeInt, value)
+	}
+	if value, ok := aluo.mutation.AddedActorID(); ok {
+		_spec.AddField(auditlog.FieldActorID, field.TypeInt, value)
+	}
+	if aluo.mutation.ActorIDCleared() {
+		_spec.ClearField(auditlog.FieldActorID, field.TypeInt)
+	}
+	if value, ok := aluo.mutation.Action(); ok {
+		_spec.SetField(auditlog.FieldAction, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.TargetType(); ok {
+		_spec.SetField(auditlog.FieldTargetType, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.TargetID(); ok {
+		_spec.SetField(auditlog.FieldTargetID, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.IP(); ok {
+		_spec.SetField(auditlog.FieldIP, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.UserAgent(); ok {
+		_spec.SetField(auditlog.FieldUserAgent, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.RequestID(); ok {
+		_spec.SetField(auditlog.FieldRequestID, field.TypeString, value)
+	}
+	if value, ok := aluo.mutation.Metadata(); ok {
+		_spec.SetField(auditlog.FieldMetadata, field.TypeJSON, value)
+	}
+	if aluo.mutation.MetadataCleared() {
+		_spec.ClearField(auditlog.FieldMetadata, field.TypeJSON)
+	}
+	_node = &AuditLog{config: aluo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, aluo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{auditlog.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	aluo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,196 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-template/ent/consolequery"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ConsoleQuery is the model entity for the ConsoleQuery schema.
+type ConsoleQuery struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// Query holds the value of the "query" field.
+	Query string `json:"query,omitempty"`
+	// Format holds the value of the "format" field.
+	Format consolequery.Format `json:"format,omitempty"`
+	// Status holds the value of the "status" field.
+	Status consolequery.Status `json:"status,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// RowCount holds the value of the "row_count" field.
+	RowCount int `json:"row_count,omitempty"`
+	// Truncated holds the value of the "truncated" field.
+	Truncated bool `json:"truncated,omitempty"`
+	// DurationMs holds the value of the "duration_ms" field.
+	DurationMs int64 `json:"duration_ms,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*ConsoleQuery) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case consolequery.FieldTruncated:
+			values[i] = new(sql.NullBool)
+		case consolequery.FieldID, consolequery.FieldUserID, consolequery.FieldRowCount, consolequery.FieldDurationMs:
+			values[i] = new(sql.NullInt64)
+		case consolequery.FieldQuery, consolequery.FieldFormat, consolequery.FieldStatus, consolequery.FieldError:
+			values[i] = new(sql.NullString)
+		case consolequery.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the ConsoleQuery fields.
+func (cq *ConsoleQuery) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case consolequery.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			cq.ID = int(value.Int64)
+		case consolequery.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				cq.UserID = int(value.Int64)
+			}
+		case consolequery.FieldQuery:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field query", values[i])
+			} else if value.Valid {
+				cq.Query = value.String
+			}
+		case consolequery.FieldFormat:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field format", values[i])
+			} else if value.Valid {
+				cq.Format = consolequery.Format(value.String)
+			}
+		case consolequery.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				cq.Status = consolequery.Status(value.String)
+			}
+		case consolequery.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				cq.Error = value.String
+			}
+		case consolequery.FieldRowCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field row_count", values[i])
+			} else if value.Valid {
+				cq.RowCount = int(value.Int64)
+			}
+		case consolequery.FieldTruncated:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field truncated", values[i])
+			} else if value.Valid {
+				cq.Truncated = value.Bool
+			}
+		case consolequery.FieldDurationMs:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field duration_ms", values[i])
+			} else if value.Valid {
+				cq.DurationMs = value.Int64
+			}
+		case consolequery.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				cq.CreatedAt = value.Time
+			}
+		default:
+			cq.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the ConsoleQuery.
+// This includes values selected through modifiers, order, etc.
+func (cq *ConsoleQuery) Value(name string) (ent.Value, error) {
+	return cq.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this ConsoleQuery.
+// Note that you need to call ConsoleQuery.Unwrap() before calling this method if this ConsoleQuery
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (cq *ConsoleQuery) Update() *ConsoleQueryUpdateOne {
+	return NewConsoleQueryClient(cq.config).UpdateOne(cq)
+}
+
+// Unwrap unwraps the ConsoleQuery entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (cq *ConsoleQuery) Unwrap() *ConsoleQuery {
+	_tx, ok := cq.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: ConsoleQuery is not a transactional entity")
+	}
+	cq.config.driver = _tx.drv
+	return cq
+}
+
+// String implements the fmt.Stringer.
+func (cq *ConsoleQuery) String() string {
+	var builder strings.Builder
+	builder.WriteString("ConsoleQuery(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", cq.ID))
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", cq.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("query=")
+	builder.WriteString(cq.Query)
+	builder.WriteString(", ")
+	builder.WriteString("format=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Format))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Status))
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(cq.Error)
+	builder.WriteString(", ")
+	builder.WriteString("row_count=")
+	builder.WriteString(fmt.Sprintf("%v", cq.RowCount))
+	builder.WriteString(", ")
+	builder.WriteString("truncated=")
+	builder.WriteString(fmt.Sprintf("%v", cq.Truncated))
+	builder.WriteString(", ")
+	builder.WriteString("duration_ms=")
+	builder.WriteString(fmt.Sprintf("%v", cq.DurationMs))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(cq.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// ConsoleQueries is a parsable slice of ConsoleQuery.
+type ConsoleQueries []*ConsoleQuery
@@ -0,0 +1,176 @@
+// Code generated by ent, DO NOT EDIT.
+
+package consolequery
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the consolequery type in the database.
+	Label = "console_query"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldQuery holds the string denoting the query field in the database.
+	FieldQuery = "query"
+	// FieldFormat holds the string denoting the format field in the database.
+	FieldFormat = "format"
+	// FieldStatus holds the string denoting the status field in the database.
+	FieldStatus = "status"
+	// FieldError holds the string denoting the error field in the database.
+	FieldError = "error"
+	// FieldRowCount holds the string denoting the row_count field in the database.
+	FieldRowCount = "row_count"
+	// FieldTruncated holds the string denoting the truncated field in the database.
+	FieldTruncated = "truncated"
+	// FieldDurationMs holds the string denoting the duration_ms field in the database.
+	FieldDurationMs = "duration_ms"
+	// FieldCreatedAt holds the string denoting the created_at field in the database.
+	FieldCreatedAt = "created_at"
+	// Table holds the table name of the consolequery in the database.
+	Table = "console_queries"
+)
+
+// Columns holds all SQL columns for consolequery fields.
+var Columns = []string{
+	FieldID,
+	FieldUserID,
+	FieldQuery,
+	FieldFormat,
+	FieldStatus,
+	FieldError,
+	FieldRowCount,
+	FieldTruncated,
+	FieldDurationMs,
+	FieldCreatedAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// DefaultError holds the default value on creation for the "error" field.
+	DefaultError string
+	// DefaultRowCount holds the default value on creation for the "row_count" field.
+	DefaultRowCount int
+	// DefaultTruncated holds the default value on creation for the "truncated" field.
+	DefaultTruncated bool
+	// DefaultDurationMs holds the default value on creation for the "duration_ms" field.
+	DefaultDurationMs int64
+	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
+	DefaultCreatedAt func() time.Time
+)
+
+// Format defines the type for the "format" enum field.
+type Format string
+
+// Format values.
+const (
+	FormatJSON   Format = "json"
+	FormatCsv    Format = "csv"
+	FormatNdjson Format = "ndjson"
+)
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// FormatValidator is a validator for the "format" field enum values. It is called by the builders before save.
+func FormatValidator(f Format) error {
+	switch f {
+	case FormatJSON, FormatCsv, FormatNdjson:
+		return nil
+	default:
+		return fmt.Errorf("consolequery: invalid enum value for format field: %q", f)
+	}
+}
+
+// Status defines the type for the "status" enum field.
+type Status string
+
+// Status values.
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusRejected  Status = "rejected"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// StatusValidator is a validator for the "status" field enum values. It is called by the builders before save.
+func StatusValidator(s Status) error {
+	switch s {
+	case StatusRunning, StatusSucceeded, StatusFailed, StatusRejected:
+		return nil
+	default:
+		return fmt.Errorf("consolequery: invalid enum value for status field: %q", s)
+	}
+}
+
+// OrderOption defines the ordering options for the ConsoleQuery queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByQuery orders the results by the query field.
+func ByQuery(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldQuery, opts...).ToFunc()
+}
+
+// ByFormat orders the results by the format field.
+func ByFormat(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldFormat, opts...).ToFunc()
+}
+
+// ByStatus orders the results by the status field.
+func ByStatus(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldStatus, opts...).ToFunc()
+}
+
+// ByError orders the results by the error field.
+func ByError(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldError, opts...).ToFunc()
+}
+
+// ByRowCount orders the results by the row_count field.
+func ByRowCount(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRowCount, opts...).ToFunc()
+}
+
+// ByTruncated orders the results by the truncated field.
+func ByTruncated(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTruncated, opts...).ToFunc()
+}
+
+// ByDurationMs orders the results by the duration_ms field.
+func ByDurationMs(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDurationMs, opts...).ToFunc()
+}
+
+// ByCreatedAt orders the results by the created_at field.
+func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
+}
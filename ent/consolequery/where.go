@@ -0,0 +1,445 @@
+// Code generated by ent, DO NOT EDIT.
+
+package consolequery
+
+import (
+	"go-template/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldID, id))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldUserID, v))
+}
+
+// Query applies equality check predicate on the "query" field. It's identical to QueryEQ.
+func Query(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldQuery, v))
+}
+
+// Error applies equality check predicate on the "error" field. It's identical to ErrorEQ.
+func Error(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldError, v))
+}
+
+// RowCount applies equality check predicate on the "row_count" field. It's identical to RowCountEQ.
+func RowCount(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldRowCount, v))
+}
+
+// Truncated applies equality check predicate on the "truncated" field. It's identical to TruncatedEQ.
+func Truncated(v bool) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldTruncated, v))
+}
+
+// DurationMs applies equality check predicate on the "duration_ms" field. It's identical to DurationMsEQ.
+func DurationMs(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldDurationMs, v))
+}
+
+// CreatedAt applies equality check predicate on the "created_at" field. It's identical to CreatedAtEQ.
+func CreatedAt(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldUserID, v))
+}
+
+// QueryEQ applies the EQ predicate on the "query" field.
+func QueryEQ(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldQuery, v))
+}
+
+// QueryNEQ applies the NEQ predicate on the "query" field.
+func QueryNEQ(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldQuery, v))
+}
+
+// QueryIn applies the In predicate on the "query" field.
+func QueryIn(vs ...string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldQuery, vs...))
+}
+
+// QueryNotIn applies the NotIn predicate on the "query" field.
+func QueryNotIn(vs ...string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldQuery, vs...))
+}
+
+// QueryGT applies the GT predicate on the "query" field.
+func QueryGT(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldQuery, v))
+}
+
+// QueryGTE applies the GTE predicate on the "query" field.
+func QueryGTE(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldQuery, v))
+}
+
+// QueryLT applies the LT predicate on the "query" field.
+func QueryLT(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldQuery, v))
+}
+
+// QueryLTE applies the LTE predicate on the "query" field.
+func QueryLTE(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldQuery, v))
+}
+
+// QueryContains applies the Contains predicate on the "query" field.
+func QueryContains(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldContains(FieldQuery, v))
+}
+
+// QueryHasPrefix applies the HasPrefix predicate on the "query" field.
+func QueryHasPrefix(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldHasPrefix(FieldQuery, v))
+}
+
+// QueryHasSuffix applies the HasSuffix predicate on the "query" field.
+func QueryHasSuffix(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldHasSuffix(FieldQuery, v))
+}
+
+// QueryEqualFold applies the EqualFold predicate on the "query" field.
+func QueryEqualFold(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEqualFold(FieldQuery, v))
+}
+
+// QueryContainsFold applies the ContainsFold predicate on the "query" field.
+func QueryContainsFold(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldContainsFold(FieldQuery, v))
+}
+
+// FormatEQ applies the EQ predicate on the "format" field.
+func FormatEQ(v Format) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldFormat, v))
+}
+
+// FormatNEQ applies the NEQ predicate on the "format" field.
+func FormatNEQ(v Format) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldFormat, v))
+}
+
+// FormatIn applies the In predicate on the "format" field.
+func FormatIn(vs ...Format) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldFormat, vs...))
+}
+
+// FormatNotIn applies the NotIn predicate on the "format" field.
+func FormatNotIn(vs ...Format) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldFormat, vs...))
+}
+
+// StatusEQ applies the EQ predicate on the "status" field.
+func StatusEQ(v Status) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldStatus, v))
+}
+
+// StatusNEQ applies the NEQ predicate on the "status" field.
+func StatusNEQ(v Status) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldStatus, v))
+}
+
+// StatusIn applies the In predicate on the "status" field.
+func StatusIn(vs ...Status) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldStatus, vs...))
+}
+
+// StatusNotIn applies the NotIn predicate on the "status" field.
+func StatusNotIn(vs ...Status) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldStatus, vs...))
+}
+
+// ErrorEQ applies the EQ predicate on the "error" field.
+func ErrorEQ(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldError, v))
+}
+
+// ErrorNEQ applies the NEQ predicate on the "error" field.
+func ErrorNEQ(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldError, v))
+}
+
+// ErrorIn applies the In predicate on the "error" field.
+func ErrorIn(vs ...string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldError, vs...))
+}
+
+// ErrorNotIn applies the NotIn predicate on the "error" field.
+func ErrorNotIn(vs ...string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldError, vs...))
+}
+
+// ErrorGT applies the GT predicate on the "error" field.
+func ErrorGT(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldError, v))
+}
+
+// ErrorGTE applies the GTE predicate on the "error" field.
+func ErrorGTE(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldError, v))
+}
+
+// ErrorLT applies the LT predicate on the "error" field.
+func ErrorLT(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldError, v))
+}
+
+// ErrorLTE applies the LTE predicate on the "error" field.
+func ErrorLTE(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldError, v))
+}
+
+// ErrorContains applies the Contains predicate on the "error" field.
+func ErrorContains(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldContains(FieldError, v))
+}
+
+// ErrorHasPrefix applies the HasPrefix predicate on the "error" field.
+func ErrorHasPrefix(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldHasPrefix(FieldError, v))
+}
+
+// ErrorHasSuffix applies the HasSuffix predicate on the "error" field.
+func ErrorHasSuffix(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldHasSuffix(FieldError, v))
+}
+
+// ErrorEqualFold applies the EqualFold predicate on the "error" field.
+func ErrorEqualFold(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEqualFold(FieldError, v))
+}
+
+// ErrorContainsFold applies the ContainsFold predicate on the "error" field.
+func ErrorContainsFold(v string) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldContainsFold(FieldError, v))
+}
+
+// RowCountEQ applies the EQ predicate on the "row_count" field.
+func RowCountEQ(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldRowCount, v))
+}
+
+// RowCountNEQ applies the NEQ predicate on the "row_count" field.
+func RowCountNEQ(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldRowCount, v))
+}
+
+// RowCountIn applies the In predicate on the "row_count" field.
+func RowCountIn(vs ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldRowCount, vs...))
+}
+
+// RowCountNotIn applies the NotIn predicate on the "row_count" field.
+func RowCountNotIn(vs ...int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldRowCount, vs...))
+}
+
+// RowCountGT applies the GT predicate on the "row_count" field.
+func RowCountGT(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldRowCount, v))
+}
+
+// RowCountGTE applies the GTE predicate on the "row_count" field.
+func RowCountGTE(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldRowCount, v))
+}
+
+// RowCountLT applies the LT predicate on the "row_count" field.
+func RowCountLT(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldRowCount, v))
+}
+
+// RowCountLTE applies the LTE predicate on the "row_count" field.
+func RowCountLTE(v int) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldRowCount, v))
+}
+
+// TruncatedEQ applies the EQ predicate on the "truncated" field.
+func TruncatedEQ(v bool) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldTruncated, v))
+}
+
+// TruncatedNEQ applies the NEQ predicate on the "truncated" field.
+func TruncatedNEQ(v bool) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldTruncated, v))
+}
+
+// DurationMsEQ applies the EQ predicate on the "duration_ms" field.
+func DurationMsEQ(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldDurationMs, v))
+}
+
+// DurationMsNEQ applies the NEQ predicate on the "duration_ms" field.
+func DurationMsNEQ(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldDurationMs, v))
+}
+
+// DurationMsIn applies the In predicate on the "duration_ms" field.
+func DurationMsIn(vs ...int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldDurationMs, vs...))
+}
+
+// DurationMsNotIn applies the NotIn predicate on the "duration_ms" field.
+func DurationMsNotIn(vs ...int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldDurationMs, vs...))
+}
+
+// DurationMsGT applies the GT predicate on the "duration_ms" field.
+func DurationMsGT(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldDurationMs, v))
+}
+
+// DurationMsGTE applies the GTE predicate on the "duration_ms" field.
+func DurationMsGTE(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldDurationMs, v))
+}
+
+// DurationMsLT applies the LT predicate on the "duration_ms" field.
+func DurationMsLT(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldDurationMs, v))
+}
+
+// DurationMsLTE applies the LTE predicate on the "duration_ms" field.
+func DurationMsLTE(v int64) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldDurationMs, v))
+}
+
+// CreatedAtEQ applies the EQ predicate on the "created_at" field.
+func CreatedAtEQ(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtNEQ applies the NEQ predicate on the "created_at" field.
+func CreatedAtNEQ(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNEQ(FieldCreatedAt, v))
+}
+
+// CreatedAtIn applies the In predicate on the "created_at" field.
+func CreatedAtIn(vs ...time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtNotIn applies the NotIn predicate on the "created_at" field.
+func CreatedAtNotIn(vs ...time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldNotIn(FieldCreatedAt, vs...))
+}
+
+// CreatedAtGT applies the GT predicate on the "created_at" field.
+func CreatedAtGT(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGT(FieldCreatedAt, v))
+}
+
+// CreatedAtGTE applies the GTE predicate on the "created_at" field.
+func CreatedAtGTE(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldGTE(FieldCreatedAt, v))
+}
+
+// CreatedAtLT applies the LT predicate on the "created_at" field.
+func CreatedAtLT(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLT(FieldCreatedAt, v))
+}
+
+// CreatedAtLTE applies the LTE predicate on the "created_at" field.
+func CreatedAtLTE(v time.Time) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.FieldLTE(FieldCreatedAt, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.ConsoleQuery) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.ConsoleQuery) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.ConsoleQuery) predicate.ConsoleQuery {
+	return predicate.ConsoleQuery(sql.NotPredicates(p))
+}
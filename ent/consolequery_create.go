@@ -0,0 +1,364 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/consolequery"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ConsoleQueryCreate is the builder for creating a ConsoleQuery entity.
+type ConsoleQueryCreate struct {
+	config
+	mutation *ConsoleQueryMutation
+	hooks    []Hook
+}
+
+// SetUserID sets the "user_id" field.
+func (cqc *ConsoleQueryCreate) SetUserID(i int) *ConsoleQueryCreate {
+	cqc.mutation.SetUserID(i)
+	return cqc
+}
+
+// SetQuery sets the "query" field.
+func (cqc *ConsoleQueryCreate) SetQuery(s string) *ConsoleQueryCreate {
+	cqc.mutation.SetQuery(s)
+	return cqc
+}
+
+// SetFormat sets the "format" field.
+func (cqc *ConsoleQueryCreate) SetFormat(c consolequery.Format) *ConsoleQueryCreate {
+	cqc.mutation.SetFormat(c)
+	return cqc
+}
+
+// SetStatus sets the "status" field.
+func (cqc *ConsoleQueryCreate) SetStatus(c consolequery.Status) *ConsoleQueryCreate {
+	cqc.mutation.SetStatus(c)
+	return cqc
+}
+
+// SetError sets the "error" field.
+func (cqc *ConsoleQueryCreate) SetError(s string) *ConsoleQueryCreate {
+	cqc.mutation.SetError(s)
+	return cqc
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (cqc *ConsoleQueryCreate) SetNillableError(s *string) *ConsoleQueryCreate {
+	if s != nil {
+		cqc.SetError(*s)
+	}
+	return cqc
+}
+
+// SetRowCount sets the "row_count" field.
+func (cqc *ConsoleQueryCreate) SetRowCount(i int) *ConsoleQueryCreate {
+	cqc.mutation.SetRowCount(i)
+	return cqc
+}
+
+// SetNillableRowCount sets the "row_count" field if the given value is not nil.
+func (cqc *ConsoleQueryCreate) SetNillableRowCount(i *int) *ConsoleQueryCreate {
+	if i != nil {
+		cqc.SetRowCount(*i)
+	}
+	return cqc
+}
+
+// SetTruncated sets the "truncated" field.
+func (cqc *ConsoleQueryCreate) SetTruncated(b bool) *ConsoleQueryCreate {
+	cqc.mutation.SetTruncated(b)
+	return cqc
+}
+
+// SetNillableTruncated sets the "truncated" field if the given value is not nil.
+func (cqc *ConsoleQueryCreate) SetNillableTruncated(b *bool) *ConsoleQueryCreate {
+	if b != nil {
+		cqc.SetTruncated(*b)
+	}
+	return cqc
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (cqc *ConsoleQueryCreate) SetDurationMs(i int64) *ConsoleQueryCreate {
+	cqc.mutation.SetDurationMs(i)
+	return cqc
+}
+
+// SetNillableDurationMs sets the "duration_ms" field if the given value is not nil.
+func (cqc *ConsoleQueryCreate) SetNillableDurationMs(i *int64) *ConsoleQueryCreate {
+	if i != nil {
+		cqc.SetDurationMs(*i)
+	}
+	return cqc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (cqc *ConsoleQueryCreate) SetCreatedAt(t time.Time) *ConsoleQueryCreate {
+	cqc.mutation.SetCreatedAt(t)
+	return cqc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (cqc *ConsoleQueryCreate) SetNillableCreatedAt(t *time.Time) *ConsoleQueryCreate {
+	if t != nil {
+		cqc.SetCreatedAt(*t)
+	}
+	return cqc
+}
+
+// Mutation returns the ConsoleQueryMutation object of the builder.
+func (cqc *ConsoleQueryCreate) Mutation() *ConsoleQueryMutation {
+	return cqc.mutation
+}
+
+// Save creates the ConsoleQuery in the database.
+func (cqc *ConsoleQueryCreate) Save(ctx context.Context) (*ConsoleQuery, error) {
+	cqc.defaults()
+	return withHooks(ctx, cqc.sqlSave, cqc.mutation, cqc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (cqc *ConsoleQueryCreate) SaveX(ctx context.Context) *ConsoleQuery {
+	v, err := cqc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cqc *ConsoleQueryCreate) Exec(ctx context.Context) error {
+	_, err := cqc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqc *ConsoleQueryCreate) ExecX(ctx context.Context) {
+	if err := cqc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (cqc *ConsoleQueryCreate) defaults() {
+	if _, ok := cqc.mutation.Error(); !ok {
+		v := consolequery.DefaultError
+		cqc.mutation.SetError(v)
+	}
+	if _, ok := cqc.mutation.RowCount(); !ok {
+		v := consolequery.DefaultRowCount
+		cqc.mutation.SetRowCount(v)
+	}
+	if _, ok := cqc.mutation.Truncated(); !ok {
+		v := consolequery.DefaultTruncated
+		cqc.mutation.SetTruncated(v)
+	}
+	if _, ok := cqc.mutation.DurationMs(); !ok {
+		v := consolequery.DefaultDurationMs
+		cqc.mutation.SetDurationMs(v)
+	}
+	if _, ok := cqc.mutation.CreatedAt(); !ok {
+		v := consolequery.DefaultCreatedAt()
+		cqc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cqc *ConsoleQueryCreate) check() error {
+	if _, ok := cqc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "ConsoleQuery.user_id"`)}
+	}
+	if _, ok := cqc.mutation.Query(); !ok {
+		return &ValidationError{Name: "query", err: errors.New(`ent: missing required field "ConsoleQuery.query"`)}
+	}
+	if _, ok := cqc.mutation.Format(); !ok {
+		return &ValidationError{Name: "format", err: errors.New(`ent: missing required field "ConsoleQuery.format"`)}
+	}
+	if v, ok := cqc.mutation.Format(); ok {
+		if err := consolequery.FormatValidator(v); err != nil {
+			return &ValidationError{Name: "format", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.format": %w`, err)}
+		}
+	}
+	if _, ok := cqc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "ConsoleQuery.status"`)}
+	}
+	if v, ok := cqc.mutation.Status(); ok {
+		if err := consolequery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.status": %w`, err)}
+		}
+	}
+	if _, ok := cqc.mutation.Error(); !ok {
+		return &ValidationError{Name: "error", err: errors.New(`ent: missing required field "ConsoleQuery.error"`)}
+	}
+	if _, ok := cqc.mutation.RowCount(); !ok {
+		return &ValidationError{Name: "row_count", err: errors.New(`ent: missing required field "ConsoleQuery.row_count"`)}
+	}
+	if _, ok := cqc.mutation.Truncated(); !ok {
+		return &ValidationError{Name: "truncated", err: errors.New(`ent: missing required field "ConsoleQuery.truncated"`)}
+	}
+	if _, ok := cqc.mutation.DurationMs(); !ok {
+		return &ValidationError{Name: "duration_ms", err: errors.New(`ent: missing required field "ConsoleQuery.duration_ms"`)}
+	}
+	if _, ok := cqc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ConsoleQuery.created_at"`)}
+	}
+	return nil
+}
+
+func (cqc *ConsoleQueryCreate) sqlSave(ctx context.Context) (*ConsoleQuery, error) {
+	if err := cqc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := cqc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, cqc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	cqc.mutation.id = &_node.ID
+	cqc.mutation.done = true
+	return _node, nil
+}
+
+func (cqc *ConsoleQueryCreate) createSpec() (*ConsoleQuery, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ConsoleQuery{config: cqc.config}
+		_spec = sqlgraph.NewCreateSpec(consolequery.Table, sqlgraph.NewFieldSpec(consolequery.FieldID, field.TypeInt))
+	)
+	if value, ok := cqc.mutation.UserID(); ok {
+		_spec.SetField(consolequery.FieldUserID, field.TypeInt, value)
+		_node.UserID = value
+	}
+	if value, ok := cqc.mutation.Query(); ok {
+		_spec.SetField(consolequery.FieldQuery, field.TypeString, value)
+		_node.Query = value
+	}
+	if value, ok := cqc.mutation.Format(); ok {
+		_spec.SetField(consolequery.FieldFormat, field.TypeEnum, value)
+		_node.Format = value
+	}
+	if value, ok := cqc.mutation.Status(); ok {
+		_spec.SetField(consolequery.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := cqc.mutation.Error(); ok {
+		_spec.SetField(consolequery.FieldError, field.TypeString, value)
+		_node.Error = value
+	}
+	if value, ok := cqc.mutation.RowCount(); ok {
+		_spec.SetField(consolequery.FieldRowCount, field.TypeInt, value)
+		_node.RowCount = value
+	}
+	if value, ok := cqc.mutation.Truncated(); ok {
+		_spec.SetField(consolequery.FieldTruncated, field.TypeBool, value)
+		_node.Truncated = value
+	}
+	if value, ok := cqc.mutation.DurationMs(); ok {
+		_spec.SetField(consolequery.FieldDurationMs, field.TypeInt64, value)
+		_node.DurationMs = value
+	}
+	if value, ok := cqc.mutation.CreatedAt(); ok {
+		_spec.SetField(consolequery.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	return _node, _spec
+}
+
+// ConsoleQueryCreateBulk is the builder for creating many ConsoleQuery entities in bulk.
+type ConsoleQueryCreateBulk struct {
+	config
+	err      error
+	builders []*ConsoleQueryCreate
+}
+
+// Save creates the ConsoleQuery entities in the database.
+func (cqcb *ConsoleQueryCreateBulk) Save(ctx context.Context) ([]*ConsoleQuery, error) {
+	if cqcb.err != nil {
+		return nil, cqcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(cqcb.builders))
+	nodes := make([]*ConsoleQuery, len(cqcb.builders))
+	mutators := make([]Mutator, len(cqcb.builders))
+	for i := range cqcb.builders {
+		func(i int, root context.Context) {
+			builder := cqcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ConsoleQueryMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, cqcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, cqcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, cqcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cqcb *ConsoleQueryCreateBulk) SaveX(ctx context.Context) []*ConsoleQuery {
+	v, err := cqcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (cqcb *ConsoleQueryCreateBulk) Exec(ctx context.Context) error {
+	_, err := cqcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqcb *ConsoleQueryCreateBulk) ExecX(ctx context.Context) {
+	if err := cqcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"go-template/ent/consolequery"
+	"go-template/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ConsoleQueryDelete is the builder for deleting a ConsoleQuery entity.
+type ConsoleQueryDelete struct {
+	config
+	hooks    []Hook
+	mutation *ConsoleQueryMutation
+}
+
+// Where appends a list predicates to the ConsoleQueryDelete builder.
+func (cqd *ConsoleQueryDelete) Where(ps ...predicate.ConsoleQuery) *ConsoleQueryDelete {
+	cqd.mutation.Where(ps...)
+	return cqd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (cqd *ConsoleQueryDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, cqd.sqlExec, cqd.mutation, cqd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqd *ConsoleQueryDelete) ExecX(ctx context.Context) int {
+	n, err := cqd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (cqd *ConsoleQueryDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(consolequery.Table, sqlgraph.NewFieldSpec(consolequery.FieldID, field.TypeInt))
+	if ps := cqd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, cqd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	cqd.mutation.done = true
+	return affected, err
+}
+
+// ConsoleQueryDeleteOne is the builder for deleting a single ConsoleQuery entity.
+type ConsoleQueryDeleteOne struct {
+	cqd *ConsoleQueryDelete
+}
+
+// Where appends a list predicates to the ConsoleQueryDelete builder.
+func (cqdo *ConsoleQueryDeleteOne) Where(ps ...predicate.ConsoleQuery) *ConsoleQueryDeleteOne {
+	cqdo.cqd.mutation.Where(ps...)
+	return cqdo
+}
+
+// Exec executes the deletion query.
+func (cqdo *ConsoleQueryDeleteOne) Exec(ctx context.Context) error {
+	n, err := cqdo.cqd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{consolequery.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqdo *ConsoleQueryDeleteOne) ExecX(ctx context.Context) {
+	if err := cqdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
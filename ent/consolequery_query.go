@@ -0,0 +1,527 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-template/ent/consolequery"
+	"go-template/ent/predicate"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ConsoleQueryQuery is the builder for querying ConsoleQuery entities.
+type ConsoleQueryQuery struct {
+	config
+	ctx        *QueryContext
+	order      []consolequery.OrderOption
+	inters     []Interceptor
+	predicates []predicate.ConsoleQuery
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the ConsoleQueryQuery builder.
+func (cqq *ConsoleQueryQuery) Where(ps ...predicate.ConsoleQuery) *ConsoleQueryQuery {
+	cqq.predicates = append(cqq.predicates, ps...)
+	return cqq
+}
+
+// Limit the number of records to be returned by this query.
+func (cqq *ConsoleQueryQuery) Limit(limit int) *ConsoleQueryQuery {
+	cqq.ctx.Limit = &limit
+	return cqq
+}
+
+// Offset to start from.
+func (cqq *ConsoleQueryQuery) Offset(offset int) *ConsoleQueryQuery {
+	cqq.ctx.Offset = &offset
+	return cqq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (cqq *ConsoleQueryQuery) Unique(unique bool) *ConsoleQueryQuery {
+	cqq.ctx.Unique = &unique
+	return cqq
+}
+
+// Order specifies how the records should be ordered.
+func (cqq *ConsoleQueryQuery) Order(o ...consolequery.OrderOption) *ConsoleQueryQuery {
+	cqq.order = append(cqq.order, o...)
+	return cqq
+}
+
+// First returns the first ConsoleQuery entity from the query.
+// Returns a *NotFoundError when no ConsoleQuery was found.
+func (cqq *ConsoleQueryQuery) First(ctx context.Context) (*ConsoleQuery, error) {
+	nodes, err := cqq.Limit(1).All(setContextOp(ctx, cqq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{consolequery.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) FirstX(ctx context.Context) *ConsoleQuery {
+	node, err := cqq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first ConsoleQuery ID from the query.
+// Returns a *NotFoundError when no ConsoleQuery ID was found.
+func (cqq *ConsoleQueryQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = cqq.Limit(1).IDs(setContextOp(ctx, cqq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{consolequery.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) FirstIDX(ctx context.Context) int {
+	id, err := cqq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single ConsoleQuery entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one ConsoleQuery entity is found.
+// Returns a *NotFoundError when no ConsoleQuery entities are found.
+func (cqq *ConsoleQueryQuery) Only(ctx context.Context) (*ConsoleQuery, error) {
+	nodes, err := cqq.Limit(2).All(setContextOp(ctx, cqq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{consolequery.Label}
+	default:
+		return nil, &NotSingularError{consolequery.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) OnlyX(ctx context.Context) *ConsoleQuery {
+	node, err := cqq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only ConsoleQuery ID in the query.
+// Returns a *NotSingularError when more than one ConsoleQuery ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (cqq *ConsoleQueryQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = cqq.Limit(2).IDs(setContextOp(ctx, cqq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{consolequery.Label}
+	default:
+		err = &NotSingularError{consolequery.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) OnlyIDX(ctx context.Context) int {
+	id, err := cqq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of ConsoleQueries.
+func (cqq *ConsoleQueryQuery) All(ctx context.Context) ([]*ConsoleQuery, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryAll)
+	if err := cqq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*ConsoleQuery, *ConsoleQueryQuery]()
+	return withInterceptors[[]*ConsoleQuery](ctx, cqq, qr, cqq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) AllX(ctx context.Context) []*ConsoleQuery {
+	nodes, err := cqq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of ConsoleQuery IDs.
+func (cqq *ConsoleQueryQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if cqq.ctx.Unique == nil && cqq.path != nil {
+		cqq.Unique(true)
+	}
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryIDs)
+	if err = cqq.Select(consolequery.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) IDsX(ctx context.Context) []int {
+	ids, err := cqq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (cqq *ConsoleQueryQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryCount)
+	if err := cqq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, cqq, querierCount[*ConsoleQueryQuery](), cqq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) CountX(ctx context.Context) int {
+	count, err := cqq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (cqq *ConsoleQueryQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, cqq.ctx, ent.OpQueryExist)
+	switch _, err := cqq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (cqq *ConsoleQueryQuery) ExistX(ctx context.Context) bool {
+	exist, err := cqq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the ConsoleQueryQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (cqq *ConsoleQueryQuery) Clone() *ConsoleQueryQuery {
+	if cqq == nil {
+		return nil
+	}
+	return &ConsoleQueryQuery{
+		config:     cqq.config,
+		ctx:        cqq.ctx.Clone(),
+		order:      append([]consolequery.OrderOption{}, cqq.order...),
+		inters:     append([]Interceptor{}, cqq.inters...),
+		predicates: append([]predicate.ConsoleQuery{}, cqq.predicates...),
+		// clone intermediate query.
+		sql:  cqq.sql.Clone(),
+		path: cqq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int `json:"user_id,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.ConsoleQuery.Query().
+//		GroupBy(consolequery.FieldUserID).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (cqq *ConsoleQueryQuery) GroupBy(field string, fields ...string) *ConsoleQueryGroupBy {
+	cqq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &ConsoleQueryGroupBy{build: cqq}
+	grbuild.flds = &cqq.ctx.Fields
+	grbuild.label = consolequery.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		UserID int `json:"user_id,omitempty"`
+//	}
+//
+//	client.ConsoleQuery.Query().
+//		Select(consolequery.FieldUserID).
+//		Scan(ctx, &v)
+func (cqq *ConsoleQueryQuery) Select(fields ...string) *ConsoleQuerySelect {
+	cqq.ctx.Fields = append(cqq.ctx.Fields, fields...)
+	sbuild := &ConsoleQuerySelect{ConsoleQueryQuery: cqq}
+	sbuild.label = consolequery.Label
+	sbuild.flds, sbuild.scan = &cqq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a ConsoleQuerySelect configured with the given aggregations.
+func (cqq *ConsoleQueryQuery) Aggregate(fns ...AggregateFunc) *ConsoleQuerySelect {
+	return cqq.Select().Aggregate(fns...)
+}
+
+func (cqq *ConsoleQueryQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range cqq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, cqq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range cqq.ctx.Fields {
+		if !consolequery.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if cqq.path != nil {
+		prev, err := cqq.path(ctx)
+		if err != nil {
+			return err
+		}
+		cqq.sql = prev
+	}
+	return nil
+}
+
+func (cqq *ConsoleQueryQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*ConsoleQuery, error) {
+	var (
+		nodes = []*ConsoleQuery{}
+		_spec = cqq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*ConsoleQuery).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &ConsoleQuery{config: cqq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, cqq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (cqq *ConsoleQueryQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := cqq.querySpec()
+	_spec.Node.Columns = cqq.ctx.Fields
+	if len(cqq.ctx.Fields) > 0 {
+		_spec.Unique = cqq.ctx.Unique != nil && *cqq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, cqq.driver, _spec)
+}
+
+func (cqq *ConsoleQueryQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(consolequery.Table, consolequery.Columns, sqlgraph.NewFieldSpec(consolequery.FieldID, field.TypeInt))
+	_spec.From = cqq.sql
+	if unique := cqq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if cqq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := cqq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, consolequery.FieldID)
+		for i := range fields {
+			if fields[i] != consolequery.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := cqq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := cqq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := cqq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := cqq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (cqq *ConsoleQueryQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(cqq.driver.Dialect())
+	t1 := builder.Table(consolequery.Table)
+	columns := cqq.ctx.Fields
+	if len(columns) == 0 {
+		columns = consolequery.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if cqq.sql != nil {
+		selector = cqq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if cqq.ctx.Unique != nil && *cqq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range cqq.predicates {
+		p(selector)
+	}
+	for _, p := range cqq.order {
+		p(selector)
+	}
+	if offset := cqq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := cqq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ConsoleQueryGroupBy is the group-by builder for ConsoleQuery entities.
+type ConsoleQueryGroupBy struct {
+	selector
+	build *ConsoleQueryQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (cqgb *ConsoleQueryGroupBy) Aggregate(fns ...AggregateFunc) *ConsoleQueryGroupBy {
+	cqgb.fns = append(cqgb.fns, fns...)
+	return cqgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cqgb *ConsoleQueryGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cqgb.build.ctx, ent.OpQueryGroupBy)
+	if err := cqgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ConsoleQueryQuery, *ConsoleQueryGroupBy](ctx, cqgb.build, cqgb, cqgb.build.inters, v)
+}
+
+func (cqgb *ConsoleQueryGroupBy) sqlScan(ctx context.Context, root *ConsoleQueryQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(cqgb.fns))
+	for _, fn := range cqgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*cqgb.flds)+len(cqgb.fns))
+		for _, f := range *cqgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*cqgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cqgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// ConsoleQuerySelect is the builder for selecting fields of ConsoleQuery entities.
+type ConsoleQuerySelect struct {
+	*ConsoleQueryQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (cqs *ConsoleQuerySelect) Aggregate(fns ...AggregateFunc) *ConsoleQuerySelect {
+	cqs.fns = append(cqs.fns, fns...)
+	return cqs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (cqs *ConsoleQuerySelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, cqs.ctx, ent.OpQuerySelect)
+	if err := cqs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*ConsoleQueryQuery, *ConsoleQuerySelect](ctx, cqs.ConsoleQueryQuery, cqs, cqs.inters, v)
+}
+
+func (cqs *ConsoleQuerySelect) sqlScan(ctx context.Context, root *ConsoleQueryQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(cqs.fns))
+	for _, fn := range cqs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*cqs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := cqs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
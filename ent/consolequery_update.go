@@ -0,0 +1,543 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/consolequery"
+	"go-template/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ConsoleQueryUpdate is the builder for updating ConsoleQuery entities.
+type ConsoleQueryUpdate struct {
+	config
+	hooks    []Hook
+	mutation *ConsoleQueryMutation
+}
+
+// Where appends a list predicates to the ConsoleQueryUpdate builder.
+func (cqu *ConsoleQueryUpdate) Where(ps ...predicate.ConsoleQuery) *ConsoleQueryUpdate {
+	cqu.mutation.Where(ps...)
+	return cqu
+}
+
+// SetUserID sets the "user_id" field.
+func (cqu *ConsoleQueryUpdate) SetUserID(i int) *ConsoleQueryUpdate {
+	cqu.mutation.ResetUserID()
+	cqu.mutation.SetUserID(i)
+	return cqu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableUserID(i *int) *ConsoleQueryUpdate {
+	if i != nil {
+		cqu.SetUserID(*i)
+	}
+	return cqu
+}
+
+// AddUserID adds i to the "user_id" field.
+func (cqu *ConsoleQueryUpdate) AddUserID(i int) *ConsoleQueryUpdate {
+	cqu.mutation.AddUserID(i)
+	return cqu
+}
+
+// SetQuery sets the "query" field.
+func (cqu *ConsoleQueryUpdate) SetQuery(s string) *ConsoleQueryUpdate {
+	cqu.mutation.SetQuery(s)
+	return cqu
+}
+
+// SetNillableQuery sets the "query" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableQuery(s *string) *ConsoleQueryUpdate {
+	if s != nil {
+		cqu.SetQuery(*s)
+	}
+	return cqu
+}
+
+// SetFormat sets the "format" field.
+func (cqu *ConsoleQueryUpdate) SetFormat(c consolequery.Format) *ConsoleQueryUpdate {
+	cqu.mutation.SetFormat(c)
+	return cqu
+}
+
+// SetNillableFormat sets the "format" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableFormat(c *consolequery.Format) *ConsoleQueryUpdate {
+	if c != nil {
+		cqu.SetFormat(*c)
+	}
+	return cqu
+}
+
+// SetStatus sets the "status" field.
+func (cqu *ConsoleQueryUpdate) SetStatus(c consolequery.Status) *ConsoleQueryUpdate {
+	cqu.mutation.SetStatus(c)
+	return cqu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableStatus(c *consolequery.Status) *ConsoleQueryUpdate {
+	if c != nil {
+		cqu.SetStatus(*c)
+	}
+	return cqu
+}
+
+// SetError sets the "error" field.
+func (cqu *ConsoleQueryUpdate) SetError(s string) *ConsoleQueryUpdate {
+	cqu.mutation.SetError(s)
+	return cqu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableError(s *string) *ConsoleQueryUpdate {
+	if s != nil {
+		cqu.SetError(*s)
+	}
+	return cqu
+}
+
+// SetRowCount sets the "row_count" field.
+func (cqu *ConsoleQueryUpdate) SetRowCount(i int) *ConsoleQueryUpdate {
+	cqu.mutation.ResetRowCount()
+	cqu.mutation.SetRowCount(i)
+	return cqu
+}
+
+// SetNillableRowCount sets the "row_count" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableRowCount(i *int) *ConsoleQueryUpdate {
+	if i != nil {
+		cqu.SetRowCount(*i)
+	}
+	return cqu
+}
+
+// AddRowCount adds i to the "row_count" field.
+func (cqu *ConsoleQueryUpdate) AddRowCount(i int) *ConsoleQueryUpdate {
+	cqu.mutation.AddRowCount(i)
+	return cqu
+}
+
+// SetTruncated sets the "truncated" field.
+func (cqu *ConsoleQueryUpdate) SetTruncated(b bool) *ConsoleQueryUpdate {
+	cqu.mutation.SetTruncated(b)
+	return cqu
+}
+
+// SetNillableTruncated sets the "truncated" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableTruncated(b *bool) *ConsoleQueryUpdate {
+	if b != nil {
+		cqu.SetTruncated(*b)
+	}
+	return cqu
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (cqu *ConsoleQueryUpdate) SetDurationMs(i int64) *ConsoleQueryUpdate {
+	cqu.mutation.ResetDurationMs()
+	cqu.mutation.SetDurationMs(i)
+	return cqu
+}
+
+// SetNillableDurationMs sets the "duration_ms" field if the given value is not nil.
+func (cqu *ConsoleQueryUpdate) SetNillableDurationMs(i *int64) *ConsoleQueryUpdate {
+	if i != nil {
+		cqu.SetDurationMs(*i)
+	}
+	return cqu
+}
+
+// AddDurationMs adds i to the "duration_ms" field.
+func (cqu *ConsoleQueryUpdate) AddDurationMs(i int64) *ConsoleQueryUpdate {
+	cqu.mutation.AddDurationMs(i)
+	return cqu
+}
+
+// Mutation returns the ConsoleQueryMutation object of the builder.
+func (cqu *ConsoleQueryUpdate) Mutation() *ConsoleQueryMutation {
+	return cqu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (cqu *ConsoleQueryUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, cqu.sqlSave, cqu.mutation, cqu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cqu *ConsoleQueryUpdate) SaveX(ctx context.Context) int {
+	affected, err := cqu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (cqu *ConsoleQueryUpdate) Exec(ctx context.Context) error {
+	_, err := cqu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cqu *ConsoleQueryUpdate) ExecX(ctx context.Context) {
+	if err := cqu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cqu *ConsoleQueryUpdate) check() error {
+	if v, ok := cqu.mutation.Format(); ok {
+		if err := consolequery.FormatValidator(v); err != nil {
+			return &ValidationError{Name: "format", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.format": %w`, err)}
+		}
+	}
+	if v, ok := cqu.mutation.Status(); ok {
+		if err := consolequery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cqu *ConsoleQueryUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := cqu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(consolequery.Table, consolequery.Columns, sqlgraph.NewFieldSpec(consolequery.FieldID, field.TypeInt))
+	if ps := cqu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cqu.mutation.UserID(); ok {
+		_spec.SetField(consolequery.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.AddedUserID(); ok {
+		_spec.AddField(consolequery.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.Query(); ok {
+		_spec.SetField(consolequery.FieldQuery, field.TypeString, value)
+	}
+	if value, ok := cqu.mutation.Format(); ok {
+		_spec.SetField(consolequery.FieldFormat, field.TypeEnum, value)
+	}
+	if value, ok := cqu.mutation.Status(); ok {
+		_spec.SetField(consolequery.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := cqu.mutation.Error(); ok {
+		_spec.SetField(consolequery.FieldError, field.TypeString, value)
+	}
+	if value, ok := cqu.mutation.RowCount(); ok {
+		_spec.SetField(consolequery.FieldRowCount, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.AddedRowCount(); ok {
+		_spec.AddField(consolequery.FieldRowCount, field.TypeInt, value)
+	}
+	if value, ok := cqu.mutation.Truncated(); ok {
+		_spec.SetField(consolequery.FieldTruncated, field.TypeBool, value)
+	}
+	if value, ok := cqu.mutation.DurationMs(); ok {
+		_spec.SetField(consolequery.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := cqu.mutation.AddedDurationMs(); ok {
+		_spec.AddField(consolequery.FieldDurationMs, field.TypeInt64, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, cqu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consolequery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	cqu.mutation.done = true
+	return n, nil
+}
+
+// ConsoleQueryUpdateOne is the builder for updating a single ConsoleQuery entity.
+type ConsoleQueryUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *ConsoleQueryMutation
+}
+
+// SetUserID sets the "user_id" field.
+func (cquo *ConsoleQueryUpdateOne) SetUserID(i int) *ConsoleQueryUpdateOne {
+	cquo.mutation.ResetUserID()
+	cquo.mutation.SetUserID(i)
+	return cquo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableUserID(i *int) *ConsoleQueryUpdateOne {
+	if i != nil {
+		cquo.SetUserID(*i)
+	}
+	return cquo
+}
+
+// AddUserID adds i to the "user_id" field.
+func (cquo *ConsoleQueryUpdateOne) AddUserID(i int) *ConsoleQueryUpdateOne {
+	cquo.mutation.AddUserID(i)
+	return cquo
+}
+
+// SetQuery sets the "query" field.
+func (cquo *ConsoleQueryUpdateOne) SetQuery(s string) *ConsoleQueryUpdateOne {
+	cquo.mutation.SetQuery(s)
+	return cquo
+}
+
+// SetNillableQuery sets the "query" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableQuery(s *string) *ConsoleQueryUpdateOne {
+	if s != nil {
+		cquo.SetQuery(*s)
+	}
+	return cquo
+}
+
+// SetFormat sets the "format" field.
+func (cquo *ConsoleQueryUpdateOne) SetFormat(c consolequery.Format) *ConsoleQueryUpdateOne {
+	cquo.mutation.SetFormat(c)
+	return cquo
+}
+
+// SetNillableFormat sets the "format" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableFormat(c *consolequery.Format) *ConsoleQueryUpdateOne {
+	if c != nil {
+		cquo.SetFormat(*c)
+	}
+	return cquo
+}
+
+// SetStatus sets the "status" field.
+func (cquo *ConsoleQueryUpdateOne) SetStatus(c consolequery.Status) *ConsoleQueryUpdateOne {
+	cquo.mutation.SetStatus(c)
+	return cquo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableStatus(c *consolequery.Status) *ConsoleQueryUpdateOne {
+	if c != nil {
+		cquo.SetStatus(*c)
+	}
+	return cquo
+}
+
+// SetError sets the "error" field.
+func (cquo *ConsoleQueryUpdateOne) SetError(s string) *ConsoleQueryUpdateOne {
+	cquo.mutation.SetError(s)
+	return cquo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableError(s *string) *ConsoleQueryUpdateOne {
+	if s != nil {
+		cquo.SetError(*s)
+	}
+	return cquo
+}
+
+// SetRowCount sets the "row_count" field.
+func (cquo *ConsoleQueryUpdateOne) SetRowCount(i int) *ConsoleQueryUpdateOne {
+	cquo.mutation.ResetRowCount()
+	cquo.mutation.SetRowCount(i)
+	return cquo
+}
+
+// SetNillableRowCount sets the "row_count" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableRowCount(i *int) *ConsoleQueryUpdateOne {
+	if i != nil {
+		cquo.SetRowCount(*i)
+	}
+	return cquo
+}
+
+// AddRowCount adds i to the "row_count" field.
+func (cquo *ConsoleQueryUpdateOne) AddRowCount(i int) *ConsoleQueryUpdateOne {
+	cquo.mutation.AddRowCount(i)
+	return cquo
+}
+
+// SetTruncated sets the "truncated" field.
+func (cquo *ConsoleQueryUpdateOne) SetTruncated(b bool) *ConsoleQueryUpdateOne {
+	cquo.mutation.SetTruncated(b)
+	return cquo
+}
+
+// SetNillableTruncated sets the "truncated" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableTruncated(b *bool) *ConsoleQueryUpdateOne {
+	if b != nil {
+		cquo.SetTruncated(*b)
+	}
+	return cquo
+}
+
+// SetDurationMs sets the "duration_ms" field.
+func (cquo *ConsoleQueryUpdateOne) SetDurationMs(i int64) *ConsoleQueryUpdateOne {
+	cquo.mutation.ResetDurationMs()
+	cquo.mutation.SetDurationMs(i)
+	return cquo
+}
+
+// SetNillableDurationMs sets the "duration_ms" field if the given value is not nil.
+func (cquo *ConsoleQueryUpdateOne) SetNillableDurationMs(i *int64) *ConsoleQueryUpdateOne {
+	if i != nil {
+		cquo.SetDurationMs(*i)
+	}
+	return cquo
+}
+
+// AddDurationMs adds i to the "duration_ms" field.
+func (cquo *ConsoleQueryUpdateOne) AddDurationMs(i int64) *ConsoleQueryUpdateOne {
+	cquo.mutation.AddDurationMs(i)
+	return cquo
+}
+
+// Mutation returns the ConsoleQueryMutation object of the builder.
+func (cquo *ConsoleQueryUpdateOne) Mutation() *ConsoleQueryMutation {
+	return cquo.mutation
+}
+
+// Where appends a list predicates to the ConsoleQueryUpdate builder.
+func (cquo *ConsoleQueryUpdateOne) Where(ps ...predicate.ConsoleQuery) *ConsoleQueryUpdateOne {
+	cquo.mutation.Where(ps...)
+	return cquo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (cquo *ConsoleQueryUpdateOne) Select(field string, fields ...string) *ConsoleQueryUpdateOne {
+	cquo.fields = append([]string{field}, fields...)
+	return cquo
+}
+
+// Save executes the query and returns the updated ConsoleQuery entity.
+func (cquo *ConsoleQueryUpdateOne) Save(ctx context.Context) (*ConsoleQuery, error) {
+	return withHooks(ctx, cquo.sqlSave, cquo.mutation, cquo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (cquo *ConsoleQueryUpdateOne) SaveX(ctx context.Context) *ConsoleQuery {
+	node, err := cquo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (cquo *ConsoleQueryUpdateOne) Exec(ctx context.Context) error {
+	_, err := cquo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (cquo *ConsoleQueryUpdateOne) ExecX(ctx context.Context) {
+	if err := cquo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (cquo *ConsoleQueryUpdateOne) check() error {
+	if v, ok := cquo.mutation.Format(); ok {
+		if err := consolequery.FormatValidator(v); err != nil {
+			return &ValidationError{Name: "format", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.format": %w`, err)}
+		}
+	}
+	if v, ok := cquo.mutation.Status(); ok {
+		if err := consolequery.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "ConsoleQuery.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (cquo *ConsoleQueryUpdateOne) sqlSave(ctx context.Context) (_node *ConsoleQuery, err error) {
+	if err := cquo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(consolequery.Table, consolequery.Columns, sqlgraph.NewFieldSpec(consolequery.FieldID, field.TypeInt))
+	id, ok := cquo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "ConsoleQuery.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := cquo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, consolequery.FieldID)
+		for _, f := range fields {
+			if !consolequery.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != consolequery.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := cquo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := cquo.mutation.UserID(); ok {
+		_spec.SetField(consolequery.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.AddedUserID(); ok {
+		_spec.AddField(consolequery.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.Query(); ok {
+		_spec.SetField(consolequery.FieldQuery, field.TypeString, value)
+	}
+	if value, ok := cquo.mutation.Format(); ok {
+		_spec.SetField(consolequery.FieldFormat, field.TypeEnum, value)
+	}
+	if value, ok := cquo.mutation.Status(); ok {
+		_spec.SetField(consolequery.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := cquo.mutation.Error(); ok {
+		_spec.SetField(consolequery.FieldError, field.TypeString, value)
+	}
+	if value, ok := cquo.mutation.RowCount(); ok {
+		_spec.SetField(consolequery.FieldRowCount, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.AddedRowCount(); ok {
+		_spec.AddField(consolequery.FieldRowCount, field.TypeInt, value)
+	}
+	if value, ok := cquo.mutation.Truncated(); ok {
+		_spec.SetField(consolequery.FieldTruncated, field.TypeBool, value)
+	}
+	if value, ok := cquo.mutation.DurationMs(); ok {
+		_spec.SetField(consolequery.FieldDurationMs, field.TypeInt64, value)
+	}
+	if value, ok := cquo.mutation.AddedDurationMs(); ok {
+		_spec.AddField(consolequery.FieldDurationMs, field.TypeInt64, value)
+	}
+	_node = &ConsoleQuery{config: cquo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, cquo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{consolequery.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	cquo.mutation.done = true
+	return _node, nil
+}
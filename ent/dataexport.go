@@ -0,0 +1,172 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-template/ent/dataexport"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// DataExport is the model entity for the DataExport schema.
+type DataExport struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// UserID holds the value of the "user_id" field.
+	UserID int `json:"user_id,omitempty"`
+	// Status holds the value of the "status" field.
+	Status dataexport.Status `json:"status,omitempty"`
+	// File holds the value of the "file" field.
+	File string `json:"file,omitempty"`
+	// Error holds the value of the "error" field.
+	Error string `json:"error,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt    time.Time `json:"expires_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DataExport) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case dataexport.FieldID, dataexport.FieldUserID:
+			values[i] = new(sql.NullInt64)
+		case dataexport.FieldStatus, dataexport.FieldFile, dataexport.FieldError:
+			values[i] = new(sql.NullString)
+		case dataexport.FieldCreatedAt, dataexport.FieldUpdatedAt, dataexport.FieldExpiresAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DataExport fields.
+func (de *DataExport) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case dataexport.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			de.ID = int(value.Int64)
+		case dataexport.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				de.CreatedAt = value.Time
+			}
+		case dataexport.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				de.UpdatedAt = value.Time
+			}
+		case dataexport.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				de.UserID = int(value.Int64)
+			}
+		case dataexport.FieldStatus:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field status", values[i])
+			} else if value.Valid {
+				de.Status = dataexport.Status(value.String)
+			}
+		case dataexport.FieldFile:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field file", values[i])
+			} else if value.Valid {
+				de.File = value.String
+			}
+		case dataexport.FieldError:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field error", values[i])
+			} else if value.Valid {
+				de.Error = value.String
+			}
+		case dataexport.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				de.ExpiresAt = value.Time
+			}
+		default:
+			de.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DataExport.
+// This includes values selected through modifiers, order, etc.
+func (de *DataExport) Value(name string) (ent.Value, error) {
+	return de.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DataExport.
+// Note that you need to call DataExport.Unwrap() before calling this method if this DataExport
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (de *DataExport) Update() *DataExportUpdateOne {
+	return NewDataExportClient(de.config).UpdateOne(de)
+}
+
+// Unwrap unwraps the DataExport entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (de *DataExport) Unwrap() *DataExport {
+	_tx, ok := de.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DataExport is not a transactional entity")
+	}
+	de.config.driver = _tx.drv
+	return de
+}
+
+// String implements the fmt.Stringer.
+func (de *DataExport) String() string {
+	var builder strings.Builder
+	builder.WriteString("DataExport(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", de.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(de.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(de.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", de.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("status=")
+	builder.WriteString(fmt.Sprintf("%v", de.Status))
+	builder.WriteString(", ")
+	builder.WriteString("file=")
+	builder.WriteString(de.File)
+	builder.WriteString(", ")
+	builder.WriteString("error=")
+	builder.WriteString(de.Error)
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(de.ExpiresAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DataExports is a parsable slice of DataExport.
+type DataExports []*DataExport
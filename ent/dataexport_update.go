@@ -0,0 +1,428 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/dataexport"
+	"go-template/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// DataExportUpdate is the builder for updating DataExport entities.
+type DataExportUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DataExportMutation
+}
+
+// Where appends a list predicates to the DataExportUpdate builder.
+func (deu *DataExportUpdate) Where(ps ...predicate.DataExport) *DataExportUpdate {
+	deu.mutation.Where(ps...)
+	return deu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (deu *DataExportUpdate) SetUpdatedAt(t time.Time) *DataExportUpdate {
+	deu.mutation.SetUpdatedAt(t)
+	return deu
+}
+
+// SetUserID sets the "user_id" field.
+func (deu *DataExportUpdate) SetUserID(i int) *DataExportUpdate {
+	deu.mutation.ResetUserID()
+	deu.mutation.SetUserID(i)
+	return deu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableUserID(i *int) *DataExportUpdate {
+	if i != nil {
+		deu.SetUserID(*i)
+	}
+	return deu
+}
+
+// AddUserID adds i to the "user_id" field.
+func (deu *DataExportUpdate) AddUserID(i int) *DataExportUpdate {
+	deu.mutation.AddUserID(i)
+	return deu
+}
+
+// SetStatus sets the "status" field.
+func (deu *DataExportUpdate) SetStatus(d dataexport.Status) *DataExportUpdate {
+	deu.mutation.SetStatus(d)
+	return deu
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableStatus(d *dataexport.Status) *DataExportUpdate {
+	if d != nil {
+		deu.SetStatus(*d)
+	}
+	return deu
+}
+
+// SetFile sets the "file" field.
+func (deu *DataExportUpdate) SetFile(s string) *DataExportUpdate {
+	deu.mutation.SetFile(s)
+	return deu
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableFile(s *string) *DataExportUpdate {
+	if s != nil {
+		deu.SetFile(*s)
+	}
+	return deu
+}
+
+// SetError sets the "error" field.
+func (deu *DataExportUpdate) SetError(s string) *DataExportUpdate {
+	deu.mutation.SetError(s)
+	return deu
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableError(s *string) *DataExportUpdate {
+	if s != nil {
+		deu.SetError(*s)
+	}
+	return deu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (deu *DataExportUpdate) SetExpiresAt(t time.Time) *DataExportUpdate {
+	deu.mutation.SetExpiresAt(t)
+	return deu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (deu *DataExportUpdate) SetNillableExpiresAt(t *time.Time) *DataExportUpdate {
+	if t != nil {
+		deu.SetExpiresAt(*t)
+	}
+	return deu
+}
+
+// Mutation returns the DataExportMutation object of the builder.
+func (deu *DataExportUpdate) Mutation() *DataExportMutation {
+	return deu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (deu *DataExportUpdate) Save(ctx context.Context) (int, error) {
+	deu.defaults()
+	return withHooks(ctx, deu.sqlSave, deu.mutation, deu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (deu *DataExportUpdate) SaveX(ctx context.Context) int {
+	affected, err := deu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (deu *DataExportUpdate) Exec(ctx context.Context) error {
+	_, err := deu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (deu *DataExportUpdate) ExecX(ctx context.Context) {
+	if err := deu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (deu *DataExportUpdate) defaults() {
+	if _, ok := deu.mutation.UpdatedAt(); !ok {
+		v := dataexport.UpdateDefaultUpdatedAt()
+		deu.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (deu *DataExportUpdate) check() error {
+	if v, ok := deu.mutation.Status(); ok {
+		if err := dataexport.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "DataExport.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (deu *DataExportUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := deu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(dataexport.Table, dataexport.Columns, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeInt))
+	if ps := deu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := deu.mutation.UpdatedAt(); ok {
+		_spec.SetField(dataexport.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := deu.mutation.UserID(); ok {
+		_spec.SetField(dataexport.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := deu.mutation.AddedUserID(); ok {
+		_spec.AddField(dataexport.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := deu.mutation.Status(); ok {
+		_spec.SetField(dataexport.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := deu.mutation.File(); ok {
+		_spec.SetField(dataexport.FieldFile, field.TypeString, value)
+	}
+	if value, ok := deu.mutation.Error(); ok {
+		_spec.SetField(dataexport.FieldError, field.TypeString, value)
+	}
+	if value, ok := deu.mutation.ExpiresAt(); ok {
+		_spec.SetField(dataexport.FieldExpiresAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, deu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{dataexport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	deu.mutation.done = true
+	return n, nil
+}
+
+// DataExportUpdateOne is the builder for updating a single DataExport entity.
+type DataExportUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DataExportMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (deuo *DataExportUpdateOne) SetUpdatedAt(t time.Time) *DataExportUpdateOne {
+	deuo.mutation.SetUpdatedAt(t)
+	return deuo
+}
+
+// SetUserID sets the "user_id" field.
+func (deuo *DataExportUpdateOne) SetUserID(i int) *DataExportUpdateOne {
+	deuo.mutation.ResetUserID()
+	deuo.mutation.SetUserID(i)
+	return deuo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableUserID(i *int) *DataExportUpdateOne {
+	if i != nil {
+		deuo.SetUserID(*i)
+	}
+	return deuo
+}
+
+// AddUserID adds i to the "user_id" field.
+func (deuo *DataExportUpdateOne) AddUserID(i int) *DataExportUpdateOne {
+	deuo.mutation.AddUserID(i)
+	return deuo
+}
+
+// SetStatus sets the "status" field.
+func (deuo *DataExportUpdateOne) SetStatus(d dataexport.Status) *DataExportUpdateOne {
+	deuo.mutation.SetStatus(d)
+	return deuo
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableStatus(d *dataexport.Status) *DataExportUpdateOne {
+	if d != nil {
+		deuo.SetStatus(*d)
+	}
+	return deuo
+}
+
+// SetFile sets the "file" field.
+func (deuo *DataExportUpdateOne) SetFile(s string) *DataExportUpdateOne {
+	deuo.mutation.SetFile(s)
+	return deuo
+}
+
+// SetNillableFile sets the "file" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableFile(s *string) *DataExportUpdateOne {
+	if s != nil {
+		deuo.SetFile(*s)
+	}
+	return deuo
+}
+
+// SetError sets the "error" field.
+func (deuo *DataExportUpdateOne) SetError(s string) *DataExportUpdateOne {
+	deuo.mutation.SetError(s)
+	return deuo
+}
+
+// SetNillableError sets the "error" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableError(s *string) *DataExportUpdateOne {
+	if s != nil {
+		deuo.SetError(*s)
+	}
+	return deuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (deuo *DataExportUpdateOne) SetExpiresAt(t time.Time) *DataExportUpdateOne {
+	deuo.mutation.SetExpiresAt(t)
+	return deuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (deuo *DataExportUpdateOne) SetNillableExpiresAt(t *time.Time) *DataExportUpdateOne {
+	if t != nil {
+		deuo.SetExpiresAt(*t)
+	}
+	return deuo
+}
+
+// Mutation returns the DataExportMutation object of the builder.
+func (deuo *DataExportUpdateOne) Mutation() *DataExportMutation {
+	return deuo.mutation
+}
+
+// Where appends a list predicates to the DataExportUpdate builder.
+func (deuo *DataExportUpdateOne) Where(ps ...predicate.DataExport) *DataExportUpdateOne {
+	deuo.mutation.Where(ps...)
+	return deuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (deuo *DataExportUpdateOne) Select(field string, fields ...string) *DataExportUpdateOne {
+	deuo.fields = append([]string{field}, fields...)
+	return deuo
+}
+
+// Save executes the query and returns the updated DataExport entity.
+func (deuo *DataExportUpdateOne) Save(ctx context.Context) (*DataExport, error) {
+	deuo.defaults()
+	return withHooks(ctx, deuo.sqlSave, deuo.mutation, deuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (deuo *DataExportUpdateOne) SaveX(ctx context.Context) *DataExport {
+	node, err := deuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (deuo *DataExportUpdateOne) Exec(ctx context.Context) error {
+	_, err := deuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (deuo *DataExportUpdateOne) ExecX(ctx context.Context) {
+	if err := deuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (deuo *DataExportUpdateOne) defaults() {
+	if _, ok := deuo.mutation.UpdatedAt(); !ok {
+		v := dataexport.UpdateDefaultUpdatedAt()
+		deuo.mutation.SetUpdatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (deuo *DataExportUpdateOne) check() error {
+	if v, ok := deuo.mutation.Status(); ok {
+		if err := dataexport.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "DataExport.status": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (deuo *DataExportUpdateOne) sqlSave(ctx context.Context) (_node *DataExport, err error) {
+	if err := deuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(dataexport.Table, dataexport.Columns, sqlgraph.NewFieldSpec(dataexport.FieldID, field.TypeInt))
+	id, ok := deuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DataExport.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := deuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, dataexport.FieldID)
+		for _, f := range fields {
+			if !dataexport.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != dataexport.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := deuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := deuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(dataexport.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := deuo.mutation.UserID(); ok {
+		_spec.SetField(dataexport.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := deuo.mutation.AddedUserID(); ok {
+		_spec.AddField(dataexport.FieldUserID, field.TypeInt, value)
+	}
+	if value, ok := deuo.mutation.Status(); ok {
+		_spec.SetField(dataexport.FieldStatus, field.TypeEnum, value)
+	}
+	if value, ok := deuo.mutation.File(); ok {
+		_spec.SetField(dataexport.FieldFile, field.TypeString, value)
+	}
+	if value, ok := deuo.mutation.Error(); ok {
+		_spec.SetField(dataexport.FieldError, field.TypeString, value)
+	}
+	if value, ok := deuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(dataexport.FieldExpiresAt, field.TypeTime, value)
+	}
+	_node = &DataExport{config: deuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, deuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{dataexport.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	deuo.mutation.done = true
+	return _node, nil
+}
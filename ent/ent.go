@@ -6,7 +6,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go-template/ent/auditlog"
+	"go-template/ent/consolequery"
+	"go-template/ent/dataexport"
+	"go-template/ent/invitation"
+	"go-template/ent/membership"
+	"go-template/ent/organization"
 	"go-template/ent/role"
+	"go-template/ent/session"
+	"go-template/ent/team"
 	"go-template/ent/user"
 	"reflect"
 	"sync"
@@ -74,8 +82,16 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			role.Table: role.ValidColumn,
-			user.Table: user.ValidColumn,
+			auditlog.Table:     auditlog.ValidColumn,
+			consolequery.Table: consolequery.ValidColumn,
+			dataexport.Table:   dataexport.ValidColumn,
+			invitation.Table:   invitation.ValidColumn,
+			membership.Table:   membership.ValidColumn,
+			organization.Table: organization.ValidColumn,
+			role.Table:         role.ValidColumn,
+			session.Table:      session.ValidColumn,
+			team.Table:         team.ValidColumn,
+			user.Table:         user.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
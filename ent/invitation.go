@@ -0,0 +1,264 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-template/ent/invitation"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Invitation is the model entity for the Invitation schema.
+type Invitation struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// OrganizationID holds the value of the "organization_id" field.
+	OrganizationID int `json:"organization_id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// CreatedBy holds the value of the "created_by" field.
+	CreatedBy *int `json:"created_by,omitempty"`
+	// UpdatedBy holds the value of the "updated_by" field.
+	UpdatedBy *int `json:"updated_by,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// RoleID holds the value of the "role_id" field.
+	RoleID int `json:"role_id,omitempty"`
+	// TeamID holds the value of the "team_id" field.
+	TeamID *int `json:"team_id,omitempty"`
+	// InvitedBy holds the value of the "invited_by" field.
+	InvitedBy int `json:"invited_by,omitempty"`
+	// Version holds the value of the "version" field.
+	Version int `json:"version,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// LastSentAt holds the value of the "last_sent_at" field.
+	LastSentAt time.Time `json:"last_sent_at,omitempty"`
+	// AcceptedAt holds the value of the "accepted_at" field.
+	AcceptedAt *time.Time `json:"accepted_at,omitempty"`
+	// RevokedAt holds the value of the "revoked_at" field.
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Invitation) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case invitation.FieldID, invitation.FieldOrganizationID, invitation.FieldCreatedBy, invitation.FieldUpdatedBy, invitation.FieldRoleID, invitation.FieldTeamID, invitation.FieldInvitedBy, invitation.FieldVersion:
+			values[i] = new(sql.NullInt64)
+		case invitation.FieldEmail:
+			values[i] = new(sql.NullString)
+		case invitation.FieldCreatedAt, invitation.FieldUpdatedAt, invitation.FieldExpiresAt, invitation.FieldLastSentAt, invitation.FieldAcceptedAt, invitation.FieldRevokedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Invitation fields.
+func (i *Invitation) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for j := range columns {
+		switch columns[j] {
+		case invitation.FieldID:
+			value, ok := values[j].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			i.ID = int(value.Int64)
+		case invitation.FieldOrganizationID:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field organization_id", values[j])
+			} else if value.Valid {
+				i.OrganizationID = int(value.Int64)
+			}
+		case invitation.FieldCreatedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[j])
+			} else if value.Valid {
+				i.CreatedAt = value.Time
+			}
+		case invitation.FieldUpdatedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[j])
+			} else if value.Valid {
+				i.UpdatedAt = value.Time
+			}
+		case invitation.FieldCreatedBy:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field created_by", values[j])
+			} else if value.Valid {
+				i.CreatedBy = new(int)
+				*i.CreatedBy = int(value.Int64)
+			}
+		case invitation.FieldUpdatedBy:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_by", values[j])
+			} else if value.Valid {
+				i.UpdatedBy = new(int)
+				*i.UpdatedBy = int(value.Int64)
+			}
+		case invitation.FieldEmail:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[j])
+			} else if value.Valid {
+				i.Email = value.String
+			}
+		case invitation.FieldRoleID:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field role_id", values[j])
+			} else if value.Valid {
+				i.RoleID = int(value.Int64)
+			}
+		case invitation.FieldTeamID:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field team_id", values[j])
+			} else if value.Valid {
+				i.TeamID = new(int)
+				*i.TeamID = int(value.Int64)
+			}
+		case invitation.FieldInvitedBy:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field invited_by", values[j])
+			} else if value.Valid {
+				i.InvitedBy = int(value.Int64)
+			}
+		case invitation.FieldVersion:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field version", values[j])
+			} else if value.Valid {
+				i.Version = int(value.Int64)
+			}
+		case invitation.FieldExpiresAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[j])
+			} else if value.Valid {
+				i.ExpiresAt = value.Time
+			}
+		case invitation.FieldLastSentAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_sent_at", values[j])
+			} else if value.Valid {
+				i.LastSentAt = value.Time
+			}
+		case invitation.FieldAcceptedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field accepted_at", values[j])
+			} else if value.Valid {
+				i.AcceptedAt = new(time.Time)
+				*i.AcceptedAt = value.Time
+			}
+		case invitation.FieldRevokedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[j])
+			} else if value.Valid {
+				i.RevokedAt = new(time.Time)
+				*i.RevokedAt = value.Time
+			}
+		default:
+			i.selectValues.Set(columns[j], values[j])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Invitation.
+// This includes values selected through modifiers, order, etc.
+func (i *Invitation) Value(name string) (ent.Value, error) {
+	return i.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this Invitation.
+// Note that you need to call Invitation.Unwrap() before calling this method if this Invitation
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (i *Invitation) Update() *InvitationUpdateOne {
+	return NewInvitationClient(i.config).UpdateOne(i)
+}
+
+// Unwrap unwraps the Invitation entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (i *Invitation) Unwrap() *Invitation {
+	_tx, ok := i.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Invitation is not a transactional entity")
+	}
+	i.config.driver = _tx.drv
+	return i
+}
+
+// String implements the fmt.Stringer.
+func (i *Invitation) String() string {
+	var builder strings.Builder
+	builder.WriteString("Invitation(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", i.ID))
+	builder.WriteString("organization_id=")
+	builder.WriteString(fmt.Sprintf("%v", i.OrganizationID))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(i.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(i.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := i.CreatedBy; v != nil {
+		builder.WriteString("created_by=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	if v := i.UpdatedBy; v != nil {
+		builder.WriteString("updated_by=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(i.Email)
+	builder.WriteString(", ")
+	builder.WriteString("role_id=")
+	builder.WriteString(fmt.Sprintf("%v", i.RoleID))
+	builder.WriteString(", ")
+	if v := i.TeamID; v != nil {
+		builder.WriteString("team_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("invited_by=")
+	builder.WriteString(fmt.Sprintf("%v", i.InvitedBy))
+	builder.WriteString(", ")
+	builder.WriteString("version=")
+	builder.WriteString(fmt.Sprintf("%v", i.Version))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(i.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("last_sent_at=")
+	builder.WriteString(i.LastSentAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := i.AcceptedAt; v != nil {
+		builder.WriteString("accepted_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := i.RevokedAt; v != nil {
+		builder.WriteString("revoked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Invitations is a parsable slice of Invitation.
+type Invitations []*Invitation
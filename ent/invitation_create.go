@@ -0,0 +1,449 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/invitation"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// InvitationCreate is the builder for creating a Invitation entity.
+type InvitationCreate struct {
+	config
+	mutation *InvitationMutation
+	hooks    []Hook
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (ic *InvitationCreate) SetOrganizationID(i int) *InvitationCreate {
+	ic.mutation.SetOrganizationID(i)
+	return ic
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (ic *InvitationCreate) SetCreatedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetCreatedAt(t)
+	return ic
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableCreatedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetCreatedAt(*t)
+	}
+	return ic
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ic *InvitationCreate) SetUpdatedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetUpdatedAt(t)
+	return ic
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableUpdatedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetUpdatedAt(*t)
+	}
+	return ic
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (ic *InvitationCreate) SetCreatedBy(i int) *InvitationCreate {
+	ic.mutation.SetCreatedBy(i)
+	return ic
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableCreatedBy(i *int) *InvitationCreate {
+	if i != nil {
+		ic.SetCreatedBy(*i)
+	}
+	return ic
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (ic *InvitationCreate) SetUpdatedBy(i int) *InvitationCreate {
+	ic.mutation.SetUpdatedBy(i)
+	return ic
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableUpdatedBy(i *int) *InvitationCreate {
+	if i != nil {
+		ic.SetUpdatedBy(*i)
+	}
+	return ic
+}
+
+// SetEmail sets the "email" field.
+func (ic *InvitationCreate) SetEmail(s string) *InvitationCreate {
+	ic.mutation.SetEmail(s)
+	return ic
+}
+
+// SetRoleID sets the "role_id" field.
+func (ic *InvitationCreate) SetRoleID(i int) *InvitationCreate {
+	ic.mutation.SetRoleID(i)
+	return ic
+}
+
+// SetTeamID sets the "team_id" field.
+func (ic *InvitationCreate) SetTeamID(i int) *InvitationCreate {
+	ic.mutation.SetTeamID(i)
+	return ic
+}
+
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableTeamID(i *int) *InvitationCreate {
+	if i != nil {
+		ic.SetTeamID(*i)
+	}
+	return ic
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (ic *InvitationCreate) SetInvitedBy(i int) *InvitationCreate {
+	ic.mutation.SetInvitedBy(i)
+	return ic
+}
+
+// SetVersion sets the "version" field.
+func (ic *InvitationCreate) SetVersion(i int) *InvitationCreate {
+	ic.mutation.SetVersion(i)
+	return ic
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableVersion(i *int) *InvitationCreate {
+	if i != nil {
+		ic.SetVersion(*i)
+	}
+	return ic
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (ic *InvitationCreate) SetExpiresAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetExpiresAt(t)
+	return ic
+}
+
+// SetLastSentAt sets the "last_sent_at" field.
+func (ic *InvitationCreate) SetLastSentAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetLastSentAt(t)
+	return ic
+}
+
+// SetNillableLastSentAt sets the "last_sent_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableLastSentAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetLastSentAt(*t)
+	}
+	return ic
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (ic *InvitationCreate) SetAcceptedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetAcceptedAt(t)
+	return ic
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableAcceptedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetAcceptedAt(*t)
+	}
+	return ic
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (ic *InvitationCreate) SetRevokedAt(t time.Time) *InvitationCreate {
+	ic.mutation.SetRevokedAt(t)
+	return ic
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (ic *InvitationCreate) SetNillableRevokedAt(t *time.Time) *InvitationCreate {
+	if t != nil {
+		ic.SetRevokedAt(*t)
+	}
+	return ic
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (ic *InvitationCreate) Mutation() *InvitationMutation {
+	return ic.mutation
+}
+
+// Save creates the Invitation in the database.
+func (ic *InvitationCreate) Save(ctx context.Context) (*Invitation, error) {
+	if err := ic.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, ic.sqlSave, ic.mutation, ic.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (ic *InvitationCreate) SaveX(ctx context.Context) *Invitation {
+	v, err := ic.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ic *InvitationCreate) Exec(ctx context.Context) error {
+	_, err := ic.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ic *InvitationCreate) ExecX(ctx context.Context) {
+	if err := ic.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ic *InvitationCreate) defaults() error {
+	if _, ok := ic.mutation.CreatedAt(); !ok {
+		if invitation.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized invitation.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := invitation.DefaultCreatedAt()
+		ic.mutation.SetCreatedAt(v)
+	}
+	if _, ok := ic.mutation.UpdatedAt(); !ok {
+		if invitation.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized invitation.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := invitation.DefaultUpdatedAt()
+		ic.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := ic.mutation.Version(); !ok {
+		v := invitation.DefaultVersion
+		ic.mutation.SetVersion(v)
+	}
+	if _, ok := ic.mutation.LastSentAt(); !ok {
+		if invitation.DefaultLastSentAt == nil {
+			return fmt.Errorf("ent: uninitialized invitation.DefaultLastSentAt (forgotten import ent/runtime?)")
+		}
+		v := invitation.DefaultLastSentAt()
+		ic.mutation.SetLastSentAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ic *InvitationCreate) check() error {
+	if _, ok := ic.mutation.OrganizationID(); !ok {
+		return &ValidationError{Name: "organization_id", err: errors.New(`ent: missing required field "Invitation.organization_id"`)}
+	}
+	if _, ok := ic.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Invitation.created_at"`)}
+	}
+	if _, ok := ic.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Invitation.updated_at"`)}
+	}
+	if _, ok := ic.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "Invitation.email"`)}
+	}
+	if v, ok := ic.mutation.Email(); ok {
+		if err := invitation.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Invitation.email": %w`, err)}
+		}
+	}
+	if _, ok := ic.mutation.RoleID(); !ok {
+		return &ValidationError{Name: "role_id", err: errors.New(`ent: missing required field "Invitation.role_id"`)}
+	}
+	if _, ok := ic.mutation.InvitedBy(); !ok {
+		return &ValidationError{Name: "invited_by", err: errors.New(`ent: missing required field "Invitation.invited_by"`)}
+	}
+	if _, ok := ic.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Invitation.version"`)}
+	}
+	if _, ok := ic.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "Invitation.expires_at"`)}
+	}
+	if _, ok := ic.mutation.LastSentAt(); !ok {
+		return &ValidationError{Name: "last_sent_at", err: errors.New(`ent: missing required field "Invitation.last_sent_at"`)}
+	}
+	return nil
+}
+
+func (ic *InvitationCreate) sqlSave(ctx context.Context) (*Invitation, error) {
+	if err := ic.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := ic.createSpec()
+	if err := sqlgraph.CreateNode(ctx, ic.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	ic.mutation.id = &_node.ID
+	ic.mutation.done = true
+	return _node, nil
+}
+
+func (ic *InvitationCreate) createSpec() (*Invitation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Invitation{config: ic.config}
+		_spec = sqlgraph.NewCreateSpec(invitation.Table, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeInt))
+	)
+	if value, ok := ic.mutation.OrganizationID(); ok {
+		_spec.SetField(invitation.FieldOrganizationID, field.TypeInt, value)
+		_node.OrganizationID = value
+	}
+	if value, ok := ic.mutation.CreatedAt(); ok {
+		_spec.SetField(invitation.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := ic.mutation.UpdatedAt(); ok {
+		_spec.SetField(invitation.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := ic.mutation.CreatedBy(); ok {
+		_spec.SetField(invitation.FieldCreatedBy, field.TypeInt, value)
+		_node.CreatedBy = &value
+	}
+	if value, ok := ic.mutation.UpdatedBy(); ok {
+		_spec.SetField(invitation.FieldUpdatedBy, field.TypeInt, value)
+		_node.UpdatedBy = &value
+	}
+	if value, ok := ic.mutation.Email(); ok {
+		_spec.SetField(invitation.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := ic.mutation.RoleID(); ok {
+		_spec.SetField(invitation.FieldRoleID, field.TypeInt, value)
+		_node.RoleID = value
+	}
+	if value, ok := ic.mutation.TeamID(); ok {
+		_spec.SetField(invitation.FieldTeamID, field.TypeInt, value)
+		_node.TeamID = &value
+	}
+	if value, ok := ic.mutation.InvitedBy(); ok {
+		_spec.SetField(invitation.FieldInvitedBy, field.TypeInt, value)
+		_node.InvitedBy = value
+	}
+	if value, ok := ic.mutation.Version(); ok {
+		_spec.SetField(invitation.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
+	if value, ok := ic.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := ic.mutation.LastSentAt(); ok {
+		_spec.SetField(invitation.FieldLastSentAt, field.TypeTime, value)
+		_node.LastSentAt = value
+	}
+	if value, ok := ic.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+		_node.AcceptedAt = &value
+	}
+	if value, ok := ic.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	return _node, _spec
+}
+
+// InvitationCreateBulk is the builder for creating many Invitation entities in bulk.
+type InvitationCreateBulk struct {
+	config
+	err      error
+	builders []*InvitationCreate
+}
+
+// Save creates the Invitation entities in the database.
+func (icb *InvitationCreateBulk) Save(ctx context.Context) ([]*Invitation, error) {
+	if icb.err != nil {
+		return nil, icb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(icb.builders))
+	nodes := make([]*Invitation, len(icb.builders))
+	mutators := make([]Mutator, len(icb.builders))
+	for i := range icb.builders {
+		func(i int, root context.Context) {
+			builder := icb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*InvitationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, icb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, icb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, icb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (icb *InvitationCreateBulk) SaveX(ctx context.Context) []*Invitation {
+	v, err := icb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (icb *InvitationCreateBulk) Exec(ctx context.Context) error {
+	_, err := icb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (icb *InvitationCreateBulk) ExecX(ctx context.Context) {
+	if err := icb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
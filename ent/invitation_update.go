@@ -0,0 +1,768 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/invitation"
+	"go-template/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// InvitationUpdate is the builder for updating Invitation entities.
+type InvitationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *InvitationMutation
+}
+
+// Where appends a list predicates to the InvitationUpdate builder.
+func (iu *InvitationUpdate) Where(ps ...predicate.Invitation) *InvitationUpdate {
+	iu.mutation.Where(ps...)
+	return iu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (iu *InvitationUpdate) SetUpdatedAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetUpdatedAt(t)
+	return iu
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (iu *InvitationUpdate) SetUpdatedBy(i int) *InvitationUpdate {
+	iu.mutation.ResetUpdatedBy()
+	iu.mutation.SetUpdatedBy(i)
+	return iu
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableUpdatedBy(i *int) *InvitationUpdate {
+	if i != nil {
+		iu.SetUpdatedBy(*i)
+	}
+	return iu
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (iu *InvitationUpdate) AddUpdatedBy(i int) *InvitationUpdate {
+	iu.mutation.AddUpdatedBy(i)
+	return iu
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (iu *InvitationUpdate) ClearUpdatedBy() *InvitationUpdate {
+	iu.mutation.ClearUpdatedBy()
+	return iu
+}
+
+// SetEmail sets the "email" field.
+func (iu *InvitationUpdate) SetEmail(s string) *InvitationUpdate {
+	iu.mutation.SetEmail(s)
+	return iu
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableEmail(s *string) *InvitationUpdate {
+	if s != nil {
+		iu.SetEmail(*s)
+	}
+	return iu
+}
+
+// SetRoleID sets the "role_id" field.
+func (iu *InvitationUpdate) SetRoleID(i int) *InvitationUpdate {
+	iu.mutation.ResetRoleID()
+	iu.mutation.SetRoleID(i)
+	return iu
+}
+
+// SetNillableRoleID sets the "role_id" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableRoleID(i *int) *InvitationUpdate {
+	if i != nil {
+		iu.SetRoleID(*i)
+	}
+	return iu
+}
+
+// AddRoleID adds i to the "role_id" field.
+func (iu *InvitationUpdate) AddRoleID(i int) *InvitationUpdate {
+	iu.mutation.AddRoleID(i)
+	return iu
+}
+
+// SetTeamID sets the "team_id" field.
+func (iu *InvitationUpdate) SetTeamID(i int) *InvitationUpdate {
+	iu.mutation.ResetTeamID()
+	iu.mutation.SetTeamID(i)
+	return iu
+}
+
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableTeamID(i *int) *InvitationUpdate {
+	if i != nil {
+		iu.SetTeamID(*i)
+	}
+	return iu
+}
+
+// AddTeamID adds i to the "team_id" field.
+func (iu *InvitationUpdate) AddTeamID(i int) *InvitationUpdate {
+	iu.mutation.AddTeamID(i)
+	return iu
+}
+
+// ClearTeamID clears the value of the "team_id" field.
+func (iu *InvitationUpdate) ClearTeamID() *InvitationUpdate {
+	iu.mutation.ClearTeamID()
+	return iu
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (iu *InvitationUpdate) SetInvitedBy(i int) *InvitationUpdate {
+	iu.mutation.ResetInvitedBy()
+	iu.mutation.SetInvitedBy(i)
+	return iu
+}
+
+// SetNillableInvitedBy sets the "invited_by" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableInvitedBy(i *int) *InvitationUpdate {
+	if i != nil {
+		iu.SetInvitedBy(*i)
+	}
+	return iu
+}
+
+// AddInvitedBy adds i to the "invited_by" field.
+func (iu *InvitationUpdate) AddInvitedBy(i int) *InvitationUpdate {
+	iu.mutation.AddInvitedBy(i)
+	return iu
+}
+
+// SetVersion sets the "version" field.
+func (iu *InvitationUpdate) SetVersion(i int) *InvitationUpdate {
+	iu.mutation.ResetVersion()
+	iu.mutation.SetVersion(i)
+	return iu
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableVersion(i *int) *InvitationUpdate {
+	if i != nil {
+		iu.SetVersion(*i)
+	}
+	return iu
+}
+
+// AddVersion adds i to the "version" field.
+func (iu *InvitationUpdate) AddVersion(i int) *InvitationUpdate {
+	iu.mutation.AddVersion(i)
+	return iu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iu *InvitationUpdate) SetExpiresAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetExpiresAt(t)
+	return iu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableExpiresAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetExpiresAt(*t)
+	}
+	return iu
+}
+
+// SetLastSentAt sets the "last_sent_at" field.
+func (iu *InvitationUpdate) SetLastSentAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetLastSentAt(t)
+	return iu
+}
+
+// SetNillableLastSentAt sets the "last_sent_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableLastSentAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetLastSentAt(*t)
+	}
+	return iu
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (iu *InvitationUpdate) SetAcceptedAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetAcceptedAt(t)
+	return iu
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableAcceptedAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetAcceptedAt(*t)
+	}
+	return iu
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (iu *InvitationUpdate) ClearAcceptedAt() *InvitationUpdate {
+	iu.mutation.ClearAcceptedAt()
+	return iu
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iu *InvitationUpdate) SetRevokedAt(t time.Time) *InvitationUpdate {
+	iu.mutation.SetRevokedAt(t)
+	return iu
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iu *InvitationUpdate) SetNillableRevokedAt(t *time.Time) *InvitationUpdate {
+	if t != nil {
+		iu.SetRevokedAt(*t)
+	}
+	return iu
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iu *InvitationUpdate) ClearRevokedAt() *InvitationUpdate {
+	iu.mutation.ClearRevokedAt()
+	return iu
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (iu *InvitationUpdate) Mutation() *InvitationMutation {
+	return iu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iu *InvitationUpdate) Save(ctx context.Context) (int, error) {
+	if err := iu.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, iu.sqlSave, iu.mutation, iu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iu *InvitationUpdate) SaveX(ctx context.Context) int {
+	affected, err := iu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iu *InvitationUpdate) Exec(ctx context.Context) error {
+	_, err := iu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iu *InvitationUpdate) ExecX(ctx context.Context) {
+	if err := iu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (iu *InvitationUpdate) defaults() error {
+	if _, ok := iu.mutation.UpdatedAt(); !ok {
+		if invitation.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized invitation.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := invitation.UpdateDefaultUpdatedAt()
+		iu.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iu *InvitationUpdate) check() error {
+	if v, ok := iu.mutation.Email(); ok {
+		if err := invitation.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Invitation.email": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (iu *InvitationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := iu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invitation.Table, invitation.Columns, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeInt))
+	if ps := iu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iu.mutation.UpdatedAt(); ok {
+		_spec.SetField(invitation.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if iu.mutation.CreatedByCleared() {
+		_spec.ClearField(invitation.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := iu.mutation.UpdatedBy(); ok {
+		_spec.SetField(invitation.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(invitation.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if iu.mutation.UpdatedByCleared() {
+		_spec.ClearField(invitation.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := iu.mutation.Email(); ok {
+		_spec.SetField(invitation.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := iu.mutation.RoleID(); ok {
+		_spec.SetField(invitation.FieldRoleID, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedRoleID(); ok {
+		_spec.AddField(invitation.FieldRoleID, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.TeamID(); ok {
+		_spec.SetField(invitation.FieldTeamID, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedTeamID(); ok {
+		_spec.AddField(invitation.FieldTeamID, field.TypeInt, value)
+	}
+	if iu.mutation.TeamIDCleared() {
+		_spec.ClearField(invitation.FieldTeamID, field.TypeInt)
+	}
+	if value, ok := iu.mutation.InvitedBy(); ok {
+		_spec.SetField(invitation.FieldInvitedBy, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedInvitedBy(); ok {
+		_spec.AddField(invitation.FieldInvitedBy, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.Version(); ok {
+		_spec.SetField(invitation.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedVersion(); ok {
+		_spec.AddField(invitation.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := iu.mutation.LastSentAt(); ok {
+		_spec.SetField(invitation.FieldLastSentAt, field.TypeTime, value)
+	}
+	if value, ok := iu.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+	}
+	if iu.mutation.AcceptedAtCleared() {
+		_spec.ClearField(invitation.FieldAcceptedAt, field.TypeTime)
+	}
+	if value, ok := iu.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iu.mutation.RevokedAtCleared() {
+		_spec.ClearField(invitation.FieldRevokedAt, field.TypeTime)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iu.mutation.done = true
+	return n, nil
+}
+
+// InvitationUpdateOne is the builder for updating a single Invitation entity.
+type InvitationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *InvitationMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (iuo *InvitationUpdateOne) SetUpdatedAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetUpdatedAt(t)
+	return iuo
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (iuo *InvitationUpdateOne) SetUpdatedBy(i int) *InvitationUpdateOne {
+	iuo.mutation.ResetUpdatedBy()
+	iuo.mutation.SetUpdatedBy(i)
+	return iuo
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableUpdatedBy(i *int) *InvitationUpdateOne {
+	if i != nil {
+		iuo.SetUpdatedBy(*i)
+	}
+	return iuo
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (iuo *InvitationUpdateOne) AddUpdatedBy(i int) *InvitationUpdateOne {
+	iuo.mutation.AddUpdatedBy(i)
+	return iuo
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (iuo *InvitationUpdateOne) ClearUpdatedBy() *InvitationUpdateOne {
+	iuo.mutation.ClearUpdatedBy()
+	return iuo
+}
+
+// SetEmail sets the "email" field.
+func (iuo *InvitationUpdateOne) SetEmail(s string) *InvitationUpdateOne {
+	iuo.mutation.SetEmail(s)
+	return iuo
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableEmail(s *string) *InvitationUpdateOne {
+	if s != nil {
+		iuo.SetEmail(*s)
+	}
+	return iuo
+}
+
+// SetRoleID sets the "role_id" field.
+func (iuo *InvitationUpdateOne) SetRoleID(i int) *InvitationUpdateOne {
+	iuo.mutation.ResetRoleID()
+	iuo.mutation.SetRoleID(i)
+	return iuo
+}
+
+// SetNillableRoleID sets the "role_id" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableRoleID(i *int) *InvitationUpdateOne {
+	if i != nil {
+		iuo.SetRoleID(*i)
+	}
+	return iuo
+}
+
+// AddRoleID adds i to the "role_id" field.
+func (iuo *InvitationUpdateOne) AddRoleID(i int) *InvitationUpdateOne {
+	iuo.mutation.AddRoleID(i)
+	return iuo
+}
+
+// SetTeamID sets the "team_id" field.
+func (iuo *InvitationUpdateOne) SetTeamID(i int) *InvitationUpdateOne {
+	iuo.mutation.ResetTeamID()
+	iuo.mutation.SetTeamID(i)
+	return iuo
+}
+
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableTeamID(i *int) *InvitationUpdateOne {
+	if i != nil {
+		iuo.SetTeamID(*i)
+	}
+	return iuo
+}
+
+// AddTeamID adds i to the "team_id" field.
+func (iuo *InvitationUpdateOne) AddTeamID(i int) *InvitationUpdateOne {
+	iuo.mutation.AddTeamID(i)
+	return iuo
+}
+
+// ClearTeamID clears the value of the "team_id" field.
+func (iuo *InvitationUpdateOne) ClearTeamID() *InvitationUpdateOne {
+	iuo.mutation.ClearTeamID()
+	return iuo
+}
+
+// SetInvitedBy sets the "invited_by" field.
+func (iuo *InvitationUpdateOne) SetInvitedBy(i int) *InvitationUpdateOne {
+	iuo.mutation.ResetInvitedBy()
+	iuo.mutation.SetInvitedBy(i)
+	return iuo
+}
+
+// SetNillableInvitedBy sets the "invited_by" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableInvitedBy(i *int) *InvitationUpdateOne {
+	if i != nil {
+		iuo.SetInvitedBy(*i)
+	}
+	return iuo
+}
+
+// AddInvitedBy adds i to the "invited_by" field.
+func (iuo *InvitationUpdateOne) AddInvitedBy(i int) *InvitationUpdateOne {
+	iuo.mutation.AddInvitedBy(i)
+	return iuo
+}
+
+// SetVersion sets the "version" field.
+func (iuo *InvitationUpdateOne) SetVersion(i int) *InvitationUpdateOne {
+	iuo.mutation.ResetVersion()
+	iuo.mutation.SetVersion(i)
+	return iuo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableVersion(i *int) *InvitationUpdateOne {
+	if i != nil {
+		iuo.SetVersion(*i)
+	}
+	return iuo
+}
+
+// AddVersion adds i to the "version" field.
+func (iuo *InvitationUpdateOne) AddVersion(i int) *InvitationUpdateOne {
+	iuo.mutation.AddVersion(i)
+	return iuo
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iuo *InvitationUpdateOne) SetExpiresAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetExpiresAt(t)
+	return iuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableExpiresAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetExpiresAt(*t)
+	}
+	return iuo
+}
+
+// SetLastSentAt sets the "last_sent_at" field.
+func (iuo *InvitationUpdateOne) SetLastSentAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetLastSentAt(t)
+	return iuo
+}
+
+// SetNillableLastSentAt sets the "last_sent_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableLastSentAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetLastSentAt(*t)
+	}
+	return iuo
+}
+
+// SetAcceptedAt sets the "accepted_at" field.
+func (iuo *InvitationUpdateOne) SetAcceptedAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetAcceptedAt(t)
+	return iuo
+}
+
+// SetNillableAcceptedAt sets the "accepted_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableAcceptedAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetAcceptedAt(*t)
+	}
+	return iuo
+}
+
+// ClearAcceptedAt clears the value of the "accepted_at" field.
+func (iuo *InvitationUpdateOne) ClearAcceptedAt() *InvitationUpdateOne {
+	iuo.mutation.ClearAcceptedAt()
+	return iuo
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iuo *InvitationUpdateOne) SetRevokedAt(t time.Time) *InvitationUpdateOne {
+	iuo.mutation.SetRevokedAt(t)
+	return iuo
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iuo *InvitationUpdateOne) SetNillableRevokedAt(t *time.Time) *InvitationUpdateOne {
+	if t != nil {
+		iuo.SetRevokedAt(*t)
+	}
+	return iuo
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iuo *InvitationUpdateOne) ClearRevokedAt() *InvitationUpdateOne {
+	iuo.mutation.ClearRevokedAt()
+	return iuo
+}
+
+// Mutation returns the InvitationMutation object of the builder.
+func (iuo *InvitationUpdateOne) Mutation() *InvitationMutation {
+	return iuo.mutation
+}
+
+// Where appends a list predicates to the InvitationUpdate builder.
+func (iuo *InvitationUpdateOne) Where(ps ...predicate.Invitation) *InvitationUpdateOne {
+	iuo.mutation.Where(ps...)
+	return iuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (iuo *InvitationUpdateOne) Select(field string, fields ...string) *InvitationUpdateOne {
+	iuo.fields = append([]string{field}, fields...)
+	return iuo
+}
+
+// Save executes the query and returns the updated Invitation entity.
+func (iuo *InvitationUpdateOne) Save(ctx context.Context) (*Invitation, error) {
+	if err := iuo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, iuo.sqlSave, iuo.mutation, iuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iuo *InvitationUpdateOne) SaveX(ctx context.Context) *Invitation {
+	node, err := iuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (iuo *InvitationUpdateOne) Exec(ctx context.Context) error {
+	_, err := iuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iuo *InvitationUpdateOne) ExecX(ctx context.Context) {
+	if err := iuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (iuo *InvitationUpdateOne) defaults() error {
+	if _, ok := iuo.mutation.UpdatedAt(); !ok {
+		if invitation.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized invitation.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := invitation.UpdateDefaultUpdatedAt()
+		iuo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iuo *InvitationUpdateOne) check() error {
+	if v, ok := iuo.mutation.Email(); ok {
+		if err := invitation.EmailValidator(v); err != nil {
+			return &ValidationError{Name: "email", err: fmt.Errorf(`ent: validator failed for field "Invitation.email": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (iuo *InvitationUpdateOne) sqlSave(ctx context.Context) (_node *Invitation, err error) {
+	if err := iuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invitation.Table, invitation.Columns, sqlgraph.NewFieldSpec(invitation.FieldID, field.TypeInt))
+	id, ok := iuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Invitation.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := iuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, invitation.FieldID)
+		for _, f := range fields {
+			if !invitation.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != invitation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := iuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(invitation.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.CreatedByCleared() {
+		_spec.ClearField(invitation.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := iuo.mutation.UpdatedBy(); ok {
+		_spec.SetField(invitation.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(invitation.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if iuo.mutation.UpdatedByCleared() {
+		_spec.ClearField(invitation.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := iuo.mutation.Email(); ok {
+		_spec.SetField(invitation.FieldEmail, field.TypeString, value)
+	}
+	if value, ok := iuo.mutation.RoleID(); ok {
+		_spec.SetField(invitation.FieldRoleID, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedRoleID(); ok {
+		_spec.AddField(invitation.FieldRoleID, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.TeamID(); ok {
+		_spec.SetField(invitation.FieldTeamID, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedTeamID(); ok {
+		_spec.AddField(invitation.FieldTeamID, field.TypeInt, value)
+	}
+	if iuo.mutation.TeamIDCleared() {
+		_spec.ClearField(invitation.FieldTeamID, field.TypeInt)
+	}
+	if value, ok := iuo.mutation.InvitedBy(); ok {
+		_spec.SetField(invitation.FieldInvitedBy, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedInvitedBy(); ok {
+		_spec.AddField(invitation.FieldInvitedBy, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.Version(); ok {
+		_spec.SetField(invitation.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedVersion(); ok {
+		_spec.AddField(invitation.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(invitation.FieldExpiresAt, field.TypeTime, value)
+	}
+	if value, ok := iuo.mutation.LastSentAt(); ok {
+		_spec.SetField(invitation.FieldLastSentAt, field.TypeTime, value)
+	}
+	if value, ok := iuo.mutation.AcceptedAt(); ok {
+		_spec.SetField(invitation.FieldAcceptedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.AcceptedAtCleared() {
+		_spec.ClearField(invitation.FieldAcceptedAt, field.TypeTime)
+	}
+	if value, ok := iuo.mutation.RevokedAt(); ok {
+		_spec.SetField(invitation.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.RevokedAtCleared() {
+		_spec.ClearField(invitation.FieldRevokedAt, field.TypeTime)
+	}
+	_node = &Invitation{config: iuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, iuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invitation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	iuo.mutation.done = true
+	return _node, nil
+}
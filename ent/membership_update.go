@@ -0,0 +1,554 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/membership"
+	"go-template/ent/predicate"
+	"go-template/ent/role"
+	"go-template/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// MembershipUpdate is the builder for updating Membership entities.
+type MembershipUpdate struct {
+	config
+	hooks    []Hook
+	mutation *MembershipMutation
+}
+
+// Where appends a list predicates to the MembershipUpdate builder.
+func (mu *MembershipUpdate) Where(ps ...predicate.Membership) *MembershipUpdate {
+	mu.mutation.Where(ps...)
+	return mu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (mu *MembershipUpdate) SetUpdatedAt(t time.Time) *MembershipUpdate {
+	mu.mutation.SetUpdatedAt(t)
+	return mu
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (mu *MembershipUpdate) SetUpdatedBy(i int) *MembershipUpdate {
+	mu.mutation.ResetUpdatedBy()
+	mu.mutation.SetUpdatedBy(i)
+	return mu
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (mu *MembershipUpdate) SetNillableUpdatedBy(i *int) *MembershipUpdate {
+	if i != nil {
+		mu.SetUpdatedBy(*i)
+	}
+	return mu
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (mu *MembershipUpdate) AddUpdatedBy(i int) *MembershipUpdate {
+	mu.mutation.AddUpdatedBy(i)
+	return mu
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (mu *MembershipUpdate) ClearUpdatedBy() *MembershipUpdate {
+	mu.mutation.ClearUpdatedBy()
+	return mu
+}
+
+// SetUserID sets the "user_id" field.
+func (mu *MembershipUpdate) SetUserID(i int) *MembershipUpdate {
+	mu.mutation.SetUserID(i)
+	return mu
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (mu *MembershipUpdate) SetNillableUserID(i *int) *MembershipUpdate {
+	if i != nil {
+		mu.SetUserID(*i)
+	}
+	return mu
+}
+
+// SetRoleID sets the "role_id" field.
+func (mu *MembershipUpdate) SetRoleID(i int) *MembershipUpdate {
+	mu.mutation.SetRoleID(i)
+	return mu
+}
+
+// SetNillableRoleID sets the "role_id" field if the given value is not nil.
+func (mu *MembershipUpdate) SetNillableRoleID(i *int) *MembershipUpdate {
+	if i != nil {
+		mu.SetRoleID(*i)
+	}
+	return mu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (mu *MembershipUpdate) SetUser(u *User) *MembershipUpdate {
+	return mu.SetUserID(u.ID)
+}
+
+// SetRole sets the "role" edge to the Role entity.
+func (mu *MembershipUpdate) SetRole(r *Role) *MembershipUpdate {
+	return mu.SetRoleID(r.ID)
+}
+
+// Mutation returns the MembershipMutation object of the builder.
+func (mu *MembershipUpdate) Mutation() *MembershipMutation {
+	return mu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (mu *MembershipUpdate) ClearUser() *MembershipUpdate {
+	mu.mutation.ClearUser()
+	return mu
+}
+
+// ClearRole clears the "role" edge to the Role entity.
+func (mu *MembershipUpdate) ClearRole() *MembershipUpdate {
+	mu.mutation.ClearRole()
+	return mu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (mu *MembershipUpdate) Save(ctx context.Context) (int, error) {
+	if err := mu.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, mu.sqlSave, mu.mutation, mu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (mu *MembershipUpdate) SaveX(ctx context.Context) int {
+	affected, err := mu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (mu *MembershipUpdate) Exec(ctx context.Context) error {
+	_, err := mu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (mu *MembershipUpdate) ExecX(ctx context.Context) {
+	if err := mu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (mu *MembershipUpdate) defaults() error {
+	if _, ok := mu.mutation.UpdatedAt(); !ok {
+		if membership.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized membership.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := membership.UpdateDefaultUpdatedAt()
+		mu.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (mu *MembershipUpdate) check() error {
+	if mu.mutation.OrganizationCleared() && len(mu.mutation.OrganizationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.organization"`)
+	}
+	if mu.mutation.UserCleared() && len(mu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.user"`)
+	}
+	if mu.mutation.RoleCleared() && len(mu.mutation.RoleIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.role"`)
+	}
+	return nil
+}
+
+func (mu *MembershipUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := mu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(membership.Table, membership.Columns, sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt))
+	if ps := mu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := mu.mutation.UpdatedAt(); ok {
+		_spec.SetField(membership.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if mu.mutation.CreatedByCleared() {
+		_spec.ClearField(membership.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := mu.mutation.UpdatedBy(); ok {
+		_spec.SetField(membership.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := mu.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(membership.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if mu.mutation.UpdatedByCleared() {
+		_spec.ClearField(membership.FieldUpdatedBy, field.TypeInt)
+	}
+	if mu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.UserTable,
+			Columns: []string{membership.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.UserTable,
+			Columns: []string{membership.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if mu.mutation.RoleCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.RoleTable,
+			Columns: []string{membership.RoleColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := mu.mutation.RoleIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.RoleTable,
+			Columns: []string{membership.RoleColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, mu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{membership.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	mu.mutation.done = true
+	return n, nil
+}
+
+// MembershipUpdateOne is the builder for updating a single Membership entity.
+type MembershipUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *MembershipMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (muo *MembershipUpdateOne) SetUpdatedAt(t time.Time) *MembershipUpdateOne {
+	muo.mutation.SetUpdatedAt(t)
+	return muo
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (muo *MembershipUpdateOne) SetUpdatedBy(i int) *MembershipUpdateOne {
+	muo.mutation.ResetUpdatedBy()
+	muo.mutation.SetUpdatedBy(i)
+	return muo
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (muo *MembershipUpdateOne) SetNillableUpdatedBy(i *int) *MembershipUpdateOne {
+	if i != nil {
+		muo.SetUpdatedBy(*i)
+	}
+	return muo
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (muo *MembershipUpdateOne) AddUpdatedBy(i int) *MembershipUpdateOne {
+	muo.mutation.AddUpdatedBy(i)
+	return muo
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (muo *MembershipUpdateOne) ClearUpdatedBy() *MembershipUpdateOne {
+	muo.mutation.ClearUpdatedBy()
+	return muo
+}
+
+// SetUserID sets the "user_id" field.
+func (muo *MembershipUpdateOne) SetUserID(i int) *MembershipUpdateOne {
+	muo.mutation.SetUserID(i)
+	return muo
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (muo *MembershipUpdateOne) SetNillableUserID(i *int) *MembershipUpdateOne {
+	if i != nil {
+		muo.SetUserID(*i)
+	}
+	return muo
+}
+
+// SetRoleID sets the "role_id" field.
+func (muo *MembershipUpdateOne) SetRoleID(i int) *MembershipUpdateOne {
+	muo.mutation.SetRoleID(i)
+	return muo
+}
+
+// SetNillableRoleID sets the "role_id" field if the given value is not nil.
+func (muo *MembershipUpdateOne) SetNillableRoleID(i *int) *MembershipUpdateOne {
+	if i != nil {
+		muo.SetRoleID(*i)
+	}
+	return muo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (muo *MembershipUpdateOne) SetUser(u *User) *MembershipUpdateOne {
+	return muo.SetUserID(u.ID)
+}
+
+// SetRole sets the "role" edge to the Role entity.
+func (muo *MembershipUpdateOne) SetRole(r *Role) *MembershipUpdateOne {
+	return muo.SetRoleID(r.ID)
+}
+
+// Mutation returns the MembershipMutation object of the builder.
+func (muo *MembershipUpdateOne) Mutation() *MembershipMutation {
+	return muo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (muo *MembershipUpdateOne) ClearUser() *MembershipUpdateOne {
+	muo.mutation.ClearUser()
+	return muo
+}
+
+// ClearRole clears the "role" edge to the Role entity.
+func (muo *MembershipUpdateOne) ClearRole() *MembershipUpdateOne {
+	muo.mutation.ClearRole()
+	return muo
+}
+
+// Where appends a list predicates to the MembershipUpdate builder.
+func (muo *MembershipUpdateOne) Where(ps ...predicate.Membership) *MembershipUpdateOne {
+	muo.mutation.Where(ps...)
+	return muo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (muo *MembershipUpdateOne) Select(field string, fields ...string) *MembershipUpdateOne {
+	muo.fields = append([]string{field}, fields...)
+	return muo
+}
+
+// Save executes the query and returns the updated Membership entity.
+func (muo *MembershipUpdateOne) Save(ctx context.Context) (*Membership, error) {
+	if err := muo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, muo.sqlSave, muo.mutation, muo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (muo *MembershipUpdateOne) SaveX(ctx context.Context) *Membership {
+	node, err := muo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (muo *MembershipUpdateOne) Exec(ctx context.Context) error {
+	_, err := muo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (muo *MembershipUpdateOne) ExecX(ctx context.Context) {
+	if err := muo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (muo *MembershipUpdateOne) defaults() error {
+	if _, ok := muo.mutation.UpdatedAt(); !ok {
+		if membership.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized membership.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := membership.UpdateDefaultUpdatedAt()
+		muo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (muo *MembershipUpdateOne) check() error {
+	if muo.mutation.OrganizationCleared() && len(muo.mutation.OrganizationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.organization"`)
+	}
+	if muo.mutation.UserCleared() && len(muo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.user"`)
+	}
+	if muo.mutation.RoleCleared() && len(muo.mutation.RoleIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Membership.role"`)
+	}
+	return nil
+}
+
+func (muo *MembershipUpdateOne) sqlSave(ctx context.Context) (_node *Membership, err error) {
+	if err := muo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(membership.Table, membership.Columns, sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt))
+	id, ok := muo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Membership.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := muo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, membership.FieldID)
+		for _, f := range fields {
+			if !membership.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != membership.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := muo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := muo.mutation.UpdatedAt(); ok {
+		_spec.SetField(membership.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if muo.mutation.CreatedByCleared() {
+		_spec.ClearField(membership.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := muo.mutation.UpdatedBy(); ok {
+		_spec.SetField(membership.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := muo.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(membership.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if muo.mutation.UpdatedByCleared() {
+		_spec.ClearField(membership.FieldUpdatedBy, field.TypeInt)
+	}
+	if muo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.UserTable,
+			Columns: []string{membership.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.UserTable,
+			Columns: []string{membership.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if muo.mutation.RoleCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.RoleTable,
+			Columns: []string{membership.RoleColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := muo.mutation.RoleIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   membership.RoleTable,
+			Columns: []string{membership.RoleColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Membership{config: muo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, muo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{membership.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	muo.mutation.done = true
+	return _node, nil
+}
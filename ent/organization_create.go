@@ -0,0 +1,340 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/membership"
+	"go-template/ent/organization"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// OrganizationCreate is the builder for creating a Organization entity.
+type OrganizationCreate struct {
+	config
+	mutation *OrganizationMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (oc *OrganizationCreate) SetCreatedAt(t time.Time) *OrganizationCreate {
+	oc.mutation.SetCreatedAt(t)
+	return oc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (oc *OrganizationCreate) SetNillableCreatedAt(t *time.Time) *OrganizationCreate {
+	if t != nil {
+		oc.SetCreatedAt(*t)
+	}
+	return oc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (oc *OrganizationCreate) SetUpdatedAt(t time.Time) *OrganizationCreate {
+	oc.mutation.SetUpdatedAt(t)
+	return oc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (oc *OrganizationCreate) SetNillableUpdatedAt(t *time.Time) *OrganizationCreate {
+	if t != nil {
+		oc.SetUpdatedAt(*t)
+	}
+	return oc
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (oc *OrganizationCreate) SetCreatedBy(i int) *OrganizationCreate {
+	oc.mutation.SetCreatedBy(i)
+	return oc
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (oc *OrganizationCreate) SetNillableCreatedBy(i *int) *OrganizationCreate {
+	if i != nil {
+		oc.SetCreatedBy(*i)
+	}
+	return oc
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (oc *OrganizationCreate) SetUpdatedBy(i int) *OrganizationCreate {
+	oc.mutation.SetUpdatedBy(i)
+	return oc
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (oc *OrganizationCreate) SetNillableUpdatedBy(i *int) *OrganizationCreate {
+	if i != nil {
+		oc.SetUpdatedBy(*i)
+	}
+	return oc
+}
+
+// SetName sets the "name" field.
+func (oc *OrganizationCreate) SetName(s string) *OrganizationCreate {
+	oc.mutation.SetName(s)
+	return oc
+}
+
+// SetSlug sets the "slug" field.
+func (oc *OrganizationCreate) SetSlug(s string) *OrganizationCreate {
+	oc.mutation.SetSlug(s)
+	return oc
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (oc *OrganizationCreate) AddMembershipIDs(ids ...int) *OrganizationCreate {
+	oc.mutation.AddMembershipIDs(ids...)
+	return oc
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (oc *OrganizationCreate) AddMemberships(m ...*Membership) *OrganizationCreate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return oc.AddMembershipIDs(ids...)
+}
+
+// Mutation returns the OrganizationMutation object of the builder.
+func (oc *OrganizationCreate) Mutation() *OrganizationMutation {
+	return oc.mutation
+}
+
+// Save creates the Organization in the database.
+func (oc *OrganizationCreate) Save(ctx context.Context) (*Organization, error) {
+	if err := oc.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, oc.sqlSave, oc.mutation, oc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (oc *OrganizationCreate) SaveX(ctx context.Context) *Organization {
+	v, err := oc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (oc *OrganizationCreate) Exec(ctx context.Context) error {
+	_, err := oc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (oc *OrganizationCreate) ExecX(ctx context.Context) {
+	if err := oc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (oc *OrganizationCreate) defaults() error {
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		if organization.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized organization.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := organization.DefaultCreatedAt()
+		oc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := oc.mutation.UpdatedAt(); !ok {
+		if organization.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized organization.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := organization.DefaultUpdatedAt()
+		oc.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (oc *OrganizationCreate) check() error {
+	if _, ok := oc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Organization.created_at"`)}
+	}
+	if _, ok := oc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Organization.updated_at"`)}
+	}
+	if _, ok := oc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Organization.name"`)}
+	}
+	if v, ok := oc.mutation.Name(); ok {
+		if err := organization.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Organization.name": %w`, err)}
+		}
+	}
+	if _, ok := oc.mutation.Slug(); !ok {
+		return &ValidationError{Name: "slug", err: errors.New(`ent: missing required field "Organization.slug"`)}
+	}
+	if v, ok := oc.mutation.Slug(); ok {
+		if err := organization.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Organization.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (oc *OrganizationCreate) sqlSave(ctx context.Context) (*Organization, error) {
+	if err := oc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := oc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, oc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	oc.mutation.id = &_node.ID
+	oc.mutation.done = true
+	return _node, nil
+}
+
+func (oc *OrganizationCreate) createSpec() (*Organization, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Organization{config: oc.config}
+		_spec = sqlgraph.NewCreateSpec(organization.Table, sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt))
+	)
+	if value, ok := oc.mutation.CreatedAt(); ok {
+		_spec.SetField(organization.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := oc.mutation.UpdatedAt(); ok {
+		_spec.SetField(organization.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := oc.mutation.CreatedBy(); ok {
+		_spec.SetField(organization.FieldCreatedBy, field.TypeInt, value)
+		_node.CreatedBy = &value
+	}
+	if value, ok := oc.mutation.UpdatedBy(); ok {
+		_spec.SetField(organization.FieldUpdatedBy, field.TypeInt, value)
+		_node.UpdatedBy = &value
+	}
+	if value, ok := oc.mutation.Name(); ok {
+		_spec.SetField(organization.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := oc.mutation.Slug(); ok {
+		_spec.SetField(organization.FieldSlug, field.TypeString, value)
+		_node.Slug = value
+	}
+	if nodes := oc.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// OrganizationCreateBulk is the builder for creating many Organization entities in bulk.
+type OrganizationCreateBulk struct {
+	config
+	err      error
+	builders []*OrganizationCreate
+}
+
+// Save creates the Organization entities in the database.
+func (ocb *OrganizationCreateBulk) Save(ctx context.Context) ([]*Organization, error) {
+	if ocb.err != nil {
+		return nil, ocb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ocb.builders))
+	nodes := make([]*Organization, len(ocb.builders))
+	mutators := make([]Mutator, len(ocb.builders))
+	for i := range ocb.builders {
+		func(i int, root context.Context) {
+			builder := ocb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*OrganizationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ocb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ocb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ocb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ocb *OrganizationCreateBulk) SaveX(ctx context.Context) []*Organization {
+	v, err := ocb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ocb *OrganizationCreateBulk) Exec(ctx context.Context) error {
+	_, err := ocb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ocb *OrganizationCreateBulk) ExecX(ctx context.Context) {
+	if err := ocb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"go-template/ent/organization"
+	"go-template/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// OrganizationDelete is the builder for deleting a Organization entity.
+type OrganizationDelete struct {
+	config
+	hooks    []Hook
+	mutation *OrganizationMutation
+}
+
+// Where appends a list predicates to the OrganizationDelete builder.
+func (od *OrganizationDelete) Where(ps ...predicate.Organization) *OrganizationDelete {
+	od.mutation.Where(ps...)
+	return od
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (od *OrganizationDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, od.sqlExec, od.mutation, od.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (od *OrganizationDelete) ExecX(ctx context.Context) int {
+	n, err := od.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (od *OrganizationDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(organization.Table, sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt))
+	if ps := od.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, od.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	od.mutation.done = true
+	return affected, err
+}
+
+// OrganizationDeleteOne is the builder for deleting a single Organization entity.
+type OrganizationDeleteOne struct {
+	od *OrganizationDelete
+}
+
+// Where appends a list predicates to the OrganizationDelete builder.
+func (odo *OrganizationDeleteOne) Where(ps ...predicate.Organization) *OrganizationDeleteOne {
+	odo.od.mutation.Where(ps...)
+	return odo
+}
+
+// Exec executes the deletion query.
+func (odo *OrganizationDeleteOne) Exec(ctx context.Context) error {
+	n, err := odo.od.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{organization.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (odo *OrganizationDeleteOne) ExecX(ctx context.Context) {
+	if err := odo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
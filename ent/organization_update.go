@@ -0,0 +1,569 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/membership"
+	"go-template/ent/organization"
+	"go-template/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// OrganizationUpdate is the builder for updating Organization entities.
+type OrganizationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *OrganizationMutation
+}
+
+// Where appends a list predicates to the OrganizationUpdate builder.
+func (ou *OrganizationUpdate) Where(ps ...predicate.Organization) *OrganizationUpdate {
+	ou.mutation.Where(ps...)
+	return ou
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ou *OrganizationUpdate) SetUpdatedAt(t time.Time) *OrganizationUpdate {
+	ou.mutation.SetUpdatedAt(t)
+	return ou
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (ou *OrganizationUpdate) SetUpdatedBy(i int) *OrganizationUpdate {
+	ou.mutation.ResetUpdatedBy()
+	ou.mutation.SetUpdatedBy(i)
+	return ou
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (ou *OrganizationUpdate) SetNillableUpdatedBy(i *int) *OrganizationUpdate {
+	if i != nil {
+		ou.SetUpdatedBy(*i)
+	}
+	return ou
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (ou *OrganizationUpdate) AddUpdatedBy(i int) *OrganizationUpdate {
+	ou.mutation.AddUpdatedBy(i)
+	return ou
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (ou *OrganizationUpdate) ClearUpdatedBy() *OrganizationUpdate {
+	ou.mutation.ClearUpdatedBy()
+	return ou
+}
+
+// SetName sets the "name" field.
+func (ou *OrganizationUpdate) SetName(s string) *OrganizationUpdate {
+	ou.mutation.SetName(s)
+	return ou
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ou *OrganizationUpdate) SetNillableName(s *string) *OrganizationUpdate {
+	if s != nil {
+		ou.SetName(*s)
+	}
+	return ou
+}
+
+// SetSlug sets the "slug" field.
+func (ou *OrganizationUpdate) SetSlug(s string) *OrganizationUpdate {
+	ou.mutation.SetSlug(s)
+	return ou
+}
+
+// SetNillableSlug sets the "slug" field if the given value is not nil.
+func (ou *OrganizationUpdate) SetNillableSlug(s *string) *OrganizationUpdate {
+	if s != nil {
+		ou.SetSlug(*s)
+	}
+	return ou
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (ou *OrganizationUpdate) AddMembershipIDs(ids ...int) *OrganizationUpdate {
+	ou.mutation.AddMembershipIDs(ids...)
+	return ou
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (ou *OrganizationUpdate) AddMemberships(m ...*Membership) *OrganizationUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ou.AddMembershipIDs(ids...)
+}
+
+// Mutation returns the OrganizationMutation object of the builder.
+func (ou *OrganizationUpdate) Mutation() *OrganizationMutation {
+	return ou.mutation
+}
+
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (ou *OrganizationUpdate) ClearMemberships() *OrganizationUpdate {
+	ou.mutation.ClearMemberships()
+	return ou
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (ou *OrganizationUpdate) RemoveMembershipIDs(ids ...int) *OrganizationUpdate {
+	ou.mutation.RemoveMembershipIDs(ids...)
+	return ou
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (ou *OrganizationUpdate) RemoveMemberships(m ...*Membership) *OrganizationUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ou.RemoveMembershipIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (ou *OrganizationUpdate) Save(ctx context.Context) (int, error) {
+	if err := ou.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, ou.sqlSave, ou.mutation, ou.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ou *OrganizationUpdate) SaveX(ctx context.Context) int {
+	affected, err := ou.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (ou *OrganizationUpdate) Exec(ctx context.Context) error {
+	_, err := ou.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ou *OrganizationUpdate) ExecX(ctx context.Context) {
+	if err := ou.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ou *OrganizationUpdate) defaults() error {
+	if _, ok := ou.mutation.UpdatedAt(); !ok {
+		if organization.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized organization.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := organization.UpdateDefaultUpdatedAt()
+		ou.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ou *OrganizationUpdate) check() error {
+	if v, ok := ou.mutation.Name(); ok {
+		if err := organization.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Organization.name": %w`, err)}
+		}
+	}
+	if v, ok := ou.mutation.Slug(); ok {
+		if err := organization.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Organization.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ou *OrganizationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := ou.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(organization.Table, organization.Columns, sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt))
+	if ps := ou.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ou.mutation.UpdatedAt(); ok {
+		_spec.SetField(organization.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if ou.mutation.CreatedByCleared() {
+		_spec.ClearField(organization.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := ou.mutation.UpdatedBy(); ok {
+		_spec.SetField(organization.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := ou.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(organization.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if ou.mutation.UpdatedByCleared() {
+		_spec.ClearField(organization.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := ou.mutation.Name(); ok {
+		_spec.SetField(organization.FieldName, field.TypeString, value)
+	}
+	if value, ok := ou.mutation.Slug(); ok {
+		_spec.SetField(organization.FieldSlug, field.TypeString, value)
+	}
+	if ou.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ou.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !ou.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ou.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, ou.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{organization.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	ou.mutation.done = true
+	return n, nil
+}
+
+// OrganizationUpdateOne is the builder for updating a single Organization entity.
+type OrganizationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *OrganizationMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (ouo *OrganizationUpdateOne) SetUpdatedAt(t time.Time) *OrganizationUpdateOne {
+	ouo.mutation.SetUpdatedAt(t)
+	return ouo
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (ouo *OrganizationUpdateOne) SetUpdatedBy(i int) *OrganizationUpdateOne {
+	ouo.mutation.ResetUpdatedBy()
+	ouo.mutation.SetUpdatedBy(i)
+	return ouo
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (ouo *OrganizationUpdateOne) SetNillableUpdatedBy(i *int) *OrganizationUpdateOne {
+	if i != nil {
+		ouo.SetUpdatedBy(*i)
+	}
+	return ouo
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (ouo *OrganizationUpdateOne) AddUpdatedBy(i int) *OrganizationUpdateOne {
+	ouo.mutation.AddUpdatedBy(i)
+	return ouo
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (ouo *OrganizationUpdateOne) ClearUpdatedBy() *OrganizationUpdateOne {
+	ouo.mutation.ClearUpdatedBy()
+	return ouo
+}
+
+// SetName sets the "name" field.
+func (ouo *OrganizationUpdateOne) SetName(s string) *OrganizationUpdateOne {
+	ouo.mutation.SetName(s)
+	return ouo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (ouo *OrganizationUpdateOne) SetNillableName(s *string) *OrganizationUpdateOne {
+	if s != nil {
+		ouo.SetName(*s)
+	}
+	return ouo
+}
+
+// SetSlug sets the "slug" field.
+func (ouo *OrganizationUpdateOne) SetSlug(s string) *OrganizationUpdateOne {
+	ouo.mutation.SetSlug(s)
+	return ouo
+}
+
+// SetNillableSlug sets the "slug" field if the given value is not nil.
+func (ouo *OrganizationUpdateOne) SetNillableSlug(s *string) *OrganizationUpdateOne {
+	if s != nil {
+		ouo.SetSlug(*s)
+	}
+	return ouo
+}
+
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (ouo *OrganizationUpdateOne) AddMembershipIDs(ids ...int) *OrganizationUpdateOne {
+	ouo.mutation.AddMembershipIDs(ids...)
+	return ouo
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (ouo *OrganizationUpdateOne) AddMemberships(m ...*Membership) *OrganizationUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ouo.AddMembershipIDs(ids...)
+}
+
+// Mutation returns the OrganizationMutation object of the builder.
+func (ouo *OrganizationUpdateOne) Mutation() *OrganizationMutation {
+	return ouo.mutation
+}
+
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (ouo *OrganizationUpdateOne) ClearMemberships() *OrganizationUpdateOne {
+	ouo.mutation.ClearMemberships()
+	return ouo
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (ouo *OrganizationUpdateOne) RemoveMembershipIDs(ids ...int) *OrganizationUpdateOne {
+	ouo.mutation.RemoveMembershipIDs(ids...)
+	return ouo
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (ouo *OrganizationUpdateOne) RemoveMemberships(m ...*Membership) *OrganizationUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ouo.RemoveMembershipIDs(ids...)
+}
+
+// Where appends a list predicates to the OrganizationUpdate builder.
+func (ouo *OrganizationUpdateOne) Where(ps ...predicate.Organization) *OrganizationUpdateOne {
+	ouo.mutation.Where(ps...)
+	return ouo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (ouo *OrganizationUpdateOne) Select(field string, fields ...string) *OrganizationUpdateOne {
+	ouo.fields = append([]string{field}, fields...)
+	return ouo
+}
+
+// Save executes the query and returns the updated Organization entity.
+func (ouo *OrganizationUpdateOne) Save(ctx context.Context) (*Organization, error) {
+	if err := ouo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, ouo.sqlSave, ouo.mutation, ouo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ouo *OrganizationUpdateOne) SaveX(ctx context.Context) *Organization {
+	node, err := ouo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (ouo *OrganizationUpdateOne) Exec(ctx context.Context) error {
+	_, err := ouo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ouo *OrganizationUpdateOne) ExecX(ctx context.Context) {
+	if err := ouo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (ouo *OrganizationUpdateOne) defaults() error {
+	if _, ok := ouo.mutation.UpdatedAt(); !ok {
+		if organization.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized organization.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := organization.UpdateDefaultUpdatedAt()
+		ouo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (ouo *OrganizationUpdateOne) check() error {
+	if v, ok := ouo.mutation.Name(); ok {
+		if err := organization.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Organization.name": %w`, err)}
+		}
+	}
+	if v, ok := ouo.mutation.Slug(); ok {
+		if err := organization.SlugValidator(v); err != nil {
+			return &ValidationError{Name: "slug", err: fmt.Errorf(`ent: validator failed for field "Organization.slug": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (ouo *OrganizationUpdateOne) sqlSave(ctx context.Context) (_node *Organization, err error) {
+	if err := ouo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(organization.Table, organization.Columns, sqlgraph.NewFieldSpec(organization.FieldID, field.TypeInt))
+	id, ok := ouo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Organization.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := ouo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, organization.FieldID)
+		for _, f := range fields {
+			if !organization.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != organization.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := ouo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := ouo.mutation.UpdatedAt(); ok {
+		_spec.SetField(organization.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if ouo.mutation.CreatedByCleared() {
+		_spec.ClearField(organization.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := ouo.mutation.UpdatedBy(); ok {
+		_spec.SetField(organization.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := ouo.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(organization.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if ouo.mutation.UpdatedByCleared() {
+		_spec.ClearField(organization.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := ouo.mutation.Name(); ok {
+		_spec.SetField(organization.FieldName, field.TypeString, value)
+	}
+	if value, ok := ouo.mutation.Slug(); ok {
+		_spec.SetField(organization.FieldSlug, field.TypeString, value)
+	}
+	if ouo.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ouo.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !ouo.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ouo.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   organization.MembershipsTable,
+			Columns: []string{organization.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Organization{config: ouo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, ouo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{organization.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	ouo.mutation.done = true
+	return _node, nil
+}
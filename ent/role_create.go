@@ -6,8 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go-template/ent/membership"
 	"go-template/ent/role"
+	"go-template/ent/team"
 	"go-template/ent/user"
+	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
@@ -20,6 +23,104 @@ type RoleCreate struct {
 	hooks    []Hook
 }
 
+// SetOrganizationID sets the "organization_id" field.
+func (rc *RoleCreate) SetOrganizationID(i int) *RoleCreate {
+	rc.mutation.SetOrganizationID(i)
+	return rc
+}
+
+// SetNillableOrganizationID sets the "organization_id" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableOrganizationID(i *int) *RoleCreate {
+	if i != nil {
+		rc.SetOrganizationID(*i)
+	}
+	return rc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (rc *RoleCreate) SetCreatedAt(t time.Time) *RoleCreate {
+	rc.mutation.SetCreatedAt(t)
+	return rc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableCreatedAt(t *time.Time) *RoleCreate {
+	if t != nil {
+		rc.SetCreatedAt(*t)
+	}
+	return rc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (rc *RoleCreate) SetUpdatedAt(t time.Time) *RoleCreate {
+	rc.mutation.SetUpdatedAt(t)
+	return rc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableUpdatedAt(t *time.Time) *RoleCreate {
+	if t != nil {
+		rc.SetUpdatedAt(*t)
+	}
+	return rc
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (rc *RoleCreate) SetCreatedBy(i int) *RoleCreate {
+	rc.mutation.SetCreatedBy(i)
+	return rc
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableCreatedBy(i *int) *RoleCreate {
+	if i != nil {
+		rc.SetCreatedBy(*i)
+	}
+	return rc
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (rc *RoleCreate) SetUpdatedBy(i int) *RoleCreate {
+	rc.mutation.SetUpdatedBy(i)
+	return rc
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableUpdatedBy(i *int) *RoleCreate {
+	if i != nil {
+		rc.SetUpdatedBy(*i)
+	}
+	return rc
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (rc *RoleCreate) SetDeletedAt(t time.Time) *RoleCreate {
+	rc.mutation.SetDeletedAt(t)
+	return rc
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableDeletedAt(t *time.Time) *RoleCreate {
+	if t != nil {
+		rc.SetDeletedAt(*t)
+	}
+	return rc
+}
+
+// SetVersion sets the "version" field.
+func (rc *RoleCreate) SetVersion(i int) *RoleCreate {
+	rc.mutation.SetVersion(i)
+	return rc
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (rc *RoleCreate) SetNillableVersion(i *int) *RoleCreate {
+	if i != nil {
+		rc.SetVersion(*i)
+	}
+	return rc
+}
+
 // SetName sets the "name" field.
 func (rc *RoleCreate) SetName(s string) *RoleCreate {
 	rc.mutation.SetName(s)
@@ -47,6 +148,36 @@ func (rc *RoleCreate) AddUsers(u ...*User) *RoleCreate {
 	return rc.AddUserIDs(ids...)
 }
 
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (rc *RoleCreate) AddMembershipIDs(ids ...int) *RoleCreate {
+	rc.mutation.AddMembershipIDs(ids...)
+	return rc
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (rc *RoleCreate) AddMemberships(m ...*Membership) *RoleCreate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return rc.AddMembershipIDs(ids...)
+}
+
+// AddTeamIDs adds the "teams" edge to the Team entity by IDs.
+func (rc *RoleCreate) AddTeamIDs(ids ...int) *RoleCreate {
+	rc.mutation.AddTeamIDs(ids...)
+	return rc
+}
+
+// AddTeams adds the "teams" edges to the Team entity.
+func (rc *RoleCreate) AddTeams(t ...*Team) *RoleCreate {
+	ids := make([]int, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return rc.AddTeamIDs(ids...)
+}
+
 // Mutation returns the RoleMutation object of the builder.
 func (rc *RoleCreate) Mutation() *RoleMutation {
 	return rc.mutation
@@ -54,6 +185,9 @@ func (rc *RoleCreate) Mutation() *RoleMutation {
 
 // Save creates the Role in the database.
 func (rc *RoleCreate) Save(ctx context.Context) (*Role, error) {
+	if err := rc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, rc.sqlSave, rc.mutation, rc.hooks)
 }
 
@@ -79,8 +213,45 @@ func (rc *RoleCreate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (rc *RoleCreate) defaults() error {
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		if role.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized role.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := role.DefaultCreatedAt()
+		rc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := rc.mutation.UpdatedAt(); !ok {
+		if role.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized role.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := role.DefaultUpdatedAt()
+		rc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := rc.mutation.Version(); !ok {
+		v := role.DefaultVersion
+		rc.mutation.SetVersion(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (rc *RoleCreate) check() error {
+	if _, ok := rc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Role.created_at"`)}
+	}
+	if _, ok := rc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Role.updated_at"`)}
+	}
+	if _, ok := rc.mutation.Version(); !ok {
+		return &ValidationError{Name: "version", err: errors.New(`ent: missing required field "Role.version"`)}
+	}
+	if v, ok := rc.mutation.Version(); ok {
+		if err := role.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Role.version": %w`, err)}
+		}
+	}
 	if _, ok := rc.mutation.Name(); !ok {
 		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Role.name"`)}
 	}
@@ -123,6 +294,34 @@ func (rc *RoleCreate) createSpec() (*Role, *sqlgraph.CreateSpec) {
 		_node = &Role{config: rc.config}
 		_spec = sqlgraph.NewCreateSpec(role.Table, sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt))
 	)
+	if value, ok := rc.mutation.OrganizationID(); ok {
+		_spec.SetField(role.FieldOrganizationID, field.TypeInt, value)
+		_node.OrganizationID = &value
+	}
+	if value, ok := rc.mutation.CreatedAt(); ok {
+		_spec.SetField(role.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := rc.mutation.UpdatedAt(); ok {
+		_spec.SetField(role.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := rc.mutation.CreatedBy(); ok {
+		_spec.SetField(role.FieldCreatedBy, field.TypeInt, value)
+		_node.CreatedBy = &value
+	}
+	if value, ok := rc.mutation.UpdatedBy(); ok {
+		_spec.SetField(role.FieldUpdatedBy, field.TypeInt, value)
+		_node.UpdatedBy = &value
+	}
+	if value, ok := rc.mutation.DeletedAt(); ok {
+		_spec.SetField(role.FieldDeletedAt, field.TypeTime, value)
+		_node.DeletedAt = &value
+	}
+	if value, ok := rc.mutation.Version(); ok {
+		_spec.SetField(role.FieldVersion, field.TypeInt, value)
+		_node.Version = value
+	}
 	if value, ok := rc.mutation.Name(); ok {
 		_spec.SetField(role.FieldName, field.TypeString, value)
 		_node.Name = value
@@ -147,6 +346,38 @@ func (rc *RoleCreate) createSpec() (*Role, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := rc.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := rc.mutation.TeamsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -168,6 +399,7 @@ func (rcb *RoleCreateBulk) Save(ctx context.Context) ([]*Role, error) {
 	for i := range rcb.builders {
 		func(i int, root context.Context) {
 			builder := rcb.builders[i]
+			builder.defaults()
 			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
 				mutation, ok := m.(*RoleMutation)
 				if !ok {
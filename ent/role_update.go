@@ -6,9 +6,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"go-template/ent/membership"
 	"go-template/ent/predicate"
 	"go-template/ent/role"
+	"go-template/ent/team"
 	"go-template/ent/user"
+	"time"
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -28,6 +31,80 @@ func (ru *RoleUpdate) Where(ps ...predicate.Role) *RoleUpdate {
 	return ru
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (ru *RoleUpdate) SetUpdatedAt(t time.Time) *RoleUpdate {
+	ru.mutation.SetUpdatedAt(t)
+	return ru
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (ru *RoleUpdate) SetUpdatedBy(i int) *RoleUpdate {
+	ru.mutation.ResetUpdatedBy()
+	ru.mutation.SetUpdatedBy(i)
+	return ru
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (ru *RoleUpdate) SetNillableUpdatedBy(i *int) *RoleUpdate {
+	if i != nil {
+		ru.SetUpdatedBy(*i)
+	}
+	return ru
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (ru *RoleUpdate) AddUpdatedBy(i int) *RoleUpdate {
+	ru.mutation.AddUpdatedBy(i)
+	return ru
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (ru *RoleUpdate) ClearUpdatedBy() *RoleUpdate {
+	ru.mutation.ClearUpdatedBy()
+	return ru
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (ru *RoleUpdate) SetDeletedAt(t time.Time) *RoleUpdate {
+	ru.mutation.SetDeletedAt(t)
+	return ru
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (ru *RoleUpdate) SetNillableDeletedAt(t *time.Time) *RoleUpdate {
+	if t != nil {
+		ru.SetDeletedAt(*t)
+	}
+	return ru
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (ru *RoleUpdate) ClearDeletedAt() *RoleUpdate {
+	ru.mutation.ClearDeletedAt()
+	return ru
+}
+
+// SetVersion sets the "version" field.
+func (ru *RoleUpdate) SetVersion(i int) *RoleUpdate {
+	ru.mutation.ResetVersion()
+	ru.mutation.SetVersion(i)
+	return ru
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (ru *RoleUpdate) SetNillableVersion(i *int) *RoleUpdate {
+	if i != nil {
+		ru.SetVersion(*i)
+	}
+	return ru
+}
+
+// AddVersion adds i to the "version" field.
+func (ru *RoleUpdate) AddVersion(i int) *RoleUpdate {
+	ru.mutation.AddVersion(i)
+	return ru
+}
+
 // SetName sets the "name" field.
 func (ru *RoleUpdate) SetName(s string) *RoleUpdate {
 	ru.mutation.SetName(s)
@@ -71,6 +148,36 @@ func (ru *RoleUpdate) AddUsers(u ...*User) *RoleUpdate {
 	return ru.AddUserIDs(ids...)
 }
 
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (ru *RoleUpdate) AddMembershipIDs(ids ...int) *RoleUpdate {
+	ru.mutation.AddMembershipIDs(ids...)
+	return ru
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (ru *RoleUpdate) AddMemberships(m ...*Membership) *RoleUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ru.AddMembershipIDs(ids...)
+}
+
+// AddTeamIDs adds the "teams" edge to the Team entity by IDs.
+func (ru *RoleUpdate) AddTeamIDs(ids ...int) *RoleUpdate {
+	ru.mutation.AddTeamIDs(ids...)
+	return ru
+}
+
+// AddTeams adds the "teams" edges to the Team entity.
+func (ru *RoleUpdate) AddTeams(t ...*Team) *RoleUpdate {
+	ids := make([]int, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return ru.AddTeamIDs(ids...)
+}
+
 // Mutation returns the RoleMutation object of the builder.
 func (ru *RoleUpdate) Mutation() *RoleMutation {
 	return ru.mutation
@@ -97,8 +204,53 @@ func (ru *RoleUpdate) RemoveUsers(u ...*User) *RoleUpdate {
 	return ru.RemoveUserIDs(ids...)
 }
 
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (ru *RoleUpdate) ClearMemberships() *RoleUpdate {
+	ru.mutation.ClearMemberships()
+	return ru
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (ru *RoleUpdate) RemoveMembershipIDs(ids ...int) *RoleUpdate {
+	ru.mutation.RemoveMembershipIDs(ids...)
+	return ru
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (ru *RoleUpdate) RemoveMemberships(m ...*Membership) *RoleUpdate {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ru.RemoveMembershipIDs(ids...)
+}
+
+// ClearTeams clears all "teams" edges to the Team entity.
+func (ru *RoleUpdate) ClearTeams() *RoleUpdate {
+	ru.mutation.ClearTeams()
+	return ru
+}
+
+// RemoveTeamIDs removes the "teams" edge to Team entities by IDs.
+func (ru *RoleUpdate) RemoveTeamIDs(ids ...int) *RoleUpdate {
+	ru.mutation.RemoveTeamIDs(ids...)
+	return ru
+}
+
+// RemoveTeams removes "teams" edges to Team entities.
+func (ru *RoleUpdate) RemoveTeams(t ...*Team) *RoleUpdate {
+	ids := make([]int, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return ru.RemoveTeamIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (ru *RoleUpdate) Save(ctx context.Context) (int, error) {
+	if err := ru.defaults(); err != nil {
+		return 0, err
+	}
 	return withHooks(ctx, ru.sqlSave, ru.mutation, ru.hooks)
 }
 
@@ -124,8 +276,25 @@ func (ru *RoleUpdate) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (ru *RoleUpdate) defaults() error {
+	if _, ok := ru.mutation.UpdatedAt(); !ok {
+		if role.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized role.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := role.UpdateDefaultUpdatedAt()
+		ru.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (ru *RoleUpdate) check() error {
+	if v, ok := ru.mutation.Version(); ok {
+		if err := role.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Role.version": %w`, err)}
+		}
+	}
 	if v, ok := ru.mutation.Name(); ok {
 		if err := role.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Role.name": %w`, err)}
@@ -151,6 +320,36 @@ func (ru *RoleUpdate) sqlSave(ctx context.Context) (n int, err error) {
 			}
 		}
 	}
+	if ru.mutation.OrganizationIDCleared() {
+		_spec.ClearField(role.FieldOrganizationID, field.TypeInt)
+	}
+	if value, ok := ru.mutation.UpdatedAt(); ok {
+		_spec.SetField(role.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if ru.mutation.CreatedByCleared() {
+		_spec.ClearField(role.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := ru.mutation.UpdatedBy(); ok {
+		_spec.SetField(role.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(role.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if ru.mutation.UpdatedByCleared() {
+		_spec.ClearField(role.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := ru.mutation.DeletedAt(); ok {
+		_spec.SetField(role.FieldDeletedAt, field.TypeTime, value)
+	}
+	if ru.mutation.DeletedAtCleared() {
+		_spec.ClearField(role.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := ru.mutation.Version(); ok {
+		_spec.SetField(role.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := ru.mutation.AddedVersion(); ok {
+		_spec.AddField(role.FieldVersion, field.TypeInt, value)
+	}
 	if value, ok := ru.mutation.Name(); ok {
 		_spec.SetField(role.FieldName, field.TypeString, value)
 	}
@@ -202,6 +401,96 @@ func (ru *RoleUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if ru.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !ru.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ru.mutation.TeamsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.RemovedTeamsIDs(); len(nodes) > 0 && !ru.mutation.TeamsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ru.mutation.TeamsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, ru.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{role.Label}
@@ -222,6 +511,80 @@ type RoleUpdateOne struct {
 	mutation *RoleMutation
 }
 
+// SetUpdatedAt sets the "updated_at" field.
+func (ruo *RoleUpdateOne) SetUpdatedAt(t time.Time) *RoleUpdateOne {
+	ruo.mutation.SetUpdatedAt(t)
+	return ruo
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (ruo *RoleUpdateOne) SetUpdatedBy(i int) *RoleUpdateOne {
+	ruo.mutation.ResetUpdatedBy()
+	ruo.mutation.SetUpdatedBy(i)
+	return ruo
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (ruo *RoleUpdateOne) SetNillableUpdatedBy(i *int) *RoleUpdateOne {
+	if i != nil {
+		ruo.SetUpdatedBy(*i)
+	}
+	return ruo
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (ruo *RoleUpdateOne) AddUpdatedBy(i int) *RoleUpdateOne {
+	ruo.mutation.AddUpdatedBy(i)
+	return ruo
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (ruo *RoleUpdateOne) ClearUpdatedBy() *RoleUpdateOne {
+	ruo.mutation.ClearUpdatedBy()
+	return ruo
+}
+
+// SetDeletedAt sets the "deleted_at" field.
+func (ruo *RoleUpdateOne) SetDeletedAt(t time.Time) *RoleUpdateOne {
+	ruo.mutation.SetDeletedAt(t)
+	return ruo
+}
+
+// SetNillableDeletedAt sets the "deleted_at" field if the given value is not nil.
+func (ruo *RoleUpdateOne) SetNillableDeletedAt(t *time.Time) *RoleUpdateOne {
+	if t != nil {
+		ruo.SetDeletedAt(*t)
+	}
+	return ruo
+}
+
+// ClearDeletedAt clears the value of the "deleted_at" field.
+func (ruo *RoleUpdateOne) ClearDeletedAt() *RoleUpdateOne {
+	ruo.mutation.ClearDeletedAt()
+	return ruo
+}
+
+// SetVersion sets the "version" field.
+func (ruo *RoleUpdateOne) SetVersion(i int) *RoleUpdateOne {
+	ruo.mutation.ResetVersion()
+	ruo.mutation.SetVersion(i)
+	return ruo
+}
+
+// SetNillableVersion sets the "version" field if the given value is not nil.
+func (ruo *RoleUpdateOne) SetNillableVersion(i *int) *RoleUpdateOne {
+	if i != nil {
+		ruo.SetVersion(*i)
+	}
+	return ruo
+}
+
+// AddVersion adds i to the "version" field.
+func (ruo *RoleUpdateOne) AddVersion(i int) *RoleUpdateOne {
+	ruo.mutation.AddVersion(i)
+	return ruo
+}
+
 // SetName sets the "name" field.
 func (ruo *RoleUpdateOne) SetName(s string) *RoleUpdateOne {
 	ruo.mutation.SetName(s)
@@ -265,6 +628,36 @@ func (ruo *RoleUpdateOne) AddUsers(u ...*User) *RoleUpdateOne {
 	return ruo.AddUserIDs(ids...)
 }
 
+// AddMembershipIDs adds the "memberships" edge to the Membership entity by IDs.
+func (ruo *RoleUpdateOne) AddMembershipIDs(ids ...int) *RoleUpdateOne {
+	ruo.mutation.AddMembershipIDs(ids...)
+	return ruo
+}
+
+// AddMemberships adds the "memberships" edges to the Membership entity.
+func (ruo *RoleUpdateOne) AddMemberships(m ...*Membership) *RoleUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ruo.AddMembershipIDs(ids...)
+}
+
+// AddTeamIDs adds the "teams" edge to the Team entity by IDs.
+func (ruo *RoleUpdateOne) AddTeamIDs(ids ...int) *RoleUpdateOne {
+	ruo.mutation.AddTeamIDs(ids...)
+	return ruo
+}
+
+// AddTeams adds the "teams" edges to the Team entity.
+func (ruo *RoleUpdateOne) AddTeams(t ...*Team) *RoleUpdateOne {
+	ids := make([]int, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return ruo.AddTeamIDs(ids...)
+}
+
 // Mutation returns the RoleMutation object of the builder.
 func (ruo *RoleUpdateOne) Mutation() *RoleMutation {
 	return ruo.mutation
@@ -291,6 +684,48 @@ func (ruo *RoleUpdateOne) RemoveUsers(u ...*User) *RoleUpdateOne {
 	return ruo.RemoveUserIDs(ids...)
 }
 
+// ClearMemberships clears all "memberships" edges to the Membership entity.
+func (ruo *RoleUpdateOne) ClearMemberships() *RoleUpdateOne {
+	ruo.mutation.ClearMemberships()
+	return ruo
+}
+
+// RemoveMembershipIDs removes the "memberships" edge to Membership entities by IDs.
+func (ruo *RoleUpdateOne) RemoveMembershipIDs(ids ...int) *RoleUpdateOne {
+	ruo.mutation.RemoveMembershipIDs(ids...)
+	return ruo
+}
+
+// RemoveMemberships removes "memberships" edges to Membership entities.
+func (ruo *RoleUpdateOne) RemoveMemberships(m ...*Membership) *RoleUpdateOne {
+	ids := make([]int, len(m))
+	for i := range m {
+		ids[i] = m[i].ID
+	}
+	return ruo.RemoveMembershipIDs(ids...)
+}
+
+// ClearTeams clears all "teams" edges to the Team entity.
+func (ruo *RoleUpdateOne) ClearTeams() *RoleUpdateOne {
+	ruo.mutation.ClearTeams()
+	return ruo
+}
+
+// RemoveTeamIDs removes the "teams" edge to Team entities by IDs.
+func (ruo *RoleUpdateOne) RemoveTeamIDs(ids ...int) *RoleUpdateOne {
+	ruo.mutation.RemoveTeamIDs(ids...)
+	return ruo
+}
+
+// RemoveTeams removes "teams" edges to Team entities.
+func (ruo *RoleUpdateOne) RemoveTeams(t ...*Team) *RoleUpdateOne {
+	ids := make([]int, len(t))
+	for i := range t {
+		ids[i] = t[i].ID
+	}
+	return ruo.RemoveTeamIDs(ids...)
+}
+
 // Where appends a list predicates to the RoleUpdate builder.
 func (ruo *RoleUpdateOne) Where(ps ...predicate.Role) *RoleUpdateOne {
 	ruo.mutation.Where(ps...)
@@ -306,6 +741,9 @@ func (ruo *RoleUpdateOne) Select(field string, fields ...string) *RoleUpdateOne
 
 // Save executes the query and returns the updated Role entity.
 func (ruo *RoleUpdateOne) Save(ctx context.Context) (*Role, error) {
+	if err := ruo.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, ruo.sqlSave, ruo.mutation, ruo.hooks)
 }
 
@@ -331,8 +769,25 @@ func (ruo *RoleUpdateOne) ExecX(ctx context.Context) {
 	}
 }
 
+// defaults sets the default values of the builder before save.
+func (ruo *RoleUpdateOne) defaults() error {
+	if _, ok := ruo.mutation.UpdatedAt(); !ok {
+		if role.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized role.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := role.UpdateDefaultUpdatedAt()
+		ruo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
 // check runs all checks and user-defined validators on the builder.
 func (ruo *RoleUpdateOne) check() error {
+	if v, ok := ruo.mutation.Version(); ok {
+		if err := role.VersionValidator(v); err != nil {
+			return &ValidationError{Name: "version", err: fmt.Errorf(`ent: validator failed for field "Role.version": %w`, err)}
+		}
+	}
 	if v, ok := ruo.mutation.Name(); ok {
 		if err := role.NameValidator(v); err != nil {
 			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Role.name": %w`, err)}
@@ -375,6 +830,36 @@ func (ruo *RoleUpdateOne) sqlSave(ctx context.Context) (_node *Role, err error)
 			}
 		}
 	}
+	if ruo.mutation.OrganizationIDCleared() {
+		_spec.ClearField(role.FieldOrganizationID, field.TypeInt)
+	}
+	if value, ok := ruo.mutation.UpdatedAt(); ok {
+		_spec.SetField(role.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if ruo.mutation.CreatedByCleared() {
+		_spec.ClearField(role.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := ruo.mutation.UpdatedBy(); ok {
+		_spec.SetField(role.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(role.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if ruo.mutation.UpdatedByCleared() {
+		_spec.ClearField(role.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := ruo.mutation.DeletedAt(); ok {
+		_spec.SetField(role.FieldDeletedAt, field.TypeTime, value)
+	}
+	if ruo.mutation.DeletedAtCleared() {
+		_spec.ClearField(role.FieldDeletedAt, field.TypeTime)
+	}
+	if value, ok := ruo.mutation.Version(); ok {
+		_spec.SetField(role.FieldVersion, field.TypeInt, value)
+	}
+	if value, ok := ruo.mutation.AddedVersion(); ok {
+		_spec.AddField(role.FieldVersion, field.TypeInt, value)
+	}
 	if value, ok := ruo.mutation.Name(); ok {
 		_spec.SetField(role.FieldName, field.TypeString, value)
 	}
@@ -426,6 +911,96 @@ func (ruo *RoleUpdateOne) sqlSave(ctx context.Context) (_node *Role, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if ruo.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.RemovedMembershipsIDs(); len(nodes) > 0 && !ruo.mutation.MembershipsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.MembershipsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   role.MembershipsTable,
+			Columns: []string{role.MembershipsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(membership.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if ruo.mutation.TeamsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.RemovedTeamsIDs(); len(nodes) > 0 && !ruo.mutation.TeamsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := ruo.mutation.TeamsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: true,
+			Table:   role.TeamsTable,
+			Columns: role.TeamsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Role{config: ruo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -2,7 +2,335 @@
 
 package runtime
 
-// The schema-stitching logic is generated in go-template/ent/runtime.go
+import (
+	"go-template/ent/auditlog"
+	"go-template/ent/consolequery"
+	"go-template/ent/dataexport"
+	"go-template/ent/invitation"
+	"go-template/ent/membership"
+	"go-template/ent/organization"
+	"go-template/ent/role"
+	"go-template/ent/schema"
+	"go-template/ent/session"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"time"
+
+	"entgo.io/ent/schema/field"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescAction is the schema descriptor for action field.
+	auditlogDescAction := auditlogFields[1].Descriptor()
+	// auditlog.ActionValidator is a validator for the "action" field. It is called by the builders before save.
+	auditlog.ActionValidator = auditlogDescAction.Validators[0].(func(string) error)
+	// auditlogDescTargetType is the schema descriptor for target_type field.
+	auditlogDescTargetType := auditlogFields[2].Descriptor()
+	// auditlog.DefaultTargetType holds the default value on creation for the target_type field.
+	auditlog.DefaultTargetType = auditlogDescTargetType.Default.(string)
+	// auditlogDescTargetID is the schema descriptor for target_id field.
+	auditlogDescTargetID := auditlogFields[3].Descriptor()
+	// auditlog.DefaultTargetID holds the default value on creation for the target_id field.
+	auditlog.DefaultTargetID = auditlogDescTargetID.Default.(string)
+	// auditlogDescIP is the schema descriptor for ip field.
+	auditlogDescIP := auditlogFields[4].Descriptor()
+	// auditlog.DefaultIP holds the default value on creation for the ip field.
+	auditlog.DefaultIP = auditlogDescIP.Default.(string)
+	// auditlogDescUserAgent is the schema descriptor for user_agent field.
+	auditlogDescUserAgent := auditlogFields[5].Descriptor()
+	// auditlog.DefaultUserAgent holds the default value on creation for the user_agent field.
+	auditlog.DefaultUserAgent = auditlogDescUserAgent.Default.(string)
+	// auditlogDescRequestID is the schema descriptor for request_id field.
+	auditlogDescRequestID := auditlogFields[6].Descriptor()
+	// auditlog.DefaultRequestID holds the default value on creation for the request_id field.
+	auditlog.DefaultRequestID = auditlogDescRequestID.Default.(string)
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[8].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
+	consolequeryFields := schema.ConsoleQuery{}.Fields()
+	_ = consolequeryFields
+	// consolequeryDescError is the schema descriptor for error field.
+	consolequeryDescError := consolequeryFields[4].Descriptor()
+	// consolequery.DefaultError holds the default value on creation for the error field.
+	consolequery.DefaultError = consolequeryDescError.Default.(string)
+	// consolequeryDescRowCount is the schema descriptor for row_count field.
+	consolequeryDescRowCount := consolequeryFields[5].Descriptor()
+	// consolequery.DefaultRowCount holds the default value on creation for the row_count field.
+	consolequery.DefaultRowCount = consolequeryDescRowCount.Default.(int)
+	// consolequeryDescTruncated is the schema descriptor for truncated field.
+	consolequeryDescTruncated := consolequeryFields[6].Descriptor()
+	// consolequery.DefaultTruncated holds the default value on creation for the truncated field.
+	consolequery.DefaultTruncated = consolequeryDescTruncated.Default.(bool)
+	// consolequeryDescDurationMs is the schema descriptor for duration_ms field.
+	consolequeryDescDurationMs := consolequeryFields[7].Descriptor()
+	// consolequery.DefaultDurationMs holds the default value on creation for the duration_ms field.
+	consolequery.DefaultDurationMs = consolequeryDescDurationMs.Default.(int64)
+	// consolequeryDescCreatedAt is the schema descriptor for created_at field.
+	consolequeryDescCreatedAt := consolequeryFields[8].Descriptor()
+	// consolequery.DefaultCreatedAt holds the default value on creation for the created_at field.
+	consolequery.DefaultCreatedAt = consolequeryDescCreatedAt.Default.(func() time.Time)
+	dataexportMixin := schema.DataExport{}.Mixin()
+	dataexportMixinFields0 := dataexportMixin[0].Fields()
+	_ = dataexportMixinFields0
+	dataexportFields := schema.DataExport{}.Fields()
+	_ = dataexportFields
+	// dataexportDescCreatedAt is the schema descriptor for created_at field.
+	dataexportDescCreatedAt := dataexportMixinFields0[0].Descriptor()
+	// dataexport.DefaultCreatedAt holds the default value on creation for the created_at field.
+	dataexport.DefaultCreatedAt = dataexportDescCreatedAt.Default.(func() time.Time)
+	// dataexportDescUpdatedAt is the schema descriptor for updated_at field.
+	dataexportDescUpdatedAt := dataexportMixinFields0[1].Descriptor()
+	// dataexport.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	dataexport.DefaultUpdatedAt = dataexportDescUpdatedAt.Default.(func() time.Time)
+	// dataexport.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	dataexport.UpdateDefaultUpdatedAt = dataexportDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// dataexportDescFile is the schema descriptor for file field.
+	dataexportDescFile := dataexportFields[2].Descriptor()
+	// dataexport.DefaultFile holds the default value on creation for the file field.
+	dataexport.DefaultFile = dataexportDescFile.Default.(string)
+	// dataexportDescError is the schema descriptor for error field.
+	dataexportDescError := dataexportFields[3].Descriptor()
+	// dataexport.DefaultError holds the default value on creation for the error field.
+	dataexport.DefaultError = dataexportDescError.Default.(string)
+	invitationMixin := schema.Invitation{}.Mixin()
+	invitationMixinHooks0 := invitationMixin[0].Hooks()
+	invitationMixinHooks2 := invitationMixin[2].Hooks()
+	invitation.Hooks[0] = invitationMixinHooks0[0]
+	invitation.Hooks[1] = invitationMixinHooks2[0]
+	invitationMixinInters0 := invitationMixin[0].Interceptors()
+	invitation.Interceptors[0] = invitationMixinInters0[0]
+	invitationMixinFields1 := invitationMixin[1].Fields()
+	_ = invitationMixinFields1
+	invitationFields := schema.Invitation{}.Fields()
+	_ = invitationFields
+	// invitationDescCreatedAt is the schema descriptor for created_at field.
+	invitationDescCreatedAt := invitationMixinFields1[0].Descriptor()
+	// invitation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	invitation.DefaultCreatedAt = invitationDescCreatedAt.Default.(func() time.Time)
+	// invitationDescUpdatedAt is the schema descriptor for updated_at field.
+	invitationDescUpdatedAt := invitationMixinFields1[1].Descriptor()
+	// invitation.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	invitation.DefaultUpdatedAt = invitationDescUpdatedAt.Default.(func() time.Time)
+	// invitation.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	invitation.UpdateDefaultUpdatedAt = invitationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// invitationDescEmail is the schema descriptor for email field.
+	invitationDescEmail := invitationFields[0].Descriptor()
+	// invitation.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	invitation.EmailValidator = invitationDescEmail.Validators[0].(func(string) error)
+	// invitationDescVersion is the schema descriptor for version field.
+	invitationDescVersion := invitationFields[4].Descriptor()
+	// invitation.DefaultVersion holds the default value on creation for the version field.
+	invitation.DefaultVersion = invitationDescVersion.Default.(int)
+	// invitationDescLastSentAt is the schema descriptor for last_sent_at field.
+	invitationDescLastSentAt := invitationFields[6].Descriptor()
+	// invitation.DefaultLastSentAt holds the default value on creation for the last_sent_at field.
+	invitation.DefaultLastSentAt = invitationDescLastSentAt.Default.(func() time.Time)
+	membershipMixin := schema.Membership{}.Mixin()
+	membershipMixinHooks0 := membershipMixin[0].Hooks()
+	membershipMixinHooks2 := membershipMixin[2].Hooks()
+	membership.Hooks[0] = membershipMixinHooks0[0]
+	membership.Hooks[1] = membershipMixinHooks2[0]
+	membershipMixinInters0 := membershipMixin[0].Interceptors()
+	membership.Interceptors[0] = membershipMixinInters0[0]
+	membershipMixinFields1 := membershipMixin[1].Fields()
+	_ = membershipMixinFields1
+	membershipFields := schema.Membership{}.Fields()
+	_ = membershipFields
+	// membershipDescCreatedAt is the schema descriptor for created_at field.
+	membershipDescCreatedAt := membershipMixinFields1[0].Descriptor()
+	// membership.DefaultCreatedAt holds the default value on creation for the created_at field.
+	membership.DefaultCreatedAt = membershipDescCreatedAt.Default.(func() time.Time)
+	// membershipDescUpdatedAt is the schema descriptor for updated_at field.
+	membershipDescUpdatedAt := membershipMixinFields1[1].Descriptor()
+	// membership.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	membership.DefaultUpdatedAt = membershipDescUpdatedAt.Default.(func() time.Time)
+	// membership.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	membership.UpdateDefaultUpdatedAt = membershipDescUpdatedAt.UpdateDefault.(func() time.Time)
+	organizationMixin := schema.Organization{}.Mixin()
+	organizationMixinHooks1 := organizationMixin[1].Hooks()
+	organization.Hooks[0] = organizationMixinHooks1[0]
+	organizationInters := schema.Organization{}.Interceptors()
+	organization.Interceptors[0] = organizationInters[0]
+	organizationMixinFields0 := organizationMixin[0].Fields()
+	_ = organizationMixinFields0
+	organizationFields := schema.Organization{}.Fields()
+	_ = organizationFields
+	// organizationDescCreatedAt is the schema descriptor for created_at field.
+	organizationDescCreatedAt := organizationMixinFields0[0].Descriptor()
+	// organization.DefaultCreatedAt holds the default value on creation for the created_at field.
+	organization.DefaultCreatedAt = organizationDescCreatedAt.Default.(func() time.Time)
+	// organizationDescUpdatedAt is the schema descriptor for updated_at field.
+	organizationDescUpdatedAt := organizationMixinFields0[1].Descriptor()
+	// organization.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	organization.DefaultUpdatedAt = organizationDescUpdatedAt.Default.(func() time.Time)
+	// organization.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	organization.UpdateDefaultUpdatedAt = organizationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// organizationDescName is the schema descriptor for name field.
+	organizationDescName := organizationFields[0].Descriptor()
+	// organization.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	organization.NameValidator = organizationDescName.Validators[0].(func(string) error)
+	// organizationDescSlug is the schema descriptor for slug field.
+	organizationDescSlug := organizationFields[1].Descriptor()
+	// organization.SlugValidator is a validator for the "slug" field. It is called by the builders before save.
+	organization.SlugValidator = organizationDescSlug.Validators[0].(func(string) error)
+	roleMixin := schema.Role{}.Mixin()
+	roleMixinHooks0 := roleMixin[0].Hooks()
+	roleMixinHooks2 := roleMixin[2].Hooks()
+	roleMixinHooks3 := roleMixin[3].Hooks()
+	roleMixinHooks4 := roleMixin[4].Hooks()
+	role.Hooks[0] = roleMixinHooks0[0]
+	role.Hooks[1] = roleMixinHooks2[0]
+	role.Hooks[2] = roleMixinHooks3[0]
+	role.Hooks[3] = roleMixinHooks3[1]
+	role.Hooks[4] = roleMixinHooks4[0]
+	roleMixinInters0 := roleMixin[0].Interceptors()
+	roleMixinInters3 := roleMixin[3].Interceptors()
+	role.Interceptors[0] = roleMixinInters0[0]
+	role.Interceptors[1] = roleMixinInters3[0]
+	roleMixinFields1 := roleMixin[1].Fields()
+	_ = roleMixinFields1
+	roleMixinFields4 := roleMixin[4].Fields()
+	_ = roleMixinFields4
+	roleFields := schema.Role{}.Fields()
+	_ = roleFields
+	// roleDescCreatedAt is the schema descriptor for created_at field.
+	roleDescCreatedAt := roleMixinFields1[0].Descriptor()
+	// role.DefaultCreatedAt holds the default value on creation for the created_at field.
+	role.DefaultCreatedAt = roleDescCreatedAt.Default.(func() time.Time)
+	// roleDescUpdatedAt is the schema descriptor for updated_at field.
+	roleDescUpdatedAt := roleMixinFields1[1].Descriptor()
+	// role.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	role.DefaultUpdatedAt = roleDescUpdatedAt.Default.(func() time.Time)
+	// role.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	role.UpdateDefaultUpdatedAt = roleDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// roleDescVersion is the schema descriptor for version field.
+	roleDescVersion := roleMixinFields4[0].Descriptor()
+	// role.DefaultVersion holds the default value on creation for the version field.
+	role.DefaultVersion = roleDescVersion.Default.(int)
+	// role.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	role.VersionValidator = roleDescVersion.Validators[0].(func(int) error)
+	// roleDescName is the schema descriptor for name field.
+	roleDescName := roleFields[0].Descriptor()
+	// role.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	role.NameValidator = roleDescName.Validators[0].(func(string) error)
+	// roleDescDescription is the schema descriptor for description field.
+	roleDescDescription := roleFields[1].Descriptor()
+	// role.DescriptionValidator is a validator for the "description" field. It is called by the builders before save.
+	role.DescriptionValidator = roleDescDescription.Validators[0].(func(string) error)
+	sessionMixin := schema.Session{}.Mixin()
+	sessionMixinFields0 := sessionMixin[0].Fields()
+	_ = sessionMixinFields0
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionMixinFields0[0].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	// sessionDescUpdatedAt is the schema descriptor for updated_at field.
+	sessionDescUpdatedAt := sessionMixinFields0[1].Descriptor()
+	// session.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	session.DefaultUpdatedAt = sessionDescUpdatedAt.Default.(func() time.Time)
+	// session.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	session.UpdateDefaultUpdatedAt = sessionDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[1].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIP is the schema descriptor for ip field.
+	sessionDescIP := sessionFields[2].Descriptor()
+	// session.DefaultIP holds the default value on creation for the ip field.
+	session.DefaultIP = sessionDescIP.Default.(string)
+	// sessionDescLastSeenAt is the schema descriptor for last_seen_at field.
+	sessionDescLastSeenAt := sessionFields[3].Descriptor()
+	// session.DefaultLastSeenAt holds the default value on creation for the last_seen_at field.
+	session.DefaultLastSeenAt = sessionDescLastSeenAt.Default.(func() time.Time)
+	teamMixin := schema.Team{}.Mixin()
+	teamMixinHooks0 := teamMixin[0].Hooks()
+	teamMixinHooks2 := teamMixin[2].Hooks()
+	team.Hooks[0] = teamMixinHooks0[0]
+	team.Hooks[1] = teamMixinHooks2[0]
+	teamMixinInters0 := teamMixin[0].Interceptors()
+	team.Interceptors[0] = teamMixinInters0[0]
+	teamMixinFields1 := teamMixin[1].Fields()
+	_ = teamMixinFields1
+	teamFields := schema.Team{}.Fields()
+	_ = teamFields
+	// teamDescCreatedAt is the schema descriptor for created_at field.
+	teamDescCreatedAt := teamMixinFields1[0].Descriptor()
+	// team.DefaultCreatedAt holds the default value on creation for the created_at field.
+	team.DefaultCreatedAt = teamDescCreatedAt.Default.(func() time.Time)
+	// teamDescUpdatedAt is the schema descriptor for updated_at field.
+	teamDescUpdatedAt := teamMixinFields1[1].Descriptor()
+	// team.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	team.DefaultUpdatedAt = teamDescUpdatedAt.Default.(func() time.Time)
+	// team.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	team.UpdateDefaultUpdatedAt = teamDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// teamDescName is the schema descriptor for name field.
+	teamDescName := teamFields[0].Descriptor()
+	// team.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	team.NameValidator = teamDescName.Validators[0].(func(string) error)
+	// teamDescDescription is the schema descriptor for description field.
+	teamDescDescription := teamFields[1].Descriptor()
+	// team.DefaultDescription holds the default value on creation for the description field.
+	team.DefaultDescription = teamDescDescription.Default.(string)
+	userMixin := schema.User{}.Mixin()
+	userMixinHooks1 := userMixin[1].Hooks()
+	userMixinHooks2 := userMixin[2].Hooks()
+	userMixinHooks3 := userMixin[3].Hooks()
+	userHooks := schema.User{}.Hooks()
+	user.Hooks[0] = userMixinHooks1[0]
+	user.Hooks[1] = userMixinHooks2[0]
+	user.Hooks[2] = userMixinHooks2[1]
+	user.Hooks[3] = userMixinHooks3[0]
+	user.Hooks[4] = userHooks[0]
+	user.Hooks[5] = userHooks[1]
+	userMixinInters2 := userMixin[2].Interceptors()
+	userInters := schema.User{}.Interceptors()
+	user.Interceptors[0] = userMixinInters2[0]
+	user.Interceptors[1] = userInters[0]
+	userMixinFields0 := userMixin[0].Fields()
+	_ = userMixinFields0
+	userMixinFields3 := userMixin[3].Fields()
+	_ = userMixinFields3
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userMixinFields0[0].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	// userDescUpdatedAt is the schema descriptor for updated_at field.
+	userDescUpdatedAt := userMixinFields0[1].Descriptor()
+	// user.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	user.DefaultUpdatedAt = userDescUpdatedAt.Default.(func() time.Time)
+	// user.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	user.UpdateDefaultUpdatedAt = userDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// userDescVersion is the schema descriptor for version field.
+	userDescVersion := userMixinFields3[0].Descriptor()
+	// user.DefaultVersion holds the default value on creation for the version field.
+	user.DefaultVersion = userDescVersion.Default.(int)
+	// user.VersionValidator is a validator for the "version" field. It is called by the builders before save.
+	user.VersionValidator = userDescVersion.Validators[0].(func(int) error)
+	// userDescName is the schema descriptor for name field.
+	userDescName := userFields[0].Descriptor()
+	user.ValueScanner.Name = userDescName.ValueScanner.(field.TypeValueScanner[string])
+	// user.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	user.NameValidator = userDescName.Validators[0].(func(string) error)
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[1].Descriptor()
+	user.ValueScanner.Email = userDescEmail.ValueScanner.(field.TypeValueScanner[string])
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
+	// userDescPassword is the schema descriptor for password field.
+	userDescPassword := userFields[3].Descriptor()
+	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
+	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
+}
 
 const (
 	Version = "v0.14.4"                                         // Version of ent codegen.
@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"context"
+
+	"go-template/internal/actor"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// ActorMixin records which user created and last updated rows. The user is
+// taken from the context (see actor.NewContext); changes made without one,
+// such as self-registration or CLI commands, leave the fields untouched.
+type ActorMixin struct {
+	mixin.Schema
+}
+
+// Fields of the ActorMixin.
+func (ActorMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("created_by").
+			Optional().
+			Nillable().
+			Immutable(),
+		field.Int("updated_by").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Hooks of the ActorMixin.
+func (ActorMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				userID, ok := actor.FromContext(ctx)
+				if !ok || m.Op().Is(ent.OpDelete|ent.OpDeleteOne) {
+					return next.Mutate(ctx, m)
+				}
+				if m.Op().Is(ent.OpCreate) {
+					if err := m.SetField("created_by", userID); err != nil {
+						return nil, err
+					}
+				}
+				if err := m.SetField("updated_by", userID); err != nil {
+					return nil, err
+				}
+				return next.Mutate(ctx, m)
+			})
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// AuditLog holds the schema definition for the AuditLog entity.
+// Audit records reference users by ID only, without edges, so they
+// survive changes to the records they describe.
+type AuditLog struct {
+	ent.Schema
+}
+
+// Fields of the AuditLog.
+func (AuditLog) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("actor_id").
+			Optional(), // Zero for actions performed by the system
+		field.String("action").
+			NotEmpty(),
+		field.String("target_type").
+			Default(""),
+		field.String("target_id").
+			Default(""),
+		field.String("ip").
+			Default(""),
+		field.String("user_agent").
+			Default(""),
+		field.String("request_id").
+			Default(""),
+		field.JSON("metadata", map[string]interface{}{}).
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the AuditLog.
+func (AuditLog) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("actor_id"),
+		index.Fields("target_type", "target_id"),
+		index.Fields("created_at"),
+	}
+}
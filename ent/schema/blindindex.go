@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"context"
+
+	"go-template/pkg/fieldcrypt"
+
+	"entgo.io/ent"
+)
+
+// blindIndex returns a hook keeping the blind index field of an encrypted
+// field in step with it, for equality lookups of the encrypted value
+func blindIndex(name, indexName string) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if v, ok := m.Field(name); ok {
+				if s, ok := v.(string); ok {
+					if err := m.SetField(indexName, fieldcrypt.BlindIndex(s)); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
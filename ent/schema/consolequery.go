@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ConsoleQuery holds the schema definition for the ConsoleQuery entity.
+// Console queries are the history of the ad-hoc SQL run by admins in the
+// SQL console, refused queries included. Like audit records they reference
+// the admin by ID only.
+type ConsoleQuery struct {
+	ent.Schema
+}
+
+// Fields of the ConsoleQuery.
+func (ConsoleQuery) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.Text("query"),
+		field.Enum("format").
+			Values("json", "csv", "ndjson"),
+		field.Enum("status").
+			Values("running", "succeeded", "failed", "rejected"),
+		field.String("error").
+			Default(""),
+		field.Int("row_count").
+			Default(0),
+		field.Bool("truncated").
+			Default(false), // More rows than the row cap were available
+		field.Int64("duration_ms").
+			Default(0),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Indexes of the ConsoleQuery.
+func (ConsoleQuery) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
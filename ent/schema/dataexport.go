@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DataExport holds the schema definition for the DataExport entity.
+// A data export is an archive of the data linked to a user, built in the
+// background at the user's request. Like audit records it references the
+// user by ID only.
+type DataExport struct {
+	ent.Schema
+}
+
+// Mixin of the DataExport.
+func (DataExport) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the DataExport.
+func (DataExport) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.Enum("status").
+			Values("pending", "ready", "failed").
+			Default("pending"),
+		field.String("file").
+			Default(""), // Archive name in the export directory, once ready
+		field.String("error").
+			Default(""),
+		field.Time("expires_at"), // The archive is removed after this time
+	}
+}
+
+// Indexes of the DataExport.
+func (DataExport) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+		index.Fields("expires_at"),
+	}
+}
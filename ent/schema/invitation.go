@@ -0,0 +1,55 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Invitation holds the schema definition for the Invitation entity.
+// An invitation asks someone to join an organization by email.
+type Invitation struct {
+	ent.Schema
+}
+
+// Mixin of the Invitation.
+func (Invitation) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+		TimeMixin{},
+		ActorMixin{},
+	}
+}
+
+// Fields of the Invitation.
+func (Invitation) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").
+			NotEmpty(),
+		field.Int("role_id"),
+		field.Int("team_id").
+			Optional().
+			Nillable(),
+		field.Int("invited_by"),
+		field.Int("version").
+			Default(1), // Bumped on resend so earlier tokens stop working
+		field.Time("expires_at"),
+		field.Time("last_sent_at").
+			Default(time.Now),
+		field.Time("accepted_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the Invitation.
+func (Invitation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "email"),
+	}
+}
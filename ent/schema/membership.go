@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Membership holds the schema definition for the Membership entity.
+// A membership links a user to an organization with a per-organization role.
+type Membership struct {
+	ent.Schema
+}
+
+// Mixin of the Membership.
+func (Membership) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+		TimeMixin{},
+		ActorMixin{},
+	}
+}
+
+// Fields of the Membership.
+func (Membership) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.Int("role_id"),
+	}
+}
+
+// Edges of the Membership.
+func (Membership) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("organization", Organization.Type).
+			Ref("memberships").
+			Unique().
+			Required().
+			Immutable().
+			Field("organization_id"),
+		edge.From("user", User.Type).
+			Ref("memberships").
+			Unique().
+			Required().
+			Field("user_id"),
+		edge.From("role", Role.Type).
+			Ref("memberships").
+			Unique().
+			Required().
+			Field("role_id"),
+	}
+}
+
+// Indexes of the Membership.
+func (Membership) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "organization_id").Unique(),
+	}
+}
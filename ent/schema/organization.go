@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"context"
+
+	"go-template/ent/intercept"
+	"go-template/internal/tenant"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Organization holds the schema definition for the Organization entity.
+// Organizations are the tenants of the application.
+type Organization struct {
+	ent.Schema
+}
+
+// Mixin of the Organization.
+func (Organization) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		ActorMixin{},
+	}
+}
+
+// Fields of the Organization.
+func (Organization) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("slug").
+			NotEmpty().
+			Unique(),
+	}
+}
+
+// Edges of the Organization.
+func (Organization) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("memberships", Membership.Type),
+	}
+}
+
+// Interceptors of the Organization.
+func (Organization) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		// A tenant can only see its own organization
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if orgID, ok := tenant.FromContext(ctx); ok && !tenant.IsSystem(ctx) {
+				q.WhereP(sql.FieldEQ("id", orgID))
+			}
+			return nil
+		}),
+	}
+}
@@ -4,17 +4,30 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
 )
 
 // Role holds the schema definition for the Role entity.
+// Roles without an organization are shared by all tenants.
 type Role struct {
 	ent.Schema
 }
 
+// Mixin of the Role.
+func (Role) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{Shared: true},
+		TimeMixin{},
+		ActorMixin{},
+		SoftDeleteMixin{},
+		VersionMixin{},
+	}
+}
+
 // Fields of the Role.
 func (Role) Fields() []ent.Field {
 	return []ent.Field{
-		field.String("name").NotEmpty().Unique(),
+		field.String("name").NotEmpty(),
 		field.String("description").NotEmpty(),
 	}
 }
@@ -23,5 +36,15 @@ func (Role) Fields() []ent.Field {
 func (Role) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.To("users", User.Type),
+		edge.To("memberships", Membership.Type),
+		edge.From("teams", Team.Type).
+			Ref("roles"),
+	}
+}
+
+// Indexes of the Role.
+func (Role) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("name", "organization_id").Unique(),
 	}
 }
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Session holds the schema definition for the Session entity.
+// A session is created on every login and tracks the device it came from.
+type Session struct {
+	ent.Schema
+}
+
+// Mixin of the Session.
+func (Session) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+	}
+}
+
+// Fields of the Session.
+func (Session) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("user_id"),
+		field.String("user_agent").
+			Default(""),
+		field.String("ip").
+			Default(""),
+		field.Time("last_seen_at").
+			Default(time.Now),
+		field.Time("expires_at"),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Edges of the Session.
+func (Session) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Unique().
+			Required().
+			Field("user_id"),
+	}
+}
+
+// Indexes of the Session.
+func (Session) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
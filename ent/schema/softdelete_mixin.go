@@ -0,0 +1,105 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gen "go-template/ent"
+	"go-template/ent/hook"
+	"go-template/ent/intercept"
+	"go-template/internal/softdelete"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+)
+
+// SoftDeleteMixin turns deletes into setting deleted_at. Deleted rows are
+// hidden from queries and updates unless the context is marked with
+// softdelete.IncludeDeleted, and are only removed for good with softdelete.Hard.
+type SoftDeleteMixin struct {
+	mixin.Schema
+}
+
+// Fields of the SoftDeleteMixin.
+func (SoftDeleteMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("deleted_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the SoftDeleteMixin.
+func (SoftDeleteMixin) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("deleted_at"),
+	}
+}
+
+// Interceptors of the SoftDeleteMixin.
+func (SoftDeleteMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if !softdelete.IncludesDeleted(ctx) {
+				q.WhereP(sql.FieldIsNull("deleted_at"))
+			}
+			return nil
+		}),
+	}
+}
+
+// softDeleteMutation is implemented by the mutations of soft-deletable entities
+type softDeleteMutation interface {
+	ent.Mutation
+	SetOp(ent.Op)
+	Client() *gen.Client
+	SetDeletedAt(time.Time)
+	WhereP(...func(*sql.Selector))
+}
+
+// Hooks of the SoftDeleteMixin.
+func (SoftDeleteMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					if softdelete.IsHard(ctx) {
+						return next.Mutate(ctx, m)
+					}
+					mx, ok := m.(softDeleteMutation)
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+					// Deleting a row twice reports it as not found
+					mx.WhereP(sql.FieldIsNull("deleted_at"))
+					mx.SetOp(ent.OpUpdate)
+					mx.SetDeletedAt(time.Now())
+					// Run the update through the client so the other hooks apply
+					return mx.Client().Mutate(ctx, m)
+				})
+			},
+			ent.OpDeleteOne|ent.OpDelete,
+		),
+		// Interceptors only apply to queries, so updates are filtered here
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					if softdelete.IncludesDeleted(ctx) {
+						return next.Mutate(ctx, m)
+					}
+					mx, ok := m.(softDeleteMutation)
+					if !ok {
+						return nil, fmt.Errorf("unexpected mutation type %T", m)
+					}
+					mx.WhereP(sql.FieldIsNull("deleted_at"))
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdateOne|ent.OpUpdate,
+		),
+	}
+}
@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// Team holds the schema definition for the Team entity.
+// Teams group members of an organization and grant roles to all of them.
+type Team struct {
+	ent.Schema
+}
+
+// Mixin of the Team.
+func (Team) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TenantMixin{},
+		TimeMixin{},
+		ActorMixin{},
+	}
+}
+
+// Fields of the Team.
+func (Team) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("name").
+			NotEmpty(),
+		field.String("description").
+			Default(""),
+	}
+}
+
+// Edges of the Team.
+func (Team) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("members", User.Type),
+		edge.To("roles", Role.Type),
+	}
+}
+
+// Indexes of the Team.
+func (Team) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id", "name").Unique(),
+	}
+}
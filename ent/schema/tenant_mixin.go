@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"go-template/ent/intercept"
+	"go-template/internal/tenant"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"entgo.io/ent/schema/mixin"
+)
+
+// TenantMixin scopes an entity to an organization. Queries and mutations
+// made with a tenant in the context are filtered to that tenant, and new
+// rows are assigned to it.
+//
+// Rows of Shared entities may have no organization; those rows are visible
+// to every tenant but can only be changed outside of a tenant context.
+// Non-shared entities cannot be accessed without a tenant at all, unless
+// the context is marked with tenant.WithSystem.
+type TenantMixin struct {
+	mixin.Schema
+	Shared bool
+}
+
+// Fields of the TenantMixin.
+func (m TenantMixin) Fields() []ent.Field {
+	f := field.Int("organization_id").
+		Immutable()
+	if m.Shared {
+		f = f.Optional().Nillable()
+	}
+	return []ent.Field{f}
+}
+
+// Indexes of the TenantMixin.
+func (TenantMixin) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("organization_id"),
+	}
+}
+
+// Interceptors of the TenantMixin.
+func (m TenantMixin) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if tenant.IsSystem(ctx) {
+				return nil
+			}
+			orgID, ok := tenant.FromContext(ctx)
+			switch {
+			case ok && m.Shared:
+				q.WhereP(sql.OrPredicates(
+					sql.FieldEQ("organization_id", orgID),
+					sql.FieldIsNull("organization_id"),
+				))
+			case ok:
+				q.WhereP(sql.FieldEQ("organization_id", orgID))
+			case !m.Shared:
+				return tenant.ErrMissingTenant
+			}
+			return nil
+		}),
+	}
+}
+
+// tenantMutation is implemented by the mutations of tenant-scoped entities
+type tenantMutation interface {
+	ent.Mutation
+	WhereP(...func(*sql.Selector))
+}
+
+// Hooks of the TenantMixin.
+func (m TenantMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, mut ent.Mutation) (ent.Value, error) {
+				if tenant.IsSystem(ctx) {
+					return next.Mutate(ctx, mut)
+				}
+				tm, ok := mut.(tenantMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", mut)
+				}
+
+				orgID, hasTenant := tenant.FromContext(ctx)
+				if !hasTenant && !m.Shared {
+					return nil, tenant.ErrMissingTenant
+				}
+
+				if mut.Op().Is(ent.OpCreate) {
+					if _, set := mut.Field("organization_id"); !set && hasTenant {
+						if err := mut.SetField("organization_id", orgID); err != nil {
+							return nil, err
+						}
+					}
+					if v, set := mut.Field("organization_id"); set && hasTenant && v != orgID {
+						return nil, tenant.ErrForeignTenant
+					}
+					return next.Mutate(ctx, mut)
+				}
+
+				// Updates and deletes only reach the tenant's own rows. Edge-only
+				// UpdateOne mutations issue no row update for the predicate to
+				// apply to, so callers load the entity within the tenant first.
+				if hasTenant {
+					tm.WhereP(sql.FieldEQ("organization_id", orgID))
+				}
+				return next.Mutate(ctx, mut)
+			})
+		},
+	}
+}
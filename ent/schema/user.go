@@ -1,9 +1,15 @@
 package schema
 
 import (
-	"time"
+	"context"
+
+	"go-template/ent/intercept"
+	"go-template/internal/tenant"
+	"go-template/pkg/fieldcrypt"
 
 	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -34,25 +40,41 @@ func (User) Annotations() []schema.Annotation {
 	}
 }
 
+// Mixin of the User.
+func (User) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		TimeMixin{},
+		ActorMixin{},
+		SoftDeleteMixin{},
+		VersionMixin{},
+	}
+}
+
+// encryptedColumn widens columns of encrypted values past the 255 character
+// default of MySQL; other databases have no such limit
+var encryptedColumn = map[string]string{dialect.MySQL: "varchar(1024)"}
+
 // Fields of the User.
 func (User) Fields() []ent.Field {
 	return []ent.Field{
 		field.String("name").
-			NotEmpty(),
+			NotEmpty().
+			ValueScanner(fieldcrypt.String("users.name")).
+			SchemaType(encryptedColumn),
 		field.String("email").
 			NotEmpty().
-			Unique(),
+			ValueScanner(fieldcrypt.String("users.email")).
+			SchemaType(encryptedColumn),
+		field.String("email_index").
+			Optional().
+			Nillable().
+			Sensitive(), // Blind index of the encrypted email, set by a hook
 		field.String("password").
 			NotEmpty().
 			Sensitive(), // Marks the field as sensitive, won't be printed in logs
 		field.Enum("status").
 			Values(string(UserStatusActive), string(UserStatusDisabled)).
 			Default(string(UserStatusActive)),
-		field.Time("created_at").
-			Default(time.Now),
-		field.Time("updated_at").
-			Default(time.Now).
-			UpdateDefault(time.Now),
 		field.Int("role_id").
 			Optional(),
 	}
@@ -65,12 +87,58 @@ func (User) Edges() []ent.Edge {
 			Ref("users").
 			Unique().
 			Field("role_id"),
+		edge.To("sessions", Session.Type),
+		edge.To("memberships", Membership.Type),
+		edge.From("teams", Team.Type).
+			Ref("members"),
 	}
 }
 
 // Indexes of the User.
 func (User) Indexes() []ent.Index {
 	return []ent.Index{
-		index.Fields("email").Unique(),
+		index.Fields("email_index").Unique(),
+	}
+}
+
+// Interceptors of the User.
+func (User) Interceptors() []ent.Interceptor {
+	return []ent.Interceptor{
+		// Users are global identities; within a tenant only members are visible
+		intercept.TraverseFunc(func(ctx context.Context, q intercept.Query) error {
+			if orgID, ok := tenant.FromContext(ctx); ok && !tenant.IsSystem(ctx) {
+				q.WhereP(memberOf(orgID))
+			}
+			return nil
+		}),
+	}
+}
+
+// Hooks of the User.
+func (User) Hooks() []ent.Hook {
+	return []ent.Hook{
+		blindIndex("email", "email_index"),
+		func(next ent.Mutator) ent.Mutator {
+			return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+				// Within a tenant only members can be updated or deleted
+				orgID, ok := tenant.FromContext(ctx)
+				if ok && !tenant.IsSystem(ctx) && !m.Op().Is(ent.OpCreate) {
+					if um, ok := m.(interface{ WhereP(...func(*sql.Selector)) }); ok {
+						um.WhereP(memberOf(orgID))
+					}
+				}
+				return next.Mutate(ctx, m)
+			})
+		},
+	}
+}
+
+// memberOf returns a predicate matching users that are members of the organization
+func memberOf(orgID int) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		members := sql.Select("user_id").
+			From(sql.Table("memberships")).
+			Where(sql.EQ("organization_id", orgID))
+		s.Where(sql.In(s.C("id"), members))
 	}
 }
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"context"
+
+	"go-template/ent/hook"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+)
+
+// VersionMixin adds a version counter that is incremented by every update.
+// Handlers compare it with If-Match headers to reject stale writes.
+type VersionMixin struct {
+	mixin.Schema
+}
+
+// Fields of the VersionMixin.
+func (VersionMixin) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int("version").
+			Default(1).
+			Positive().
+			Annotations(entsql.Default("1")), // Backfills existing rows on migration
+	}
+}
+
+// Hooks of the VersionMixin.
+func (VersionMixin) Hooks() []ent.Hook {
+	return []ent.Hook{
+		hook.On(
+			func(next ent.Mutator) ent.Mutator {
+				return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+					// Soft deletes arrive here as updates and bump the version too
+					if err := m.AddField("version", 1); err != nil {
+						return nil, err
+					}
+					return next.Mutate(ctx, m)
+				})
+			},
+			ent.OpUpdate|ent.OpUpdateOne,
+		),
+	}
+}
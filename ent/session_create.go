@@ -0,0 +1,368 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/session"
+	"go-template/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// SessionCreate is the builder for creating a Session entity.
+type SessionCreate struct {
+	config
+	mutation *SessionMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (sc *SessionCreate) SetCreatedAt(t time.Time) *SessionCreate {
+	sc.mutation.SetCreatedAt(t)
+	return sc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableCreatedAt(t *time.Time) *SessionCreate {
+	if t != nil {
+		sc.SetCreatedAt(*t)
+	}
+	return sc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (sc *SessionCreate) SetUpdatedAt(t time.Time) *SessionCreate {
+	sc.mutation.SetUpdatedAt(t)
+	return sc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableUpdatedAt(t *time.Time) *SessionCreate {
+	if t != nil {
+		sc.SetUpdatedAt(*t)
+	}
+	return sc
+}
+
+// SetUserID sets the "user_id" field.
+func (sc *SessionCreate) SetUserID(i int) *SessionCreate {
+	sc.mutation.SetUserID(i)
+	return sc
+}
+
+// SetUserAgent sets the "user_agent" field.
+func (sc *SessionCreate) SetUserAgent(s string) *SessionCreate {
+	sc.mutation.SetUserAgent(s)
+	return sc
+}
+
+// SetNillableUserAgent sets the "user_agent" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableUserAgent(s *string) *SessionCreate {
+	if s != nil {
+		sc.SetUserAgent(*s)
+	}
+	return sc
+}
+
+// SetIP sets the "ip" field.
+func (sc *SessionCreate) SetIP(s string) *SessionCreate {
+	sc.mutation.SetIP(s)
+	return sc
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableIP(s *string) *SessionCreate {
+	if s != nil {
+		sc.SetIP(*s)
+	}
+	return sc
+}
+
+// SetLastSeenAt sets the "last_seen_at" field.
+func (sc *SessionCreate) SetLastSeenAt(t time.Time) *SessionCreate {
+	sc.mutation.SetLastSeenAt(t)
+	return sc
+}
+
+// SetNillableLastSeenAt sets the "last_seen_at" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableLastSeenAt(t *time.Time) *SessionCreate {
+	if t != nil {
+		sc.SetLastSeenAt(*t)
+	}
+	return sc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (sc *SessionCreate) SetExpiresAt(t time.Time) *SessionCreate {
+	sc.mutation.SetExpiresAt(t)
+	return sc
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (sc *SessionCreate) SetRevokedAt(t time.Time) *SessionCreate {
+	sc.mutation.SetRevokedAt(t)
+	return sc
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (sc *SessionCreate) SetNillableRevokedAt(t *time.Time) *SessionCreate {
+	if t != nil {
+		sc.SetRevokedAt(*t)
+	}
+	return sc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (sc *SessionCreate) SetUser(u *User) *SessionCreate {
+	return sc.SetUserID(u.ID)
+}
+
+// Mutation returns the SessionMutation object of the builder.
+func (sc *SessionCreate) Mutation() *SessionMutation {
+	return sc.mutation
+}
+
+// Save creates the Session in the database.
+func (sc *SessionCreate) Save(ctx context.Context) (*Session, error) {
+	sc.defaults()
+	return withHooks(ctx, sc.sqlSave, sc.mutation, sc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (sc *SessionCreate) SaveX(ctx context.Context) *Session {
+	v, err := sc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (sc *SessionCreate) Exec(ctx context.Context) error {
+	_, err := sc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (sc *SessionCreate) ExecX(ctx context.Context) {
+	if err := sc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (sc *SessionCreate) defaults() {
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		v := session.DefaultCreatedAt()
+		sc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := sc.mutation.UpdatedAt(); !ok {
+		v := session.DefaultUpdatedAt()
+		sc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := sc.mutation.UserAgent(); !ok {
+		v := session.DefaultUserAgent
+		sc.mutation.SetUserAgent(v)
+	}
+	if _, ok := sc.mutation.IP(); !ok {
+		v := session.DefaultIP
+		sc.mutation.SetIP(v)
+	}
+	if _, ok := sc.mutation.LastSeenAt(); !ok {
+		v := session.DefaultLastSeenAt()
+		sc.mutation.SetLastSeenAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (sc *SessionCreate) check() error {
+	if _, ok := sc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Session.created_at"`)}
+	}
+	if _, ok := sc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Session.updated_at"`)}
+	}
+	if _, ok := sc.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "Session.user_id"`)}
+	}
+	if _, ok := sc.mutation.UserAgent(); !ok {
+		return &ValidationError{Name: "user_agent", err: errors.New(`ent: missing required field "Session.user_agent"`)}
+	}
+	if _, ok := sc.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`ent: missing required field "Session.ip"`)}
+	}
+	if _, ok := sc.mutation.LastSeenAt(); !ok {
+		return &ValidationError{Name: "last_seen_at", err: errors.New(`ent: missing required field "Session.last_seen_at"`)}
+	}
+	if _, ok := sc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "Session.expires_at"`)}
+	}
+	if len(sc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Session.user"`)}
+	}
+	return nil
+}
+
+func (sc *SessionCreate) sqlSave(ctx context.Context) (*Session, error) {
+	if err := sc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := sc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, sc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	sc.mutation.id = &_node.ID
+	sc.mutation.done = true
+	return _node, nil
+}
+
+func (sc *SessionCreate) createSpec() (*Session, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Session{config: sc.config}
+		_spec = sqlgraph.NewCreateSpec(session.Table, sqlgraph.NewFieldSpec(session.FieldID, field.TypeInt))
+	)
+	if value, ok := sc.mutation.CreatedAt(); ok {
+		_spec.SetField(session.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := sc.mutation.UpdatedAt(); ok {
+		_spec.SetField(session.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := sc.mutation.UserAgent(); ok {
+		_spec.SetField(session.FieldUserAgent, field.TypeString, value)
+		_node.UserAgent = value
+	}
+	if value, ok := sc.mutation.IP(); ok {
+		_spec.SetField(session.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := sc.mutation.LastSeenAt(); ok {
+		_spec.SetField(session.FieldLastSeenAt, field.TypeTime, value)
+		_node.LastSeenAt = value
+	}
+	if value, ok := sc.mutation.ExpiresAt(); ok {
+		_spec.SetField(session.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := sc.mutation.RevokedAt(); ok {
+		_spec.SetField(session.FieldRevokedAt, field.TypeTime, value)
+		_node.RevokedAt = &value
+	}
+	if nodes := sc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   session.UserTable,
+			Columns: []string{session.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.UserID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// SessionCreateBulk is the builder for creating many Session entities in bulk.
+type SessionCreateBulk struct {
+	config
+	err      error
+	builders []*SessionCreate
+}
+
+// Save creates the Session entities in the database.
+func (scb *SessionCreateBulk) Save(ctx context.Context) ([]*Session, error) {
+	if scb.err != nil {
+		return nil, scb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(scb.builders))
+	nodes := make([]*Session, len(scb.builders))
+	mutators := make([]Mutator, len(scb.builders))
+	for i := range scb.builders {
+		func(i int, root context.Context) {
+			builder := scb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*SessionMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, scb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, scb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, scb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (scb *SessionCreateBulk) SaveX(ctx context.Context) []*Session {
+	v, err := scb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (scb *SessionCreateBulk) Exec(ctx context.Context) error {
+	_, err := scb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (scb *SessionCreateBulk) ExecX(ctx context.Context) {
+	if err := scb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
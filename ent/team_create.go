@@ -0,0 +1,392 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/role"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// TeamCreate is the builder for creating a Team entity.
+type TeamCreate struct {
+	config
+	mutation *TeamMutation
+	hooks    []Hook
+}
+
+// SetOrganizationID sets the "organization_id" field.
+func (tc *TeamCreate) SetOrganizationID(i int) *TeamCreate {
+	tc.mutation.SetOrganizationID(i)
+	return tc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (tc *TeamCreate) SetCreatedAt(t time.Time) *TeamCreate {
+	tc.mutation.SetCreatedAt(t)
+	return tc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (tc *TeamCreate) SetNillableCreatedAt(t *time.Time) *TeamCreate {
+	if t != nil {
+		tc.SetCreatedAt(*t)
+	}
+	return tc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tc *TeamCreate) SetUpdatedAt(t time.Time) *TeamCreate {
+	tc.mutation.SetUpdatedAt(t)
+	return tc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (tc *TeamCreate) SetNillableUpdatedAt(t *time.Time) *TeamCreate {
+	if t != nil {
+		tc.SetUpdatedAt(*t)
+	}
+	return tc
+}
+
+// SetCreatedBy sets the "created_by" field.
+func (tc *TeamCreate) SetCreatedBy(i int) *TeamCreate {
+	tc.mutation.SetCreatedBy(i)
+	return tc
+}
+
+// SetNillableCreatedBy sets the "created_by" field if the given value is not nil.
+func (tc *TeamCreate) SetNillableCreatedBy(i *int) *TeamCreate {
+	if i != nil {
+		tc.SetCreatedBy(*i)
+	}
+	return tc
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (tc *TeamCreate) SetUpdatedBy(i int) *TeamCreate {
+	tc.mutation.SetUpdatedBy(i)
+	return tc
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (tc *TeamCreate) SetNillableUpdatedBy(i *int) *TeamCreate {
+	if i != nil {
+		tc.SetUpdatedBy(*i)
+	}
+	return tc
+}
+
+// SetName sets the "name" field.
+func (tc *TeamCreate) SetName(s string) *TeamCreate {
+	tc.mutation.SetName(s)
+	return tc
+}
+
+// SetDescription sets the "description" field.
+func (tc *TeamCreate) SetDescription(s string) *TeamCreate {
+	tc.mutation.SetDescription(s)
+	return tc
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tc *TeamCreate) SetNillableDescription(s *string) *TeamCreate {
+	if s != nil {
+		tc.SetDescription(*s)
+	}
+	return tc
+}
+
+// AddMemberIDs adds the "members" edge to the User entity by IDs.
+func (tc *TeamCreate) AddMemberIDs(ids ...int) *TeamCreate {
+	tc.mutation.AddMemberIDs(ids...)
+	return tc
+}
+
+// AddMembers adds the "members" edges to the User entity.
+func (tc *TeamCreate) AddMembers(u ...*User) *TeamCreate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return tc.AddMemberIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (tc *TeamCreate) AddRoleIDs(ids ...int) *TeamCreate {
+	tc.mutation.AddRoleIDs(ids...)
+	return tc
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (tc *TeamCreate) AddRoles(r ...*Role) *TeamCreate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tc.AddRoleIDs(ids...)
+}
+
+// Mutation returns the TeamMutation object of the builder.
+func (tc *TeamCreate) Mutation() *TeamMutation {
+	return tc.mutation
+}
+
+// Save creates the Team in the database.
+func (tc *TeamCreate) Save(ctx context.Context) (*Team, error) {
+	if err := tc.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, tc.sqlSave, tc.mutation, tc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (tc *TeamCreate) SaveX(ctx context.Context) *Team {
+	v, err := tc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tc *TeamCreate) Exec(ctx context.Context) error {
+	_, err := tc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tc *TeamCreate) ExecX(ctx context.Context) {
+	if err := tc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tc *TeamCreate) defaults() error {
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		if team.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized team.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
+		v := team.DefaultCreatedAt()
+		tc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		if team.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized team.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := team.DefaultUpdatedAt()
+		tc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := tc.mutation.Description(); !ok {
+		v := team.DefaultDescription
+		tc.mutation.SetDescription(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tc *TeamCreate) check() error {
+	if _, ok := tc.mutation.OrganizationID(); !ok {
+		return &ValidationError{Name: "organization_id", err: errors.New(`ent: missing required field "Team.organization_id"`)}
+	}
+	if _, ok := tc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Team.created_at"`)}
+	}
+	if _, ok := tc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Team.updated_at"`)}
+	}
+	if _, ok := tc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Team.name"`)}
+	}
+	if v, ok := tc.mutation.Name(); ok {
+		if err := team.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Team.name": %w`, err)}
+		}
+	}
+	if _, ok := tc.mutation.Description(); !ok {
+		return &ValidationError{Name: "description", err: errors.New(`ent: missing required field "Team.description"`)}
+	}
+	return nil
+}
+
+func (tc *TeamCreate) sqlSave(ctx context.Context) (*Team, error) {
+	if err := tc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := tc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, tc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	tc.mutation.id = &_node.ID
+	tc.mutation.done = true
+	return _node, nil
+}
+
+func (tc *TeamCreate) createSpec() (*Team, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Team{config: tc.config}
+		_spec = sqlgraph.NewCreateSpec(team.Table, sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt))
+	)
+	if value, ok := tc.mutation.OrganizationID(); ok {
+		_spec.SetField(team.FieldOrganizationID, field.TypeInt, value)
+		_node.OrganizationID = value
+	}
+	if value, ok := tc.mutation.CreatedAt(); ok {
+		_spec.SetField(team.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := tc.mutation.UpdatedAt(); ok {
+		_spec.SetField(team.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := tc.mutation.CreatedBy(); ok {
+		_spec.SetField(team.FieldCreatedBy, field.TypeInt, value)
+		_node.CreatedBy = &value
+	}
+	if value, ok := tc.mutation.UpdatedBy(); ok {
+		_spec.SetField(team.FieldUpdatedBy, field.TypeInt, value)
+		_node.UpdatedBy = &value
+	}
+	if value, ok := tc.mutation.Name(); ok {
+		_spec.SetField(team.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := tc.mutation.Description(); ok {
+		_spec.SetField(team.FieldDescription, field.TypeString, value)
+		_node.Description = value
+	}
+	if nodes := tc.mutation.MembersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := tc.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// TeamCreateBulk is the builder for creating many Team entities in bulk.
+type TeamCreateBulk struct {
+	config
+	err      error
+	builders []*TeamCreate
+}
+
+// Save creates the Team entities in the database.
+func (tcb *TeamCreateBulk) Save(ctx context.Context) ([]*Team, error) {
+	if tcb.err != nil {
+		return nil, tcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(tcb.builders))
+	nodes := make([]*Team, len(tcb.builders))
+	mutators := make([]Mutator, len(tcb.builders))
+	for i := range tcb.builders {
+		func(i int, root context.Context) {
+			builder := tcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*TeamMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, tcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, tcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, tcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tcb *TeamCreateBulk) SaveX(ctx context.Context) []*Team {
+	v, err := tcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (tcb *TeamCreateBulk) Exec(ctx context.Context) error {
+	_, err := tcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tcb *TeamCreateBulk) ExecX(ctx context.Context) {
+	if err := tcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,722 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent/predicate"
+	"go-template/ent/role"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// TeamUpdate is the builder for updating Team entities.
+type TeamUpdate struct {
+	config
+	hooks    []Hook
+	mutation *TeamMutation
+}
+
+// Where appends a list predicates to the TeamUpdate builder.
+func (tu *TeamUpdate) Where(ps ...predicate.Team) *TeamUpdate {
+	tu.mutation.Where(ps...)
+	return tu
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tu *TeamUpdate) SetUpdatedAt(t time.Time) *TeamUpdate {
+	tu.mutation.SetUpdatedAt(t)
+	return tu
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (tu *TeamUpdate) SetUpdatedBy(i int) *TeamUpdate {
+	tu.mutation.ResetUpdatedBy()
+	tu.mutation.SetUpdatedBy(i)
+	return tu
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (tu *TeamUpdate) SetNillableUpdatedBy(i *int) *TeamUpdate {
+	if i != nil {
+		tu.SetUpdatedBy(*i)
+	}
+	return tu
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (tu *TeamUpdate) AddUpdatedBy(i int) *TeamUpdate {
+	tu.mutation.AddUpdatedBy(i)
+	return tu
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (tu *TeamUpdate) ClearUpdatedBy() *TeamUpdate {
+	tu.mutation.ClearUpdatedBy()
+	return tu
+}
+
+// SetName sets the "name" field.
+func (tu *TeamUpdate) SetName(s string) *TeamUpdate {
+	tu.mutation.SetName(s)
+	return tu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tu *TeamUpdate) SetNillableName(s *string) *TeamUpdate {
+	if s != nil {
+		tu.SetName(*s)
+	}
+	return tu
+}
+
+// SetDescription sets the "description" field.
+func (tu *TeamUpdate) SetDescription(s string) *TeamUpdate {
+	tu.mutation.SetDescription(s)
+	return tu
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tu *TeamUpdate) SetNillableDescription(s *string) *TeamUpdate {
+	if s != nil {
+		tu.SetDescription(*s)
+	}
+	return tu
+}
+
+// AddMemberIDs adds the "members" edge to the User entity by IDs.
+func (tu *TeamUpdate) AddMemberIDs(ids ...int) *TeamUpdate {
+	tu.mutation.AddMemberIDs(ids...)
+	return tu
+}
+
+// AddMembers adds the "members" edges to the User entity.
+func (tu *TeamUpdate) AddMembers(u ...*User) *TeamUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return tu.AddMemberIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (tu *TeamUpdate) AddRoleIDs(ids ...int) *TeamUpdate {
+	tu.mutation.AddRoleIDs(ids...)
+	return tu
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (tu *TeamUpdate) AddRoles(r ...*Role) *TeamUpdate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tu.AddRoleIDs(ids...)
+}
+
+// Mutation returns the TeamMutation object of the builder.
+func (tu *TeamUpdate) Mutation() *TeamMutation {
+	return tu.mutation
+}
+
+// ClearMembers clears all "members" edges to the User entity.
+func (tu *TeamUpdate) ClearMembers() *TeamUpdate {
+	tu.mutation.ClearMembers()
+	return tu
+}
+
+// RemoveMemberIDs removes the "members" edge to User entities by IDs.
+func (tu *TeamUpdate) RemoveMemberIDs(ids ...int) *TeamUpdate {
+	tu.mutation.RemoveMemberIDs(ids...)
+	return tu
+}
+
+// RemoveMembers removes "members" edges to User entities.
+func (tu *TeamUpdate) RemoveMembers(u ...*User) *TeamUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return tu.RemoveMemberIDs(ids...)
+}
+
+// ClearRoles clears all "roles" edges to the Role entity.
+func (tu *TeamUpdate) ClearRoles() *TeamUpdate {
+	tu.mutation.ClearRoles()
+	return tu
+}
+
+// RemoveRoleIDs removes the "roles" edge to Role entities by IDs.
+func (tu *TeamUpdate) RemoveRoleIDs(ids ...int) *TeamUpdate {
+	tu.mutation.RemoveRoleIDs(ids...)
+	return tu
+}
+
+// RemoveRoles removes "roles" edges to Role entities.
+func (tu *TeamUpdate) RemoveRoles(r ...*Role) *TeamUpdate {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tu.RemoveRoleIDs(ids...)
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (tu *TeamUpdate) Save(ctx context.Context) (int, error) {
+	if err := tu.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, tu.sqlSave, tu.mutation, tu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tu *TeamUpdate) SaveX(ctx context.Context) int {
+	affected, err := tu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (tu *TeamUpdate) Exec(ctx context.Context) error {
+	_, err := tu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tu *TeamUpdate) ExecX(ctx context.Context) {
+	if err := tu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tu *TeamUpdate) defaults() error {
+	if _, ok := tu.mutation.UpdatedAt(); !ok {
+		if team.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized team.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := team.UpdateDefaultUpdatedAt()
+		tu.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tu *TeamUpdate) check() error {
+	if v, ok := tu.mutation.Name(); ok {
+		if err := team.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Team.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tu *TeamUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := tu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(team.Table, team.Columns, sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt))
+	if ps := tu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tu.mutation.UpdatedAt(); ok {
+		_spec.SetField(team.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if tu.mutation.CreatedByCleared() {
+		_spec.ClearField(team.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := tu.mutation.UpdatedBy(); ok {
+		_spec.SetField(team.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := tu.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(team.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if tu.mutation.UpdatedByCleared() {
+		_spec.ClearField(team.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := tu.mutation.Name(); ok {
+		_spec.SetField(team.FieldName, field.TypeString, value)
+	}
+	if value, ok := tu.mutation.Description(); ok {
+		_spec.SetField(team.FieldDescription, field.TypeString, value)
+	}
+	if tu.mutation.MembersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemovedMembersIDs(); len(nodes) > 0 && !tu.mutation.MembersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.MembersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if tu.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RemovedRolesIDs(); len(nodes) > 0 && !tu.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tu.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, tu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{team.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	tu.mutation.done = true
+	return n, nil
+}
+
+// TeamUpdateOne is the builder for updating a single Team entity.
+type TeamUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *TeamMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (tuo *TeamUpdateOne) SetUpdatedAt(t time.Time) *TeamUpdateOne {
+	tuo.mutation.SetUpdatedAt(t)
+	return tuo
+}
+
+// SetUpdatedBy sets the "updated_by" field.
+func (tuo *TeamUpdateOne) SetUpdatedBy(i int) *TeamUpdateOne {
+	tuo.mutation.ResetUpdatedBy()
+	tuo.mutation.SetUpdatedBy(i)
+	return tuo
+}
+
+// SetNillableUpdatedBy sets the "updated_by" field if the given value is not nil.
+func (tuo *TeamUpdateOne) SetNillableUpdatedBy(i *int) *TeamUpdateOne {
+	if i != nil {
+		tuo.SetUpdatedBy(*i)
+	}
+	return tuo
+}
+
+// AddUpdatedBy adds i to the "updated_by" field.
+func (tuo *TeamUpdateOne) AddUpdatedBy(i int) *TeamUpdateOne {
+	tuo.mutation.AddUpdatedBy(i)
+	return tuo
+}
+
+// ClearUpdatedBy clears the value of the "updated_by" field.
+func (tuo *TeamUpdateOne) ClearUpdatedBy() *TeamUpdateOne {
+	tuo.mutation.ClearUpdatedBy()
+	return tuo
+}
+
+// SetName sets the "name" field.
+func (tuo *TeamUpdateOne) SetName(s string) *TeamUpdateOne {
+	tuo.mutation.SetName(s)
+	return tuo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (tuo *TeamUpdateOne) SetNillableName(s *string) *TeamUpdateOne {
+	if s != nil {
+		tuo.SetName(*s)
+	}
+	return tuo
+}
+
+// SetDescription sets the "description" field.
+func (tuo *TeamUpdateOne) SetDescription(s string) *TeamUpdateOne {
+	tuo.mutation.SetDescription(s)
+	return tuo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (tuo *TeamUpdateOne) SetNillableDescription(s *string) *TeamUpdateOne {
+	if s != nil {
+		tuo.SetDescription(*s)
+	}
+	return tuo
+}
+
+// AddMemberIDs adds the "members" edge to the User entity by IDs.
+func (tuo *TeamUpdateOne) AddMemberIDs(ids ...int) *TeamUpdateOne {
+	tuo.mutation.AddMemberIDs(ids...)
+	return tuo
+}
+
+// AddMembers adds the "members" edges to the User entity.
+func (tuo *TeamUpdateOne) AddMembers(u ...*User) *TeamUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return tuo.AddMemberIDs(ids...)
+}
+
+// AddRoleIDs adds the "roles" edge to the Role entity by IDs.
+func (tuo *TeamUpdateOne) AddRoleIDs(ids ...int) *TeamUpdateOne {
+	tuo.mutation.AddRoleIDs(ids...)
+	return tuo
+}
+
+// AddRoles adds the "roles" edges to the Role entity.
+func (tuo *TeamUpdateOne) AddRoles(r ...*Role) *TeamUpdateOne {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tuo.AddRoleIDs(ids...)
+}
+
+// Mutation returns the TeamMutation object of the builder.
+func (tuo *TeamUpdateOne) Mutation() *TeamMutation {
+	return tuo.mutation
+}
+
+// ClearMembers clears all "members" edges to the User entity.
+func (tuo *TeamUpdateOne) ClearMembers() *TeamUpdateOne {
+	tuo.mutation.ClearMembers()
+	return tuo
+}
+
+// RemoveMemberIDs removes the "members" edge to User entities by IDs.
+func (tuo *TeamUpdateOne) RemoveMemberIDs(ids ...int) *TeamUpdateOne {
+	tuo.mutation.RemoveMemberIDs(ids...)
+	return tuo
+}
+
+// RemoveMembers removes "members" edges to User entities.
+func (tuo *TeamUpdateOne) RemoveMembers(u ...*User) *TeamUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return tuo.RemoveMemberIDs(ids...)
+}
+
+// ClearRoles clears all "roles" edges to the Role entity.
+func (tuo *TeamUpdateOne) ClearRoles() *TeamUpdateOne {
+	tuo.mutation.ClearRoles()
+	return tuo
+}
+
+// RemoveRoleIDs removes the "roles" edge to Role entities by IDs.
+func (tuo *TeamUpdateOne) RemoveRoleIDs(ids ...int) *TeamUpdateOne {
+	tuo.mutation.RemoveRoleIDs(ids...)
+	return tuo
+}
+
+// RemoveRoles removes "roles" edges to Role entities.
+func (tuo *TeamUpdateOne) RemoveRoles(r ...*Role) *TeamUpdateOne {
+	ids := make([]int, len(r))
+	for i := range r {
+		ids[i] = r[i].ID
+	}
+	return tuo.RemoveRoleIDs(ids...)
+}
+
+// Where appends a list predicates to the TeamUpdate builder.
+func (tuo *TeamUpdateOne) Where(ps ...predicate.Team) *TeamUpdateOne {
+	tuo.mutation.Where(ps...)
+	return tuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (tuo *TeamUpdateOne) Select(field string, fields ...string) *TeamUpdateOne {
+	tuo.fields = append([]string{field}, fields...)
+	return tuo
+}
+
+// Save executes the query and returns the updated Team entity.
+func (tuo *TeamUpdateOne) Save(ctx context.Context) (*Team, error) {
+	if err := tuo.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, tuo.sqlSave, tuo.mutation, tuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (tuo *TeamUpdateOne) SaveX(ctx context.Context) *Team {
+	node, err := tuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (tuo *TeamUpdateOne) Exec(ctx context.Context) error {
+	_, err := tuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (tuo *TeamUpdateOne) ExecX(ctx context.Context) {
+	if err := tuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (tuo *TeamUpdateOne) defaults() error {
+	if _, ok := tuo.mutation.UpdatedAt(); !ok {
+		if team.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized team.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
+		v := team.UpdateDefaultUpdatedAt()
+		tuo.mutation.SetUpdatedAt(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (tuo *TeamUpdateOne) check() error {
+	if v, ok := tuo.mutation.Name(); ok {
+		if err := team.NameValidator(v); err != nil {
+			return &ValidationError{Name: "name", err: fmt.Errorf(`ent: validator failed for field "Team.name": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (tuo *TeamUpdateOne) sqlSave(ctx context.Context) (_node *Team, err error) {
+	if err := tuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(team.Table, team.Columns, sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt))
+	id, ok := tuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Team.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := tuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, team.FieldID)
+		for _, f := range fields {
+			if !team.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != team.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := tuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := tuo.mutation.UpdatedAt(); ok {
+		_spec.SetField(team.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if tuo.mutation.CreatedByCleared() {
+		_spec.ClearField(team.FieldCreatedBy, field.TypeInt)
+	}
+	if value, ok := tuo.mutation.UpdatedBy(); ok {
+		_spec.SetField(team.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if value, ok := tuo.mutation.AddedUpdatedBy(); ok {
+		_spec.AddField(team.FieldUpdatedBy, field.TypeInt, value)
+	}
+	if tuo.mutation.UpdatedByCleared() {
+		_spec.ClearField(team.FieldUpdatedBy, field.TypeInt)
+	}
+	if value, ok := tuo.mutation.Name(); ok {
+		_spec.SetField(team.FieldName, field.TypeString, value)
+	}
+	if value, ok := tuo.mutation.Description(); ok {
+		_spec.SetField(team.FieldDescription, field.TypeString, value)
+	}
+	if tuo.mutation.MembersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RemovedMembersIDs(); len(nodes) > 0 && !tuo.mutation.MembersCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.MembersIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.MembersTable,
+			Columns: team.MembersPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if tuo.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RemovedRolesIDs(); len(nodes) > 0 && !tuo.mutation.RolesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := tuo.mutation.RolesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   team.RolesTable,
+			Columns: team.RolesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(role.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Team{config: tuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, tuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{team.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	tuo.mutation.done = true
+	return _node, nil
+}
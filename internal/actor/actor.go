@@ -0,0 +1,19 @@
+// Package actor carries the user making a request through a context, so
+// ent hooks can record who created or changed a row. Like the tenant
+// package it holds no ent code, so schemas can import it.
+package actor
+
+import "context"
+
+type actorKey struct{}
+
+// NewContext returns a context acting on behalf of the user
+func NewContext(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, actorKey{}, userID)
+}
+
+// FromContext returns the user the context acts on behalf of
+func FromContext(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(actorKey{}).(int)
+	return userID, ok && userID != 0
+}
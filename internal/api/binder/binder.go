@@ -0,0 +1,56 @@
+// Package binder parses public identifiers from requests into internal keys
+package binder
+
+import (
+	"go-template/internal/api/response"
+	"go-template/pkg/errcode"
+	"go-template/pkg/publicid"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PathID decodes the path parameter as a public identifier of the kind. It
+// writes an error response and returns false if the parameter is malformed.
+func PathID(c *gin.Context, name string, kind publicid.Kind) (int, bool) {
+	return ID(c, kind, c.Param(name))
+}
+
+// ID decodes a public identifier of the kind. It writes an error response
+// and returns false if the identifier is malformed.
+func ID(c *gin.Context, kind publicid.Kind, s string) (int, bool) {
+	id, err := publicid.Decode(kind, s)
+	if err != nil {
+		response.Err(c, errcode.InvalidParams, "Invalid "+kind.Name+" ID")
+		return 0, false
+	}
+	return id, true
+}
+
+// IDs decodes a list of public identifiers of the kind. It writes an error
+// response and returns false if any identifier is malformed.
+func IDs(c *gin.Context, kind publicid.Kind, ss []string) ([]int, bool) {
+	ids := make([]int, 0, len(ss))
+	for _, s := range ss {
+		id, ok := ID(c, kind, s)
+		if !ok {
+			return nil, false
+		}
+		ids = append(ids, id)
+	}
+	return ids, true
+}
+
+// OptionalID decodes an optional public identifier of the kind, returning
+// nil for nil or blank values. It writes an error response and returns
+// false if the identifier is malformed.
+func OptionalID(c *gin.Context, kind publicid.Kind, s *string) (*int, bool) {
+	if s == nil || strings.TrimSpace(*s) == "" {
+		return nil, true
+	}
+	id, ok := ID(c, kind, *s)
+	if !ok {
+		return nil, false
+	}
+	return &id, true
+}
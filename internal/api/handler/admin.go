@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"go-template/ent"
+	"go-template/ent/user"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/response"
+	"go-template/internal/audit"
+	"go-template/internal/database"
+	"go-template/pkg/auth"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminHandler handles administrator-only HTTP requests
+type AdminHandler struct {
+	db      *database.Client
+	config  auth.JWTConfig
+	auditor *audit.Recorder
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(db *database.Client, config auth.JWTConfig, auditor *audit.Recorder) *AdminHandler {
+	return &AdminHandler{db: db, config: config, auditor: auditor}
+}
+
+// ImpersonateInput represents the input for starting an impersonation
+type ImpersonateInput struct {
+	Reason string `json:"reason" binding:"required" example:"Debugging ticket #1234"`
+}
+
+// ImpersonationResponse represents an issued impersonation token
+type ImpersonationResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+	User      UserInfo  `json:"user"`
+	ActorID   string    `json:"actor_id" example:"usr_q7vm9zp2hc54e"`
+}
+
+// Impersonate godoc
+// @Summary      Impersonate a user
+// @Description  Issue a short-lived token to act as the given user. The token carries the admin as actor and cannot be refreshed.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string            true  "User ID"
+// @Param        input  body      ImpersonateInput  true  "Impersonation reason"
+// @Success      200  {object}   response.Response{data=ImpersonationResponse} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.not_found | user.disabled | auth.access.denied"
+// @Router       /admin/users/{id}/impersonate [post]
+// @Security     BearerAuth
+func (h *AdminHandler) Impersonate(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
+		return
+	}
+
+	var input ImpersonateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	actor := auth.Actor{
+		UserID:   c.GetInt("userID"),
+		Username: c.GetString("username"),
+	}
+	if id == actor.UserID {
+		response.Err(c, errcode.InvalidParams, "Cannot impersonate yourself")
+		return
+	}
+
+	u, err := h.db.Ent.User.Query().
+		Where(user.ID(id)).
+		WithRole().
+		Only(c.Request.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.UserNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch user: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch user")
+		return
+	}
+
+	if u.Status == user.StatusDisabled {
+		response.Err(c, errcode.UserDisabled)
+		return
+	}
+
+	roleName := "user"
+	if u.Edges.Role != nil {
+		roleName = u.Edges.Role.Name
+	}
+	if roleName == "admin" {
+		response.Err(c, errcode.AuthAccessDenied, "Cannot impersonate another administrator")
+		return
+	}
+
+	// Impersonation must never happen without an audit record
+	entry := newAuditEntry(c, audit.ActionImpersonate, "user", strconv.Itoa(u.ID))
+	entry.Metadata = map[string]interface{}{
+		"reason":  input.Reason,
+		"expires": h.config.ImpersonationExpire.String(),
+	}
+	if err := h.auditor.Record(c.Request.Context(), entry); err != nil {
+		logger.Errorf("Failed to record impersonation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to start impersonation")
+		return
+	}
+
+	identity := auth.Identity{
+		UserID:    u.ID,
+		Username:  u.Name,
+		Role:      roleName,
+		SessionID: c.GetInt("sessionID"),
+	}
+	token, err := auth.GenerateImpersonationToken(identity, actor, h.config)
+	if err != nil {
+		logger.Errorf("Failed to generate impersonation token: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to generate impersonation token")
+		return
+	}
+
+	response.Ok(c, ImpersonationResponse{
+		Token:     token,
+		ExpiresAt: time.Now().Add(h.config.ImpersonationExpire),
+		User: UserInfo{
+			ID:    publicid.Encode(publicid.User, u.ID),
+			Name:  u.Name,
+			Email: u.Email,
+			Role:  roleName,
+		},
+		ActorID: publicid.Encode(publicid.User, actor.UserID),
+	})
+}
@@ -1,36 +1,53 @@
 package handler
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"go-template/ent"
-	"go-template/ent/role"
+	"go-template/ent/invitation"
+	"go-template/ent/membership"
+	"go-template/ent/team"
 	"go-template/ent/user"
+	"go-template/internal/actor"
+	"go-template/internal/api/binder"
 	"go-template/internal/api/response"
+	"go-template/internal/audit"
 	"go-template/internal/database"
+	"go-template/internal/organization"
+	"go-template/internal/pii"
+	"go-template/internal/session"
+	"go-template/internal/tenant"
 	"go-template/pkg/auth"
 	"go-template/pkg/errcode"
 	"go-template/pkg/logger"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 )
 
 // AuthHandler handles authentication-related HTTP requests
 type AuthHandler struct {
-	db     *database.Client
-	config auth.JWTConfig
+	db       *database.Client
+	config   auth.JWTConfig
+	sessions *session.Store
+	auditor  *audit.Recorder
 }
 
 // NewAuthHandler creates a new authentication handler
-func NewAuthHandler(db *database.Client, config auth.JWTConfig) *AuthHandler {
-	return &AuthHandler{db: db, config: config}
+func NewAuthHandler(db *database.Client, config auth.JWTConfig, sessions *session.Store, auditor *audit.Recorder) *AuthHandler {
+	return &AuthHandler{db: db, config: config, sessions: sessions, auditor: auditor}
 }
 
 // RegisterInput represents the input for user registration
 type RegisterInput struct {
 	Name     string `json:"name" binding:"required" example:"John Doe"`
 	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
-	Password string `json:"password" binding:"required,min=6" example:"password123"`
+	Password string `json:"password" binding:"required" example:"password123"`
 }
 
 // Register godoc
@@ -41,7 +58,7 @@ type RegisterInput struct {
 // @Produce      json
 // @Param        user  body      RegisterInput  true  "User registration data"
 // @Success      200  {object}   response.Response{data=UserInfo} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.register.error"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.register.error ｜ user.password.weak"
 // @Router       /auth/register [post]
 func (h *AuthHandler) Register(c *gin.Context) {
 	var input RegisterInput
@@ -51,97 +68,281 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	// Check if user already exists
-	exists, err := h.db.Ent.User.Query().
-		Where(user.EmailEQ(input.Email)).
-		Exist(c.Request.Context())
-
+	u, defaultRole, err := h.registerUser(c.Request.Context(), input)
 	if err != nil {
-		logger.Errorf("Failed to check user existence: %v", err)
+		if e, ok := err.(*errcode.Error); ok {
+			response.Err(c, e.Code, e.Message)
+			return
+		}
+		logger.Errorf("Failed to register user: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to process registration")
 		return
 	}
 
-	if exists {
-		response.Err(c, errcode.UserRegisterError, "Email already registered")
+	// Return user info
+	userInfo := UserInfo{
+		ID:    publicid.Encode(publicid.User, u.ID),
+		Name:  u.Name,
+		Email: u.Email,
+		Role:  defaultRole.Name,
+	}
+
+	response.Ok(c, userInfo)
+}
+
+// InvitationAcceptInput represents the input for accepting an invitation.
+// Name and password are only needed when no account exists for the invited email.
+type InvitationAcceptInput struct {
+	Token    string `json:"token" binding:"required"`
+	Name     string `json:"name" example:"John Doe"`
+	Password string `json:"password" example:"password123"`
+}
+
+// InvitationAcceptResponse represents the result of accepting an invitation
+type InvitationAcceptResponse struct {
+	User           UserInfo `json:"user"`
+	OrganizationID string   `json:"organization_id" example:"org_8d2fk5w0nq1ye"`
+	Created        bool     `json:"created"` // Whether a new account was registered
+}
+
+// AcceptInvitation godoc
+// @Summary      Accept an invitation
+// @Description  Join the inviting organization, registering an account for the invited email if none exists
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        invitation  body      InvitationAcceptInput  true  "Invitation token and optional account data"
+// @Success      200  {object}   response.Response{data=InvitationAcceptResponse} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ invitation.invalid ｜ user.disabled ｜ user.password.weak"
+// @Router       /auth/invitations/accept [post]
+func (h *AuthHandler) AcceptInvitation(c *gin.Context) {
+	var input InvitationAcceptInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
 		return
 	}
 
-	// Hash password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	claims, err := auth.ParseInvitationToken(input.Token, h.config.Secret)
 	if err != nil {
-		logger.Errorf("Failed to hash password: %v", err)
-		response.Err(c, errcode.ServerError, "Failed to process registration")
+		response.Err(c, errcode.InvitationInvalid)
 		return
 	}
 
-	// Find default user role
-	defaultRole, err := h.db.Ent.Role.Query().
-		Where(role.NameEQ("user")).
-		Only(c.Request.Context())
+	ctx := tenant.NewContext(c.Request.Context(), claims.OrgID)
 
+	inv, err := h.db.Ent.Invitation.Get(ctx, claims.InvitationID)
 	if err != nil {
 		if ent.IsNotFound(err) {
-			// Create default role if it doesn't exist
-			defaultRole, err = h.db.Ent.Role.Create().
-				SetName("user").
-				SetDescription("Regular user with standard permissions").
-				Save(c.Request.Context())
+			response.Err(c, errcode.InvitationInvalid)
+			return
+		}
+		logger.Errorf("Failed to fetch invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to accept invitation")
+		return
+	}
 
+	// Resending bumps the version, so only the latest link is honored
+	if inv.Version != claims.Version || inv.AcceptedAt != nil || inv.RevokedAt != nil ||
+		time.Now().After(inv.ExpiresAt) || !strings.EqualFold(inv.Email, claims.Email) {
+		response.Err(c, errcode.InvitationInvalid)
+		return
+	}
+
+	// A registered account and its membership are committed together, so a
+	// failed join leaves no account behind and the invitation can be retried
+	var (
+		u       *ent.User
+		created bool
+	)
+	err = h.db.WithTx(c.Request.Context(), nil, func(txCtx context.Context, tx *ent.Tx) error {
+		orgCtx := tenant.NewContext(txCtx, claims.OrgID)
+
+		// Link an existing account, or register one for the invited email
+		var err error
+		u, err = tx.User.Query().
+			Where(pii.EmailIs(inv.Email)).
+			WithRole().
+			Only(tenant.WithSystem(orgCtx))
+		switch {
+		case err == nil:
+			if u.Status == user.StatusDisabled {
+				return errcode.New(errcode.UserDisabled)
+			}
+		case ent.IsNotFound(err):
+			if input.Name == "" || input.Password == "" {
+				return errcode.New(errcode.InvalidParams).WithMessage("Name and password are required to create an account")
+			}
+			var defaultRole *ent.Role
+			u, defaultRole, err = h.registerUser(txCtx, RegisterInput{
+				Name:     input.Name,
+				Email:    inv.Email,
+				Password: input.Password,
+			})
 			if err != nil {
-				logger.Errorf("Failed to create default role: %v", err)
-				response.Err(c, errcode.ServerError, "Failed to create user role")
-				return
+				return err
 			}
-		} else {
-			logger.Errorf("Failed to fetch default role: %v", err)
-			response.Err(c, errcode.ServerError, "Failed to process registration")
+			u.Edges.Role = defaultRole
+			created = true
+		default:
+			return fmt.Errorf("fetching user: %w", err)
+		}
+
+		// The invitee joins on their own behalf
+		return joinInvitation(actor.NewContext(orgCtx, u.ID), tx, inv, u.ID)
+	})
+	if err != nil {
+		if e, ok := err.(*errcode.Error); ok {
+			response.Err(c, e.Code, e.Message)
 			return
 		}
+		logger.Errorf("Failed to accept invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to accept invitation")
+		return
+	}
+
+	info := UserInfo{
+		ID:    publicid.Encode(publicid.User, u.ID),
+		Name:  u.Name,
+		Email: u.Email,
+	}
+	if u.Edges.Role != nil {
+		info.Role = u.Edges.Role.Name
+	}
+
+	response.Ok(c, InvitationAcceptResponse{
+		User:           info,
+		OrganizationID: publicid.Encode(publicid.Organization, inv.OrganizationID),
+		Created:        created,
+	})
+}
+
+// joinInvitation marks the invitation accepted and adds the user to its
+// organization and team within tx
+func joinInvitation(ctx context.Context, tx *ent.Tx, inv *ent.Invitation, userID int) error {
+	// Claim the invitation so concurrent accepts cannot both succeed
+	n, err := tx.Invitation.Update().
+		Where(
+			invitation.ID(inv.ID),
+			invitation.Version(inv.Version),
+			invitation.AcceptedAtIsNil(),
+			invitation.RevokedAtIsNil(),
+		).
+		SetAcceptedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errcode.New(errcode.InvitationInvalid)
+	}
+
+	isMember, err := tx.Membership.Query().
+		Where(membership.UserID(userID)).
+		Exist(ctx)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		err = tx.Membership.Create().
+			SetUserID(userID).
+			SetRoleID(inv.RoleID).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if inv.TeamID == nil {
+		return nil
+	}
+	// The team may have been deleted after the invitation was sent
+	t, err := tx.Team.Query().
+		Where(team.ID(*inv.TeamID)).
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	inTeam, err := t.QueryMembers().Where(user.ID(userID)).Exist(ctx)
+	if err != nil || inTeam {
+		return err
+	}
+	return t.Update().
+		AddMemberIDs(userID).
+		Exec(ctx)
+}
+
+// registerUser creates an account with the default role. Client errors are
+// returned as *errcode.Error, anything else is a server error.
+func (h *AuthHandler) registerUser(ctx context.Context, input RegisterInput) (*ent.User, *ent.Role, error) {
+	// Check password policy
+	if err := password.Validate(input.Password); err != nil {
+		return nil, nil, errcode.New(errcode.UserPasswordWeak).WithMessage(err.Error())
+	}
+
+	// Check if user already exists
+	exists, err := h.db.Ent.User.Query().
+		Where(pii.EmailIs(input.Email)).
+		Exist(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("checking user existence: %w", err)
+	}
+	if exists {
+		return nil, nil, errcode.New(errcode.UserRegisterError).WithMessage("Email already registered")
+	}
+
+	// Hash password
+	hashedPassword, err := password.Hash(input.Password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("hashing password: %w", err)
+	}
+
+	// Find default user role, creating it if it doesn't exist
+	defaultRole, err := sharedRole(ctx, h.db.Ent, "user", "Regular user with standard permissions")
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetching default role: %w", err)
 	}
 
 	// Create user
 	u, err := h.db.Ent.User.Create().
 		SetName(input.Name).
 		SetEmail(input.Email).
-		SetPassword(string(hashedPassword)).
+		SetPassword(hashedPassword).
 		SetRoleID(defaultRole.ID).
-		Save(c.Request.Context())
-
+		Save(ctx)
 	if err != nil {
-		logger.Errorf("Failed to create user: %v", err)
-		response.Err(c, errcode.UserRegisterError, "Failed to register user")
-		return
-	}
-
-	// Return user info
-	userInfo := UserInfo{
-		ID:    u.ID,
-		Name:  u.Name,
-		Email: u.Email,
-		Role:  defaultRole.Name,
+		if ent.IsConstraintError(err) {
+			return nil, nil, errcode.New(errcode.UserRegisterError).WithMessage("Email already registered")
+		}
+		return nil, nil, fmt.Errorf("creating user: %w", err)
 	}
 
-	response.Ok(c, userInfo)
+	return u, defaultRole, nil
 }
 
 // LoginInput represents the input for user login
 type LoginInput struct {
-	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
-	Password string `json:"password" binding:"required" example:"password123"`
+	Email          string `json:"email" binding:"required,email" example:"john@example.com"`
+	Password       string `json:"password" binding:"required" example:"password123"`
+	OrganizationID string `json:"organization_id" example:"org_8d2fk5w0nq1ye"` // Optional organization to sign in to
 }
 
 // LoginResponse represents the response from a successful login
 type LoginResponse struct {
-	Token        string    `json:"token"`
-	RefreshToken string    `json:"refresh_token"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	User         UserInfo  `json:"user"`
+	Token          string    `json:"token"`
+	RefreshToken   string    `json:"refresh_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	User           UserInfo  `json:"user"`
+	OrganizationID string    `json:"organization_id,omitempty" example:"org_8d2fk5w0nq1ye"`
+	OrgRole        string    `json:"org_role,omitempty"`
 }
 
 // UserInfo represents basic user information
 type UserInfo struct {
-	ID    int    `json:"id"`
+	ID    string `json:"id" example:"usr_q7vm9zp2hc54e"`
 	Name  string `json:"name"`
 	Email string `json:"email"`
 	Role  string `json:"role"`
@@ -155,7 +356,7 @@ type UserInfo struct {
 // @Produce      json
 // @Param        credentials  body      LoginInput  true  "Login credentials"
 // @Success      200  {object}   response.Response{data=LoginResponse} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.login.error ｜ user.disabled"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.login.error ｜ user.disabled | org.not_found | org.access.denied"
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
 	var input LoginInput
@@ -167,7 +368,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	// Find user by email
 	u, err := h.db.Ent.User.Query().
-		Where(user.EmailEQ(input.Email)).
+		Where(pii.EmailIs(input.Email)).
 		WithRole().
 		Only(c.Request.Context())
 
@@ -188,19 +389,55 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Check password
-	err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(input.Password))
+	ok, err := password.Verify(input.Password, u.Password)
 	if err != nil {
+		logger.Warnf("Failed to verify password hash of user %d: %v", u.ID, err)
+	}
+	if !ok {
 		response.Err(c, errcode.UserLoginError, "Invalid email or password")
 		return
 	}
 
-	// Generate JWT token
+	// Upgrade outdated password hashes transparently
+	if password.NeedsRehash(u.Password) {
+		h.rehashPassword(c, u.ID, input.Password)
+	}
+
 	roleName := "user"
 	if u.Edges.Role != nil {
 		roleName = u.Edges.Role.Name
 	}
 
-	token, err := auth.GenerateToken(u.ID, u.Name, roleName, h.config)
+	// Check membership of the requested organization
+	var orgID int
+	var orgRole string
+	if input.OrganizationID != "" {
+		if orgID, ok = binder.ID(c, publicid.Organization, input.OrganizationID); !ok {
+			return
+		}
+		if orgRole, ok = h.resolveOrgRole(c, u.ID, roleName, orgID); !ok {
+			return
+		}
+	}
+
+	// Record the session with device metadata
+	sess, err := h.sessions.Create(c.Request.Context(), u.ID, deviceFromRequest(c), time.Now().Add(h.config.RefreshExpire))
+	if err != nil {
+		logger.Errorf("Failed to create session: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to authenticate user")
+		return
+	}
+
+	// Generate JWT token
+	identity := auth.Identity{
+		UserID:    u.ID,
+		Username:  u.Name,
+		Role:      roleName,
+		SessionID: sess.ID,
+		OrgID:     orgID,
+	}
+
+	token, err := auth.GenerateToken(identity, h.config)
 	if err != nil {
 		logger.Errorf("Failed to generate token: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to generate authentication token")
@@ -208,7 +445,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	// Generate refresh token
-	refreshToken, err := auth.GenerateRefreshToken(u.ID, u.Name, h.config)
+	refreshToken, err := auth.GenerateRefreshToken(identity, h.config)
 	if err != nil {
 		logger.Errorf("Failed to generate refresh token: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to generate refresh token")
@@ -221,16 +458,66 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		RefreshToken: refreshToken,
 		ExpiresAt:    time.Now().Add(h.config.Expiration),
 		User: UserInfo{
-			ID:    u.ID,
+			ID:    publicid.Encode(publicid.User, u.ID),
 			Name:  u.Name,
 			Email: u.Email,
 			Role:  roleName,
 		},
+		OrganizationID: encodeOrgID(orgID),
+		OrgRole:        orgRole,
 	}
 
 	response.Ok(c, resp)
 }
 
+// resolveOrgRole returns the user's role in the organization, writing the
+// error response and returning false when the user cannot access it
+func (h *AuthHandler) resolveOrgRole(c *gin.Context, userID int, globalRole string, orgID int) (string, bool) {
+	orgRole, err := organization.ResolveRole(c.Request.Context(), h.db, userID, globalRole, orgID)
+	if err != nil {
+		switch {
+		case errors.Is(err, organization.ErrNotFound):
+			response.Err(c, errcode.OrgNotFound)
+		case errors.Is(err, organization.ErrNotMember):
+			response.Err(c, errcode.OrgAccessDenied)
+		default:
+			logger.Errorf("Failed to resolve organization role: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to resolve organization")
+		}
+		return "", false
+	}
+	return orgRole, true
+}
+
+// encodeOrgID returns the public ID of the organization a token is scoped
+// to, or an empty string for tokens without one
+func encodeOrgID(orgID int) string {
+	if orgID == 0 {
+		return ""
+	}
+	return publicid.Encode(publicid.Organization, orgID)
+}
+
+// rehashPassword replaces the stored hash of a user with one produced by the
+// current algorithm. Failures are logged and never fail the login.
+func (h *AuthHandler) rehashPassword(c *gin.Context, userID int, plain string) {
+	hashedPassword, err := password.Hash(plain)
+	if err != nil {
+		logger.Errorf("Failed to rehash password of user %d: %v", userID, err)
+		return
+	}
+
+	err = h.db.Ent.User.UpdateOneID(userID).
+		SetPassword(hashedPassword).
+		Exec(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to store rehashed password of user %d: %v", userID, err)
+		return
+	}
+
+	logger.Infof("Upgraded password hash of user %d", userID)
+}
+
 // RefreshInput represents the input for token refresh
 type RefreshInput struct {
 	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`
@@ -244,7 +531,7 @@ type RefreshInput struct {
 // @Produce      json
 // @Param        refresh  body      RefreshInput  true  "Refresh token"
 // @Success      200  {object}   response.Response{data=LoginResponse} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ auth.token.expired | auth.token.invalid | user.not_found | user.disabled"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ auth.token.expired | auth.token.invalid | auth.session.invalid | user.not_found | user.disabled | org.not_found | org.access.denied"
 // @Router       /auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var input RefreshInput
@@ -255,7 +542,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	// Parse refresh token
-	userID, err := auth.ParseRefreshToken(input.RefreshToken, h.config.Secret)
+	claims, err := auth.ParseRefreshToken(input.RefreshToken, h.config.Secret)
 	if err != nil {
 		if err == auth.ErrExpiredToken {
 			response.Err(c, errcode.AuthTokenExpired)
@@ -267,7 +554,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 	// Get user information
 	u, err := h.db.Ent.User.Query().
-		Where(user.ID(userID)).
+		Where(user.ID(claims.UserID)).
 		WithRole().
 		Only(c.Request.Context())
 
@@ -287,13 +574,53 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	// Generate new JWT token
 	roleName := "user"
 	if u.Edges.Role != nil {
 		roleName = u.Edges.Role.Name
 	}
 
-	token, err := auth.GenerateToken(u.ID, u.Name, roleName, h.config)
+	// Re-check membership, which may have been removed since login
+	var orgRole string
+	if claims.OrgID != 0 {
+		var ok bool
+		if orgRole, ok = h.resolveOrgRole(c, u.ID, roleName, claims.OrgID); !ok {
+			return
+		}
+	}
+
+	// Extend the session, or start one for refresh tokens issued before sessions were tracked
+	sessionID := claims.SessionID
+	expiresAt := time.Now().Add(h.config.RefreshExpire)
+	if sessionID != 0 {
+		if err := h.sessions.Extend(c.Request.Context(), u.ID, sessionID, expiresAt); err != nil {
+			if errors.Is(err, session.ErrRevoked) {
+				response.Err(c, errcode.AuthSessionInvalid)
+				return
+			}
+			logger.Errorf("Failed to extend session: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to refresh token")
+			return
+		}
+	} else {
+		sess, err := h.sessions.Create(c.Request.Context(), u.ID, deviceFromRequest(c), expiresAt)
+		if err != nil {
+			logger.Errorf("Failed to create session: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to refresh token")
+			return
+		}
+		sessionID = sess.ID
+	}
+
+	// Generate new JWT token
+	identity := auth.Identity{
+		UserID:    u.ID,
+		Username:  u.Name,
+		Role:      roleName,
+		SessionID: sessionID,
+		OrgID:     claims.OrgID,
+	}
+
+	token, err := auth.GenerateToken(identity, h.config)
 	if err != nil {
 		logger.Errorf("Failed to generate token: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to generate authentication token")
@@ -301,7 +628,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	// Generate new refresh token
-	refreshToken, err := auth.GenerateRefreshToken(u.ID, u.Name, h.config)
+	refreshToken, err := auth.GenerateRefreshToken(identity, h.config)
 	if err != nil {
 		logger.Errorf("Failed to generate refresh token: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to generate refresh token")
@@ -314,11 +641,13 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		RefreshToken: refreshToken,
 		ExpiresAt:    time.Now().Add(h.config.Expiration),
 		User: UserInfo{
-			ID:    u.ID,
+			ID:    publicid.Encode(publicid.User, u.ID),
 			Name:  u.Name,
 			Email: u.Email,
 			Role:  roleName,
 		},
+		OrganizationID: encodeOrgID(claims.OrgID),
+		OrgRole:        orgRole,
 	}
 
 	response.Ok(c, resp)
@@ -366,7 +695,7 @@ func (h *AuthHandler) GetUserInfo(c *gin.Context) {
 
 	// Return user info
 	info := UserInfo{
-		ID:    u.ID,
+		ID:    publicid.Encode(publicid.User, u.ID),
 		Name:  u.Name,
 		Email: u.Email,
 		Role:  roleName,
@@ -374,3 +703,319 @@ func (h *AuthHandler) GetUserInfo(c *gin.Context) {
 
 	response.Ok(c, info)
 }
+
+// SessionInfo represents an active login session
+type SessionInfo struct {
+	ID         string    `json:"id" example:"ses_5w1hq9c3tz0pd"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// ListSessions godoc
+// @Summary      List active sessions
+// @Description  Returns the active login sessions of the current user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   response.Response{data=[]SessionInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ user.unauthorized"
+// @Router       /auth/sessions [get]
+// @Security     BearerAuth
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	sessions, err := h.sessions.ListActive(c.Request.Context(), userID)
+	if err != nil {
+		logger.Errorf("Failed to fetch sessions: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch sessions")
+		return
+	}
+
+	currentID := c.GetInt("sessionID")
+	infos := make([]SessionInfo, 0, len(sessions))
+	for _, s := range sessions {
+		infos = append(infos, SessionInfo{
+			ID:         publicid.Encode(publicid.Session, s.ID),
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.ID == currentID,
+		})
+	}
+
+	response.Ok(c, infos)
+}
+
+// RevokeSession godoc
+// @Summary      Revoke a session
+// @Description  Revokes one of the current user's sessions, signing that device out
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string true  "Session ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | session.not_found"
+// @Router       /auth/sessions/{id} [delete]
+// @Security     BearerAuth
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	id, ok := binder.PathID(c, "id", publicid.Session)
+	if !ok {
+		return
+	}
+
+	if err := h.sessions.Revoke(c.Request.Context(), userID, id); err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			response.Err(c, errcode.SessionNotFound)
+			return
+		}
+		logger.Errorf("Failed to revoke session: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to revoke session")
+		return
+	}
+
+	response.OkWithMessage(c, "Session revoked successfully", nil)
+}
+
+// deviceFromRequest extracts session device metadata from the request
+func deviceFromRequest(c *gin.Context) session.Device {
+	return session.Device{
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+	}
+}
+
+// ProfileUpdateInput represents the input for updating the current user's profile.
+// Omitted fields are left unchanged.
+type ProfileUpdateInput struct {
+	Name  *string `json:"name" binding:"omitempty,min=1" example:"John Doe"`
+	Email *string `json:"email" binding:"omitempty,email" example:"john@example.com"`
+}
+
+// UpdateProfile godoc
+// @Summary      Update current user profile
+// @Description  Updates profile fields of the currently authenticated user
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        profile  body      ProfileUpdateInput  true  "Profile fields"
+// @Success      200  {object}   response.Response{data=UserInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.email.taken | auth.impersonation.forbidden"
+// @Router       /auth/me [patch]
+// @Security     BearerAuth
+func (h *AuthHandler) UpdateProfile(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	var input ProfileUpdateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	update := h.db.Ent.User.UpdateOneID(userID)
+
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.Email != nil {
+		// Check the email is not used by another account
+		taken, err := h.db.Ent.User.Query().
+			Where(pii.EmailIs(*input.Email), user.IDNEQ(userID)).
+			Exist(ctx)
+		if err != nil {
+			logger.Errorf("Failed to check email usage: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to update profile")
+			return
+		}
+		if taken {
+			response.Err(c, errcode.UserEmailTaken)
+			return
+		}
+		update = update.SetEmail(*input.Email)
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.UserNotFound)
+			return
+		}
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.UserEmailTaken)
+			return
+		}
+		logger.Errorf("Failed to update profile: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update profile")
+		return
+	}
+
+	h.GetUserInfo(c)
+}
+
+// PasswordChangeInput represents the input for changing the current user's password
+type PasswordChangeInput struct {
+	CurrentPassword string `json:"current_password" binding:"required" example:"password123"`
+	NewPassword     string `json:"new_password" binding:"required" example:"newPassword456"`
+}
+
+// ChangePassword godoc
+// @Summary      Change password
+// @Description  Changes the current user's password and signs out all other sessions
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      PasswordChangeInput  true  "Current and new password"
+// @Success      200  {object}   response.Response{data=map[string]int} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | user.password.weak | auth.impersonation.forbidden"
+// @Router       /auth/me/password [post]
+// @Security     BearerAuth
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	var input PasswordChangeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if !verifyCurrentPassword(c, h.db, userID, input.CurrentPassword) {
+		return
+	}
+
+	if err := password.Validate(input.NewPassword); err != nil {
+		response.Err(c, errcode.UserPasswordWeak, err.Error())
+		return
+	}
+
+	hashedPassword, err := password.Hash(input.NewPassword)
+	if err != nil {
+		logger.Errorf("Failed to hash password: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to change password")
+		return
+	}
+
+	if err := h.db.Ent.User.UpdateOneID(userID).SetPassword(hashedPassword).Exec(ctx); err != nil {
+		logger.Errorf("Failed to update password: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to change password")
+		return
+	}
+
+	// Sign out every other device
+	revoked, err := h.sessions.RevokeAll(ctx, userID, c.GetInt("sessionID"))
+	if err != nil {
+		logger.Errorf("Failed to revoke sessions after password change: %v", err)
+	}
+
+	if err := h.auditor.Record(ctx, newAuditEntry(c, audit.ActionPasswordChange, "user", strconv.Itoa(userID))); err != nil {
+		logger.Errorf("Failed to record password change: %v", err)
+	}
+
+	response.OkWithMessage(c, "Password changed successfully", map[string]int{
+		"revoked_sessions": revoked,
+	})
+}
+
+// AccountDeleteInput represents the input for deleting the current user's account
+type AccountDeleteInput struct {
+	Password string `json:"password" binding:"required" example:"password123"`
+}
+
+// DeleteAccount godoc
+// @Summary      Delete own account
+// @Description  Deletes the current user's account after re-entering the password
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      AccountDeleteInput  true  "Password confirmation"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | auth.impersonation.forbidden"
+// @Router       /auth/me [delete]
+// @Security     BearerAuth
+func (h *AuthHandler) DeleteAccount(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	var input AccountDeleteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if !verifyCurrentPassword(c, h.db, userID, input.Password) {
+		return
+	}
+
+	// Sign out everywhere as the account goes away. The sessions are kept,
+	// revoked, along with the soft-deleted user.
+	err := h.db.WithTx(ctx, nil, func(txCtx context.Context, tx *ent.Tx) error {
+		if _, err := h.sessions.RevokeAll(txCtx, userID, 0); err != nil {
+			return err
+		}
+		return tx.User.DeleteOneID(userID).Exec(txCtx)
+	})
+	if err != nil {
+		logger.Errorf("Failed to delete account: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to delete account")
+		return
+	}
+
+	if err := h.auditor.Record(ctx, newAuditEntry(c, audit.ActionAccountDeletion, "user", strconv.Itoa(userID))); err != nil {
+		logger.Errorf("Failed to record account deletion: %v", err)
+	}
+
+	response.OkWithMessage(c, "Account deleted successfully", nil)
+}
+
+// verifyCurrentPassword re-authenticates the user with their password,
+// writing the error response and returning false when it does not match
+func verifyCurrentPassword(c *gin.Context, db *database.Client, userID int, plain string) bool {
+	u, err := db.Ent.User.Get(c.Request.Context(), userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.UserNotFound)
+			return false
+		}
+		logger.Errorf("Failed to fetch user: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to verify password")
+		return false
+	}
+
+	ok, err := password.Verify(plain, u.Password)
+	if err != nil {
+		logger.Warnf("Failed to verify password hash of user %d: %v", u.ID, err)
+	}
+	if !ok {
+		response.Err(c, errcode.UserPasswordWrong)
+		return false
+	}
+
+	return true
+}
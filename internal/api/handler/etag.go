@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"go-template/internal/api/response"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// entityTag formats an entity version as a strong entity tag
+func entityTag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setETag sets the ETag header of the response to the entity version
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", entityTag(version))
+}
+
+// notModified sets the ETag header of a read and reports whether it matches
+// the If-None-Match header, in which case a 304 response has been written
+func notModified(c *gin.Context, version int) bool {
+	setETag(c, version)
+
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	tag := entityTag(version)
+	for _, t := range strings.Split(header, ",") {
+		// If-None-Match uses the weak comparison
+		t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+		if t == "*" || t == tag {
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersions returns the entity versions accepted by the If-Match
+// header, or nil when the write is unconditional. Weak and malformed tags
+// never match, so a header without a usable tag yields an empty slice.
+func ifMatchVersions(c *gin.Context) []int {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	versions := []int{}
+	for _, t := range strings.Split(header, ",") {
+		t = strings.TrimSpace(t)
+		if len(t) < 3 || t[0] != '"' || t[len(t)-1] != '"' {
+			continue
+		}
+		if v, err := strconv.Atoi(t[1 : len(t)-1]); err == nil {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// versionMatches reports whether the If-Match header accepts the entity
+// version, writing a 412 response if it does not
+func versionMatches(c *gin.Context, version int) bool {
+	versions := ifMatchVersions(c)
+	if versions == nil {
+		return true
+	}
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	response.ErrWithStatus(c, http.StatusPreconditionFailed, errcode.ResourceModified)
+	return false
+}
+
+// notFoundOrModified reports a conditional write that matched no row. It
+// writes 412 if the entity still exists, so its version must have changed,
+// and the not found error otherwise.
+func notFoundOrModified(c *gin.Context, conditional bool, exists func() (bool, error), notFound string) {
+	if conditional {
+		ok, err := exists()
+		if err != nil {
+			logger.Errorf("Failed to check entity existence: %v", err)
+			response.Err(c, errcode.ServerError)
+			return
+		}
+		if ok {
+			response.ErrWithStatus(c, http.StatusPreconditionFailed, errcode.ResourceModified)
+			return
+		}
+	}
+	response.Err(c, notFound)
+}
@@ -1,6 +1,12 @@
 package handler
 
 import (
+	"context"
+	"go-template/internal/api/policy"
+	"go-template/internal/api/response"
+	"go-template/internal/audit"
+	"go-template/internal/softdelete"
+	"go-template/pkg/errcode"
 	"go-template/pkg/logger"
 	"net/http"
 
@@ -15,3 +21,69 @@ func Welcome(c *gin.Context) {
 		"version": "1.0.0",
 	})
 }
+
+// newAuditEntry builds an audit entry for the current request. For
+// impersonated requests the real actor is recorded, with the impersonated
+// user kept in the metadata by callers that need it.
+func newAuditEntry(c *gin.Context, action, targetType, targetID string) audit.Entry {
+	actorID := c.GetInt("userID")
+	if id := c.GetInt("actorID"); id != 0 {
+		actorID = id
+	}
+
+	return audit.Entry{
+		ActorID:    actorID,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         c.ClientIP(),
+		UserAgent:  c.Request.UserAgent(),
+		RequestID:  c.GetString("RequestID"),
+	}
+}
+
+// deletedScope returns the request context, widened to soft-deleted rows
+// when an admin asks for them with ?include_deleted=true. It writes an
+// error response and returns false if a non-admin asks for them.
+func deletedScope(c *gin.Context) (context.Context, bool) {
+	ctx := c.Request.Context()
+	if c.Query("include_deleted") != "true" {
+		return ctx, true
+	}
+	if !policy.SubjectFrom(c).IsAdmin() {
+		response.Err(c, errcode.AuthAccessDenied, "Only admins can view deleted records")
+		return nil, false
+	}
+	return softdelete.IncludeDeleted(ctx), true
+}
+
+// defaultPageSize is the page size of paginated lists without page_size
+const defaultPageSize = 20
+
+// Pagination is the page requested from a paginated list
+type Pagination struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"page_size" binding:"omitempty,min=1,max=100"`
+}
+
+// Offset returns the number of items before the page
+func (p Pagination) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// bindPagination reads the page query parameters, defaulting to the first
+// page. It writes an error response and returns false if they are invalid.
+func bindPagination(c *gin.Context) (Pagination, bool) {
+	var p Pagination
+	if err := c.ShouldBindQuery(&p); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return p, false
+	}
+	if p.Page == 0 {
+		p.Page = 1
+	}
+	if p.PageSize == 0 {
+		p.PageSize = defaultPageSize
+	}
+	return p, true
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"go-template/internal/api/response"
+	"go-template/internal/database"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// healthTimeout bounds how long a health check waits for the database
+const healthTimeout = 2 * time.Second
+
+// HealthHandler reports whether the service can serve requests
+type HealthHandler struct {
+	db *database.Client
+}
+
+// NewHealthHandler creates a new health handler
+func NewHealthHandler(db *database.Client) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// Check responds 200 while the database is reachable and 503 with db.error
+// otherwise, so load balancers and orchestrators can take the instance out
+// of rotation. It is served at /health, outside the API base path.
+func (h *HealthHandler) Check(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), healthTimeout)
+	defer cancel()
+
+	if err := h.db.Ping(ctx); err != nil {
+		logger.Errorf("Health check failed: %v", err)
+		response.ErrWithStatus(c, http.StatusServiceUnavailable, errcode.DBError)
+		return
+	}
+	response.Ok(c, nil)
+}
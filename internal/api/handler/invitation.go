@@ -0,0 +1,364 @@
+package handler
+
+import (
+	"go-template/ent"
+	entinv "go-template/ent/invitation"
+	"go-template/ent/membership"
+	"go-template/ent/role"
+	"go-template/ent/team"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/response"
+	"go-template/internal/database"
+	"go-template/internal/invitation"
+	"go-template/internal/pii"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Invitation statuses
+const (
+	InvitationStatusPending  = "pending"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// InvitationHandler handles invitation-related HTTP requests. All queries
+// run in the organization selected with X-Org-ID.
+type InvitationHandler struct {
+	db     *database.Client
+	sender *invitation.Sender
+}
+
+// NewInvitationHandler creates a new invitation handler
+func NewInvitationHandler(db *database.Client, sender *invitation.Sender) *InvitationHandler {
+	return &InvitationHandler{db: db, sender: sender}
+}
+
+// InvitationInfo represents an invitation to an organization
+type InvitationInfo struct {
+	ID         string    `json:"id" example:"inv_7pz2me4k9wq0b"`
+	Email      string    `json:"email"`
+	RoleID     string    `json:"role_id" example:"rol_3kq8x0v1mz7ta"`
+	TeamID     *string   `json:"team_id,omitempty"`
+	InvitedBy  string    `json:"invited_by" example:"usr_q7vm9zp2hc54e"`
+	Status     string    `json:"status" enums:"pending,expired,accepted,revoked"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	LastSentAt time.Time `json:"last_sent_at"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UpdatedBy  *string   `json:"updated_by,omitempty"`
+}
+
+// newInvitationInfo builds the response for an invitation
+func newInvitationInfo(inv *ent.Invitation) InvitationInfo {
+	status := InvitationStatusPending
+	switch {
+	case inv.AcceptedAt != nil:
+		status = InvitationStatusAccepted
+	case inv.RevokedAt != nil:
+		status = InvitationStatusRevoked
+	case time.Now().After(inv.ExpiresAt):
+		status = InvitationStatusExpired
+	}
+
+	return InvitationInfo{
+		ID:         publicid.Encode(publicid.Invitation, inv.ID),
+		Email:      inv.Email,
+		RoleID:     publicid.Encode(publicid.Role, inv.RoleID),
+		TeamID:     publicid.EncodeOptional(publicid.Team, inv.TeamID),
+		InvitedBy:  publicid.Encode(publicid.User, inv.InvitedBy),
+		Status:     status,
+		ExpiresAt:  inv.ExpiresAt,
+		LastSentAt: inv.LastSentAt,
+		CreatedAt:  inv.CreatedAt,
+		UpdatedAt:  inv.UpdatedAt,
+		UpdatedBy:  publicid.EncodeOptional(publicid.User, inv.UpdatedBy),
+	}
+}
+
+// List godoc
+// @Summary      List invitations
+// @Description  Get the invitations of the organization selected with X-Org-ID, pending ones by default
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string  true   "Organization ID"
+// @Param        status    query   string  false  "Filter by status" Enums(pending, all)
+// @Success      200  {object}   response.Response{data=[]InvitationInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params"
+// @Router       /orgs/current/invitations [get]
+// @Security     BearerAuth
+func (h *InvitationHandler) List(c *gin.Context) {
+	query := h.db.Ent.Invitation.Query()
+
+	switch c.DefaultQuery("status", InvitationStatusPending) {
+	case InvitationStatusPending:
+		query = query.Where(
+			entinv.AcceptedAtIsNil(),
+			entinv.RevokedAtIsNil(),
+			entinv.ExpiresAtGT(time.Now()),
+		)
+	case "all":
+	default:
+		response.Err(c, errcode.InvalidParams, "Invalid status filter")
+		return
+	}
+
+	invitations, err := query.
+		Order(ent.Desc(entinv.FieldCreatedAt)).
+		All(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to fetch invitations: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch invitations")
+		return
+	}
+
+	infos := make([]InvitationInfo, 0, len(invitations))
+	for _, inv := range invitations {
+		infos = append(infos, newInvitationInfo(inv))
+	}
+
+	response.Ok(c, infos)
+}
+
+// InvitationCreateInput represents the input for inviting someone to an organization
+type InvitationCreateInput struct {
+	Email  string  `json:"email" binding:"required,email" example:"jane@example.com"`
+	RoleID string  `json:"role_id" binding:"required" example:"rol_3kq8x0v1mz7ta"`
+	TeamID *string `json:"team_id" example:"team_0c6yb4r8sx2fn"` // Optional team to join on acceptance
+}
+
+// Create godoc
+// @Summary      Invite by email
+// @Description  Email a signed, expiring invitation to join the organization selected with X-Org-ID
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID    header  string                 true  "Organization ID"
+// @Param        invitation  body    InvitationCreateInput  true  "Invitation Info"
+// @Success      200  {object}   response.Response{data=InvitationInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | role.not_found | team.not_found | org.member.exists | invitation.pending"
+// @Router       /orgs/current/invitations [post]
+// @Security     BearerAuth
+func (h *InvitationHandler) Create(c *gin.Context) {
+	var input InvitationCreateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+	input.Email = strings.TrimSpace(input.Email)
+
+	roleID, ok := binder.ID(c, publicid.Role, input.RoleID)
+	if !ok {
+		return
+	}
+	teamID, ok := binder.OptionalID(c, publicid.Team, input.TeamID)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Only shared roles and the organization's own roles are visible here
+	roleExists, err := h.db.Ent.Role.Query().Where(role.ID(roleID)).Exist(ctx)
+	if err != nil {
+		logger.Errorf("Failed to check role existence: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create invitation")
+		return
+	}
+	if !roleExists {
+		response.Err(c, errcode.RoleNotFound)
+		return
+	}
+
+	if teamID != nil {
+		teamExists, err := h.db.Ent.Team.Query().Where(team.ID(*teamID)).Exist(ctx)
+		if err != nil {
+			logger.Errorf("Failed to check team existence: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to create invitation")
+			return
+		}
+		if !teamExists {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+	}
+
+	isMember, err := h.db.Ent.Membership.Query().
+		Where(membership.HasUserWith(pii.EmailIs(input.Email))).
+		Exist(ctx)
+	if err != nil {
+		logger.Errorf("Failed to check membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create invitation")
+		return
+	}
+	if isMember {
+		response.Err(c, errcode.OrgMemberExists)
+		return
+	}
+
+	pending, err := h.db.Ent.Invitation.Query().
+		Where(
+			entinv.EmailEqualFold(input.Email),
+			entinv.AcceptedAtIsNil(),
+			entinv.RevokedAtIsNil(),
+			entinv.ExpiresAtGT(time.Now()),
+		).
+		Exist(ctx)
+	if err != nil {
+		logger.Errorf("Failed to check pending invitations: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create invitation")
+		return
+	}
+	if pending {
+		response.Err(c, errcode.InvitationPending)
+		return
+	}
+
+	inv, err := h.db.Ent.Invitation.Create().
+		SetEmail(input.Email).
+		SetRoleID(roleID).
+		SetNillableTeamID(teamID).
+		SetInvitedBy(c.GetInt("userID")).
+		SetExpiresAt(h.sender.ExpiresAt()).
+		Save(ctx)
+	if err != nil {
+		logger.Errorf("Failed to create invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create invitation")
+		return
+	}
+
+	if !h.send(c, inv) {
+		return
+	}
+
+	response.Ok(c, newInvitationInfo(inv))
+}
+
+// Resend godoc
+// @Summary      Resend an invitation
+// @Description  Email a new link for an invitation, extending its expiry and invalidating earlier links
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        id        path    string true  "Invitation ID"
+// @Success      200  {object}   response.Response{data=InvitationInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | invitation.not_found | invitation.invalid"
+// @Router       /orgs/current/invitations/{id}/resend [post]
+// @Security     BearerAuth
+func (h *InvitationHandler) Resend(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Invitation)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	inv, err := h.db.Ent.Invitation.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.InvitationNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to resend invitation")
+		return
+	}
+
+	// Expired invitations can be resent, answered ones cannot
+	if inv.AcceptedAt != nil || inv.RevokedAt != nil {
+		response.Err(c, errcode.InvitationInvalid)
+		return
+	}
+
+	inv, err = inv.Update().
+		AddVersion(1).
+		SetExpiresAt(h.sender.ExpiresAt()).
+		SetLastSentAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Errorf("Failed to update invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to resend invitation")
+		return
+	}
+
+	if !h.send(c, inv) {
+		return
+	}
+
+	response.Ok(c, newInvitationInfo(inv))
+}
+
+// Revoke godoc
+// @Summary      Revoke an invitation
+// @Description  Revoke a pending invitation so its link can no longer be accepted
+// @Tags         invitations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        id        path    string true  "Invitation ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | invitation.not_found | invitation.invalid"
+// @Router       /orgs/current/invitations/{id} [delete]
+// @Security     BearerAuth
+func (h *InvitationHandler) Revoke(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Invitation)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	n, err := h.db.Ent.Invitation.Update().
+		Where(entinv.ID(id), entinv.AcceptedAtIsNil(), entinv.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		logger.Errorf("Failed to revoke invitation: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to revoke invitation")
+		return
+	}
+
+	if n == 0 {
+		exists, err := h.db.Ent.Invitation.Query().Where(entinv.ID(id)).Exist(ctx)
+		if err != nil {
+			logger.Errorf("Failed to check invitation existence: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to revoke invitation")
+			return
+		}
+		if !exists {
+			response.Err(c, errcode.InvitationNotFound)
+			return
+		}
+		response.Err(c, errcode.InvitationInvalid, "Invitation was already accepted or revoked")
+		return
+	}
+
+	response.OkWithMessage(c, "Invitation revoked successfully", nil)
+}
+
+// send emails the invitation, writing an error response on failure
+func (h *InvitationHandler) send(c *gin.Context, inv *ent.Invitation) bool {
+	ctx := c.Request.Context()
+
+	org, err := h.db.Ent.Organization.Get(ctx, inv.OrganizationID)
+	if err != nil {
+		logger.Errorf("Failed to fetch organization: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to send invitation")
+		return false
+	}
+
+	if err := h.sender.Send(ctx, inv, org.Name); err != nil {
+		logger.Errorf("Failed to send invitation %d: %v", inv.ID, err)
+		response.Err(c, errcode.ServerError, "Failed to send invitation")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"go-template/ent"
+	"go-template/ent/membership"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/response"
+	"go-template/internal/database"
+	"go-template/internal/organization"
+	"go-template/internal/tenant"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// OrganizationHandler handles organization and membership HTTP requests
+type OrganizationHandler struct {
+	db *database.Client
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(db *database.Client) *OrganizationHandler {
+	return &OrganizationHandler{db: db}
+}
+
+// OrganizationInfo represents an organization the current user belongs to
+type OrganizationInfo struct {
+	ID        string    `json:"id" example:"org_8d2fk5w0nq1ye"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MemberInfo represents a member of an organization
+type MemberInfo struct {
+	UserID   string    `json:"user_id" example:"usr_q7vm9zp2hc54e"`
+	Name     string    `json:"name"`
+	Email    string    `json:"email"`
+	RoleID   string    `json:"role_id" example:"rol_3kq8x0v1mz7ta"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// List godoc
+// @Summary      List my organizations
+// @Description  Get the organizations the current user is a member of
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   response.Response{data=[]OrganizationInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error"
+// @Router       /orgs [get]
+// @Security     BearerAuth
+func (h *OrganizationHandler) List(c *gin.Context) {
+	// Memberships span tenants, so they are listed outside the tenant scope
+	memberships, err := h.db.Ent.Membership.Query().
+		Where(membership.UserID(c.GetInt("userID"))).
+		WithOrganization().
+		WithRole().
+		All(tenant.WithSystem(c.Request.Context()))
+	if err != nil {
+		logger.Errorf("Failed to fetch memberships: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch organizations")
+		return
+	}
+
+	orgs := make([]OrganizationInfo, 0, len(memberships))
+	for _, m := range memberships {
+		orgs = append(orgs, OrganizationInfo{
+			ID:        publicid.Encode(publicid.Organization, m.Edges.Organization.ID),
+			Name:      m.Edges.Organization.Name,
+			Slug:      m.Edges.Organization.Slug,
+			Role:      m.Edges.Role.Name,
+			CreatedAt: m.Edges.Organization.CreatedAt,
+		})
+	}
+
+	response.Ok(c, orgs)
+}
+
+// OrganizationCreateInput represents the input for creating an organization
+type OrganizationCreateInput struct {
+	Name string `json:"name" binding:"required" example:"Acme Inc."`
+	Slug string `json:"slug" binding:"required,alphanum" example:"acme"`
+}
+
+// Create godoc
+// @Summary      Create an organization
+// @Description  Create an organization with the current user as its admin
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        org  body      OrganizationCreateInput  true  "Organization Info"
+// @Success      200  {object}   response.Response{data=OrganizationInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | org.slug.taken"
+// @Router       /orgs [post]
+// @Security     BearerAuth
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	var input OrganizationCreateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	// The new organization is not the request's tenant yet
+	ctx := tenant.WithSystem(c.Request.Context())
+
+	tx, err := h.db.Ent.Tx(ctx)
+	if err != nil {
+		logger.Errorf("Failed to start transaction: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create organization")
+		return
+	}
+
+	org, err := tx.Organization.Create().
+		SetName(input.Name).
+		SetSlug(input.Slug).
+		Save(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.OrgSlugTaken)
+			return
+		}
+		logger.Errorf("Failed to create organization: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create organization")
+		return
+	}
+
+	adminRole, err := sharedRole(ctx, tx.Client(), organization.AdminRole, "Organization administrator")
+	if err != nil {
+		_ = tx.Rollback()
+		logger.Errorf("Failed to fetch admin role: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create organization")
+		return
+	}
+
+	err = tx.Membership.Create().
+		SetOrganizationID(org.ID).
+		SetUserID(c.GetInt("userID")).
+		SetRoleID(adminRole.ID).
+		Exec(ctx)
+	if err != nil {
+		_ = tx.Rollback()
+		logger.Errorf("Failed to create membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create organization")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		logger.Errorf("Failed to commit organization: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create organization")
+		return
+	}
+
+	response.Ok(c, OrganizationInfo{
+		ID:        publicid.Encode(publicid.Organization, org.ID),
+		Name:      org.Name,
+		Slug:      org.Slug,
+		Role:      adminRole.Name,
+		CreatedAt: org.CreatedAt,
+	})
+}
+
+// ListMembers godoc
+// @Summary      List organization members
+// @Description  Get the members of the organization selected with X-Org-ID
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Success      200  {object}   response.Response{data=[]MemberInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | org.access.denied"
+// @Router       /orgs/current/members [get]
+// @Security     BearerAuth
+func (h *OrganizationHandler) ListMembers(c *gin.Context) {
+	memberships, err := h.db.Ent.Membership.Query().
+		WithUser().
+		WithRole().
+		All(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to fetch members: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch members")
+		return
+	}
+
+	members := make([]MemberInfo, 0, len(memberships))
+	for _, m := range memberships {
+		// Deleted and erased users keep their memberships until purged
+		if m.Edges.User == nil {
+			continue
+		}
+		info := MemberInfo{
+			UserID:   publicid.Encode(publicid.User, m.UserID),
+			Name:     m.Edges.User.Name,
+			Email:    m.Edges.User.Email,
+			RoleID:   publicid.Encode(publicid.Role, m.RoleID),
+			JoinedAt: m.CreatedAt,
+		}
+		if m.Edges.Role != nil {
+			info.Role = m.Edges.Role.Name
+		}
+		members = append(members, info)
+	}
+
+	response.Ok(c, members)
+}
+
+// RemoveMember godoc
+// @Summary      Remove an organization member
+// @Description  Remove a user from the organization selected with X-Org-ID
+// @Tags         organizations
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        user_id   path    string true  "User ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | org.member.not_found"
+// @Router       /orgs/current/members/{user_id} [delete]
+// @Security     BearerAuth
+func (h *OrganizationHandler) RemoveMember(c *gin.Context) {
+	userID, ok := binder.PathID(c, "user_id", publicid.User)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	n, err := h.db.Ent.Membership.Delete().
+		Where(membership.UserID(userID)).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf("Failed to delete membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to remove member")
+		return
+	}
+	if n == 0 {
+		response.Err(c, errcode.OrgMemberNotFound)
+		return
+	}
+
+	// Former members keep no roles through the organization's teams
+	err = h.db.Ent.Team.Update().
+		Where(team.HasMembersWith(user.ID(userID))).
+		RemoveMemberIDs(userID).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf("Failed to remove team memberships: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to remove member")
+		return
+	}
+
+	response.OkWithMessage(c, "Member removed successfully", nil)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"go-template/internal/api/patch"
+	"go-template/internal/api/response"
+	"go-template/pkg/errcode"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// bindPatch parses the request body as a merge patch or JSON Patch of the
+// document. It writes an error response and returns false on failure.
+func bindPatch(c *gin.Context, fields patch.Fields, doc patch.Document) (*patch.Patch, bool) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		response.Err(c, errcode.InvalidParams, "Failed to read request body")
+		return nil, false
+	}
+
+	p, err := patch.Parse(c.GetHeader("Content-Type"), body, fields, doc)
+	if err != nil {
+		switch {
+		case errors.Is(err, patch.ErrMediaType):
+			response.ErrWithStatus(c, http.StatusUnsupportedMediaType, errcode.InvalidParams,
+				"Content-Type must be "+patch.MergePatchType+" or "+patch.JSONPatchType)
+		case errors.Is(err, patch.ErrTestFailed):
+			response.ErrWithStatus(c, http.StatusPreconditionFailed, errcode.ResourceModified, err.Error())
+		default:
+			response.Err(c, errcode.InvalidParams, err.Error())
+		}
+		return nil, false
+	}
+	return p, true
+}
+
+// decodePatch decodes the changed values of a patch into v and validates
+// them with its binding tags. It writes an error response and returns false
+// on failure.
+func decodePatch(c *gin.Context, p *patch.Patch, v interface{}) bool {
+	if err := p.Decode(v); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return false
+	}
+	if err := binding.Validator.ValidateStruct(v); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return false
+	}
+	return true
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"errors"
+	"go-template/ent"
+	"go-template/ent/dataexport"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/response"
+	"go-template/internal/audit"
+	"go-template/internal/database"
+	"go-template/internal/privacy"
+	"go-template/internal/session"
+	"go-template/pkg/auth"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrivacyHandler handles personal data exports and erasure
+type PrivacyHandler struct {
+	db       *database.Client
+	privacy  *privacy.Service
+	sessions *session.Store
+	auditor  *audit.Recorder
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(db *database.Client, privacy *privacy.Service, sessions *session.Store, auditor *audit.Recorder) *PrivacyHandler {
+	return &PrivacyHandler{db: db, privacy: privacy, sessions: sessions, auditor: auditor}
+}
+
+// ExportInfo represents a data export in API responses
+type ExportInfo struct {
+	ID          string    `json:"id" example:"exp_8d2kq0vhx3m7a"`
+	Status      string    `json:"status" enums:"pending,ready,failed"`
+	Error       string    `json:"error,omitempty"`
+	DownloadURL string    `json:"download_url,omitempty"` // Signed link, set once the export is ready
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// newExportInfo converts an export, signing a download link when it is ready
+func (h *PrivacyHandler) newExportInfo(export *ent.DataExport) (ExportInfo, error) {
+	info := ExportInfo{
+		ID:        publicid.Encode(publicid.DataExport, export.ID),
+		Status:    string(export.Status),
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt,
+		ExpiresAt: export.ExpiresAt,
+	}
+	if export.Status == dataexport.StatusReady {
+		link, err := h.privacy.DownloadURL(export)
+		if err != nil {
+			return info, err
+		}
+		info.DownloadURL = link
+	}
+	return info, nil
+}
+
+// RequestExport godoc
+// @Summary      Export own data
+// @Description  Starts building a zip archive of JSON files with the data linked to the current user: profile, role, sessions, memberships and audit entries.
+// @Description  The archive is built in the background; poll the export until it is ready to get a signed download link.
+// @Description  While an export is being built, requesting another returns it.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   response.Response{data=ExportInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ user.unauthorized | auth.impersonation.forbidden"
+// @Router       /auth/me/export [post]
+// @Security     BearerAuth
+func (h *PrivacyHandler) RequestExport(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	ctx := c.Request.Context()
+	export, err := h.privacy.RequestExport(ctx, userID)
+	if err != nil {
+		logger.Errorf("Failed to request data export: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to request data export")
+		return
+	}
+
+	if err := h.auditor.Record(ctx, newAuditEntry(c, audit.ActionDataExport, "user", strconv.Itoa(userID))); err != nil {
+		logger.Errorf("Failed to record data export: %v", err)
+	}
+
+	h.respondExport(c, export)
+}
+
+// GetExport godoc
+// @Summary      Get own data export
+// @Description  Returns the status of a data export of the current user, with a signed download link once it is ready
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string true  "Export ID"
+// @Success      200  {object}   response.Response{data=ExportInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | export.not_found"
+// @Router       /auth/me/exports/{id} [get]
+// @Security     BearerAuth
+func (h *PrivacyHandler) GetExport(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+	id, ok := binder.PathID(c, "id", publicid.DataExport)
+	if !ok {
+		return
+	}
+
+	export, err := h.privacy.GetExport(c.Request.Context(), userID, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.ExportNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch data export: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch data export")
+		return
+	}
+
+	h.respondExport(c, export)
+}
+
+// respondExport writes an export as the response
+func (h *PrivacyHandler) respondExport(c *gin.Context, export *ent.DataExport) {
+	info, err := h.newExportInfo(export)
+	if err != nil {
+		logger.Errorf("Failed to sign export download link: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch data export")
+		return
+	}
+	response.Ok(c, info)
+}
+
+// DownloadExport godoc
+// @Summary      Download a data export
+// @Description  Serves the archive of a data export. The signed token comes from the export's download link and expires with the archive.
+// @Tags         auth
+// @Produce      application/zip
+// @Param        token  query  string  true  "Signed download token"
+// @Success      200  {file}     file "zip archive"
+// @Failure      500  {object}   response.Response "server.error ｜ export.invalid"
+// @Router       /auth/exports/download [get]
+func (h *PrivacyHandler) DownloadExport(c *gin.Context) {
+	path, export, err := h.privacy.OpenDownload(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		if ent.IsNotFound(err) || errors.Is(err, privacy.ErrNotReady) ||
+			errors.Is(err, auth.ErrInvalidToken) || errors.Is(err, auth.ErrExpiredToken) {
+			response.Err(c, errcode.ExportInvalid)
+			return
+		}
+		logger.Errorf("Failed to open data export: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to download data export")
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.FileAttachment(path, "data-export-"+export.CreatedAt.Format("2006-01-02")+".zip")
+}
+
+// AccountEraseInput represents the input for erasing the current user's data
+type AccountEraseInput struct {
+	Password string `json:"password" binding:"required" example:"password123"`
+}
+
+// EraseAccount godoc
+// @Summary      Erase own personal data
+// @Description  Anonymizes the current user's name, email and password and closes the account after re-entering the password.
+// @Description  The account record is kept so references to it stay valid, and audit records are kept. Sessions, invitations to the user's email and data exports are deleted.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      AccountEraseInput  true  "Password confirmation"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.unauthorized | user.not_found | user.password.wrong | auth.impersonation.forbidden"
+// @Router       /auth/me/erase [post]
+// @Security     BearerAuth
+func (h *PrivacyHandler) EraseAccount(c *gin.Context) {
+	userID := c.GetInt("userID")
+	if userID == 0 {
+		response.Err(c, errcode.UserUnauthorized)
+		return
+	}
+
+	var input AccountEraseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	ctx := c.Request.Context()
+	if !verifyCurrentPassword(c, h.db, userID, input.Password) {
+		return
+	}
+
+	// Sign out everywhere before the sessions go away
+	if _, err := h.sessions.RevokeAll(ctx, userID, 0); err != nil {
+		logger.Errorf("Failed to revoke sessions: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to erase account")
+		return
+	}
+
+	if err := h.privacy.Erase(ctx, userID); err != nil {
+		logger.Errorf("Failed to erase user: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to erase account")
+		return
+	}
+
+	if err := h.auditor.Record(ctx, newAuditEntry(c, audit.ActionAccountErasure, "user", strconv.Itoa(userID))); err != nil {
+		logger.Errorf("Failed to record account erasure: %v", err)
+	}
+
+	response.OkWithMessage(c, "Account erased successfully", nil)
+}
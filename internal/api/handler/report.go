@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"errors"
+	"go-template/internal/api/response"
+	"go-template/internal/audit"
+	"go-template/internal/report"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReportHandler handles the reports of administrators
+type ReportHandler struct {
+	reports *report.Service
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(reports *report.Service) *ReportHandler {
+	return &ReportHandler{reports: reports}
+}
+
+// GetUserStats godoc
+// @Summary      Get user statistics
+// @Description  Count the users by status and role (admin only)
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   response.Response{data=report.UserStats} "ok"
+// @Failure      500  {object}   response.Response "server.error | auth.access.denied"
+// @Router       /reports/user-stats [get]
+// @Security     BearerAuth
+func (h *ReportHandler) GetUserStats(c *gin.Context) {
+	stats, err := h.reports.UserStats(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to compute user statistics: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch user statistics")
+		return
+	}
+
+	response.Ok(c, stats)
+}
+
+// RoleUserCountDTO represents a role with user count
+type RoleUserCountDTO struct {
+	RoleID      string `json:"role_id" example:"rol_3kq8x0v1mz7ta"`
+	RoleName    string `json:"role_name"`
+	Description string `json:"description"`
+	UserCount   int    `json:"user_count"`
+}
+
+// GetRoleUserCounts godoc
+// @Summary      Get role user counts
+// @Description  Count the users of each shared role, most used first (admin only)
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}   response.Response{data=[]RoleUserCountDTO} "ok"
+// @Failure      500  {object}   response.Response "server.error | auth.access.denied"
+// @Router       /reports/role-user-counts [get]
+// @Security     BearerAuth
+func (h *ReportHandler) GetRoleUserCounts(c *gin.Context) {
+	counts, err := h.reports.RoleUserCounts(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to count role users: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch role user counts")
+		return
+	}
+
+	results := make([]RoleUserCountDTO, len(counts))
+	for i, count := range counts {
+		results[i] = RoleUserCountDTO{
+			RoleID:      publicid.Encode(publicid.Role, count.RoleID),
+			RoleName:    count.RoleName,
+			Description: count.Description,
+			UserCount:   count.UserCount,
+		}
+	}
+
+	response.Ok(c, results)
+}
+
+// ActivateRoleUsersInput names the role whose users are activated
+type ActivateRoleUsersInput struct {
+	Role string `json:"role" binding:"required,max=100" example:"user"`
+}
+
+// ActivateRoleUsersDTO reports the outcome of activating the users of a role
+type ActivateRoleUsersDTO struct {
+	Activated   int64 `json:"activated"`
+	ActiveUsers int   `json:"active_users"`
+}
+
+// ActivateRoleUsers godoc
+// @Summary      Activate the users of a role
+// @Description  Activate the disabled users of a shared role (admin only). The action is audited.
+// @Tags         reports
+// @Accept       json
+// @Produce      json
+// @Param        input body ActivateRoleUsersInput true "Role"
+// @Success      200  {object}   response.Response{data=ActivateRoleUsersDTO} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden | role.not_found"
+// @Router       /reports/role-users/activate [post]
+// @Security     BearerAuth
+func (h *ReportHandler) ActivateRoleUsers(c *gin.Context) {
+	var input ActivateRoleUsersInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	// The role ID is only known once the role is found
+	entry := newAuditEntry(c, audit.ActionRoleUsersActivate, "role", "")
+	activated, active, err := h.reports.ActivateRoleUsers(c.Request.Context(), input.Role, entry)
+	if err != nil {
+		if errors.Is(err, report.ErrRoleNotFound) {
+			response.Err(c, errcode.RoleNotFound)
+			return
+		}
+		logger.Errorf("Failed to activate role users: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update users")
+		return
+	}
+
+	response.Ok(c, ActivateRoleUsersDTO{Activated: activated, ActiveUsers: active})
+}
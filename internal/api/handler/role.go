@@ -1,14 +1,21 @@
 package handler
 
 import (
+	"context"
 	"go-template/ent"
+	"go-template/ent/membership"
 	"go-template/ent/role"
 	"go-template/ent/user"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/patch"
 	"go-template/internal/api/response"
 	"go-template/internal/database"
+	"go-template/internal/softdelete"
+	"go-template/internal/tenant"
 	"go-template/pkg/errcode"
 	"go-template/pkg/logger"
-	"strconv"
+	"go-template/pkg/publicid"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,6 +30,41 @@ func NewRoleHandler(db *database.Client) *RoleHandler {
 	return &RoleHandler{db: db}
 }
 
+// RoleInfo represents a role, with its users when requested
+type RoleInfo struct {
+	ID             string       `json:"id" example:"rol_3kq8x0v1mz7ta"`
+	OrganizationID *string      `json:"organization_id,omitempty"` // Empty for roles shared by all organizations
+	Name           string       `json:"name"`
+	Description    string       `json:"description"`
+	Version        int          `json:"version"` // Also sent as the ETag header of single-role responses
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
+	CreatedBy      *string      `json:"created_by,omitempty"`
+	UpdatedBy      *string      `json:"updated_by,omitempty"`
+	DeletedAt      *time.Time   `json:"deleted_at,omitempty"`
+	Users          []UserDetail `json:"users,omitempty"`
+}
+
+// newRoleInfo converts a role, with its users if loaded, to its API representation
+func newRoleInfo(r *ent.Role) RoleInfo {
+	info := RoleInfo{
+		ID:             publicid.Encode(publicid.Role, r.ID),
+		OrganizationID: publicid.EncodeOptional(publicid.Organization, r.OrganizationID),
+		Name:           r.Name,
+		Description:    r.Description,
+		Version:        r.Version,
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+		CreatedBy:      publicid.EncodeOptional(publicid.User, r.CreatedBy),
+		UpdatedBy:      publicid.EncodeOptional(publicid.User, r.UpdatedBy),
+		DeletedAt:      r.DeletedAt,
+	}
+	if r.Edges.Users != nil {
+		info.Users = newUserDetails(r.Edges.Users)
+	}
+	return info
+}
+
 // List godoc
 // @Summary      List Roles
 // @Description  Get a list of roles
@@ -30,11 +72,17 @@ func NewRoleHandler(db *database.Client) *RoleHandler {
 // @Accept       json
 // @Produce      json
 // @Param        with_users query bool false "Include users information"
-// @Success      200  {object}   response.Response{data=[]ent.Role} "ok"
+// @Param        include_deleted query bool false "Include soft-deleted roles"
+// @Success      200  {object}   response.Response{data=[]RoleInfo} "ok"
 // @Failure      500  {object}   response.Response "server.error"
 // @Router       /roles [get]
 // @Security     BearerAuth
 func (h *RoleHandler) List(c *gin.Context) {
+	ctx, ok := deletedScope(c)
+	if !ok {
+		return
+	}
+
 	// Check if we should include users information
 	withUsers := c.Query("with_users") == "true"
 
@@ -47,32 +95,44 @@ func (h *RoleHandler) List(c *gin.Context) {
 	}
 
 	// Execute the query
-	roles, err := query.All(c.Request.Context())
+	roles, err := query.All(ctx)
 	if err != nil {
 		logger.Errorf("Failed to fetch roles: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to fetch roles")
 		return
 	}
 
-	response.Ok(c, roles)
+	infos := make([]RoleInfo, 0, len(roles))
+	for _, r := range roles {
+		infos = append(infos, newRoleInfo(r))
+	}
+	response.Ok(c, infos)
 }
 
 // Get godoc
 // @Summary      Get a role
-// @Description  Get a role by ID
+// @Description  Get a role by ID. Without with_users, the ETag header carries the role's version;
+// @Description  send it in If-None-Match to get 304 while the role is unchanged.
 // @Tags         roles
 // @Accept       json
 // @Produce      json
-// @Param        id path int true "Role ID"
+// @Param        id path string true "Role ID"
 // @Param        with_users query bool false "Include users information"
-// @Success      200  {object}   response.Response{data=ent.Role} "ok"
+// @Param        include_deleted query bool false "Include soft-deleted roles"
+// @Param        If-None-Match header string false "ETag of a previous response"
+// @Success      200  {object}   response.Response{data=RoleInfo} "ok"
+// @Success      304  "not modified"
 // @Failure      500  {object}   response.Response "server.error | invalid.params | role.not_found"
 // @Router       /roles/{id} [get]
 // @Security     BearerAuth
 func (h *RoleHandler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid role ID")
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
+		return
+	}
+
+	ctx, ok := deletedScope(c)
+	if !ok {
 		return
 	}
 
@@ -88,7 +148,7 @@ func (h *RoleHandler) Get(c *gin.Context) {
 	}
 
 	// Execute the query
-	r, err := query.Only(c.Request.Context())
+	r, err := query.Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			response.Err(c, errcode.RoleNotFound)
@@ -99,7 +159,11 @@ func (h *RoleHandler) Get(c *gin.Context) {
 		return
 	}
 
-	response.Ok(c, r)
+	// The version does not track the users, so responses with them carry no ETag
+	if !withUsers && notModified(c, r.Version) {
+		return
+	}
+	response.Ok(c, newRoleInfo(r))
 }
 
 type RoleCreateInput struct {
@@ -114,7 +178,7 @@ type RoleCreateInput struct {
 // @Accept       json
 // @Produce      json
 // @Param        role  body      RoleCreateInput  true  "Role Info"
-// @Success      200  {object}   response.Response{data=ent.Role} "ok"
+// @Success      200  {object}   response.Response{data=RoleInfo} "ok"
 // @Failure      500  {object}   response.Response "server.error ｜ invalid.params"
 // @Router       /roles [post]
 // @Security     BearerAuth
@@ -133,12 +197,16 @@ func (h *RoleHandler) Create(c *gin.Context) {
 		Save(c.Request.Context())
 
 	if err != nil {
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.InvalidParams, "Role name is already taken")
+			return
+		}
 		logger.Errorf("Failed to create role: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to create role")
 		return
 	}
 
-	response.Ok(c, r)
+	response.Ok(c, newRoleInfo(r))
 }
 
 type RoleUpdateInput struct {
@@ -148,20 +216,21 @@ type RoleUpdateInput struct {
 
 // Update godoc
 // @Summary      Update a role
-// @Description  update an existing role
+// @Description  update an existing role. With If-Match, the update is rejected with 412 if the role changed since it was read.
 // @Tags         roles
 // @Accept       json
 // @Produce      json
-// @Param        id    path      int              true  "Role ID"
-// @Param        role  body      RoleUpdateInput  true  "Role Info"
-// @Success      200  {object}   response.Response{data=ent.Role} "ok"
+// @Param        id        path      string           true   "Role ID"
+// @Param        If-Match  header    string           false  "ETag of the version being updated"
+// @Param        role      body      RoleUpdateInput  true   "Role Info"
+// @Success      200  {object}   response.Response{data=RoleInfo} "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
 // @Failure      500  {object}   response.Response "server.error ｜ invalid.params | role.not_found"
 // @Router       /roles/{id} [put]
 // @Security     BearerAuth
 func (h *RoleHandler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid role ID")
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
 		return
 	}
 
@@ -175,6 +244,12 @@ func (h *RoleHandler) Update(c *gin.Context) {
 	// Start building the update query
 	update := h.db.Ent.Role.UpdateOneID(id)
 
+	// Only update the version the client has seen
+	versions := ifMatchVersions(c)
+	if versions != nil {
+		update = update.Where(role.VersionIn(versions...))
+	}
+
 	// Only set fields that were provided
 	if input.Name != "" {
 		update = update.SetName(input.Name)
@@ -187,7 +262,7 @@ func (h *RoleHandler) Update(c *gin.Context) {
 	r, err := update.Save(c.Request.Context())
 	if err != nil {
 		if ent.IsNotFound(err) {
-			response.Err(c, errcode.RoleNotFound)
+			notFoundOrModified(c, versions != nil, h.exists(c, id), errcode.RoleNotFound)
 			return
 		}
 		logger.Errorf("Failed to update role: %v", err)
@@ -195,24 +270,122 @@ func (h *RoleHandler) Update(c *gin.Context) {
 		return
 	}
 
-	response.Ok(c, r)
+	setETag(c, r.Version)
+	response.Ok(c, newRoleInfo(r))
+}
+
+// rolePatchFields are the fields a role patch may change
+var rolePatchFields = patch.Fields{
+	"name":        {},
+	"description": {},
+}
+
+// RolePatchInput lists the fields of a role patch
+type RolePatchInput struct {
+	Name        *string `json:"name" binding:"omitempty,min=1"`
+	Description *string `json:"description" binding:"omitempty,min=1"`
+}
+
+// Patch godoc
+// @Summary      Patch a role
+// @Description  partially update a role with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).
+// @Description  All changes are applied at once or not at all. With If-Match, the patch is rejected with 412 if the role changed since it was read.
+// @Tags         roles
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id        path      string          true   "Role ID"
+// @Param        If-Match  header    string          false  "ETag of the version being patched"
+// @Param        patch     body      RolePatchInput  true   "Merge patch, or an array of JSON Patch operations on these fields"
+// @Success      200  {object}   response.Response{data=RoleInfo} "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
+// @Failure      415  {object}   response.Response "invalid.params"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | role.not_found"
+// @Router       /roles/{id} [patch]
+// @Security     BearerAuth
+func (h *RoleHandler) Patch(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	r, err := h.db.Ent.Role.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.RoleNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch role: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch role")
+		return
+	}
+	if !versionMatches(c, r.Version) {
+		return
+	}
+
+	p, ok := bindPatch(c, rolePatchFields, patch.Document{
+		"name":        r.Name,
+		"description": r.Description,
+	})
+	if !ok {
+		return
+	}
+	var input RolePatchInput
+	if !decodePatch(c, p, &input) {
+		return
+	}
+	if p.Empty() {
+		setETag(c, r.Version)
+		response.Ok(c, newRoleInfo(r))
+		return
+	}
+
+	// The patch was computed from this version, so it must still be current
+	update := h.db.Ent.Role.UpdateOneID(id).Where(role.Version(r.Version))
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+
+	r, err = update.Save(ctx)
+	if err != nil {
+		switch {
+		case ent.IsNotFound(err):
+			notFoundOrModified(c, true, h.exists(c, id), errcode.RoleNotFound)
+		case ent.IsValidationError(err):
+			response.Err(c, errcode.InvalidParams, err.Error())
+		default:
+			logger.Errorf("Failed to patch role: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to update role")
+		}
+		return
+	}
+
+	setETag(c, r.Version)
+	response.Ok(c, newRoleInfo(r))
 }
 
 // Delete godoc
 // @Summary      Delete a role
-// @Description  delete a role by ID
+// @Description  soft-delete a role by ID. The role can be restored until it is purged.
+// @Description  With If-Match, the deletion is rejected with 412 if the role changed since it was read.
 // @Tags         roles
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "Role ID"
+// @Param        id        path    string  true   "Role ID"
+// @Param        If-Match  header  string  false  "ETag of the version being deleted"
 // @Success      200  {object}   response.Response "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
 // @Failure      500  {object}   response.Response "server.error ｜ invalid.params | role.not_found | role.in_use"
 // @Router       /roles/{id} [delete]
 // @Security     BearerAuth
 func (h *RoleHandler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid role ID")
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
 		return
 	}
 
@@ -227,6 +400,18 @@ func (h *RoleHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	// Count organization memberships across all tenants as well
+	memberCount, err := h.db.Ent.Membership.Query().
+		Where(membership.RoleID(id)).
+		Count(tenant.WithSystem(c.Request.Context()))
+
+	if err != nil {
+		logger.Errorf("Failed to check role usage: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to check role usage")
+		return
+	}
+	count += memberCount
+
 	// Prevent deletion if role is in use
 	if count > 0 {
 		response.ErrWithData(c, errcode.RoleInUse, map[string]interface{}{
@@ -236,10 +421,16 @@ func (h *RoleHandler) Delete(c *gin.Context) {
 	}
 
 	// Delete the role
-	err = h.db.Ent.Role.DeleteOneID(id).Exec(c.Request.Context())
+	del := h.db.Ent.Role.DeleteOneID(id)
+	versions := ifMatchVersions(c)
+	if versions != nil {
+		del = del.Where(role.VersionIn(versions...))
+	}
+
+	err = del.Exec(c.Request.Context())
 	if err != nil {
 		if ent.IsNotFound(err) {
-			response.Err(c, errcode.RoleNotFound)
+			notFoundOrModified(c, versions != nil, h.exists(c, id), errcode.RoleNotFound)
 			return
 		}
 		logger.Errorf("Failed to delete role: %v", err)
@@ -256,15 +447,14 @@ func (h *RoleHandler) Delete(c *gin.Context) {
 // @Tags         roles
 // @Accept       json
 // @Produce      json
-// @Param        id path int true "Role ID"
-// @Success      200  {object}   response.Response{data=[]ent.User} "ok"
+// @Param        id path string true "Role ID"
+// @Success      200  {object}   response.Response{data=[]UserDetail} "ok"
 // @Failure      500  {object}   response.Response "server.error ｜ invalid.params | role.not_found"
 // @Router       /roles/{id}/users [get]
 // @Security     BearerAuth
 func (h *RoleHandler) GetUsers(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid role ID")
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
 		return
 	}
 
@@ -284,5 +474,69 @@ func (h *RoleHandler) GetUsers(c *gin.Context) {
 		return
 	}
 
-	response.Ok(c, users)
+	response.Ok(c, newUserDetails(users))
+}
+
+// Restore godoc
+// @Summary      Restore a role
+// @Description  restore a soft-deleted role by ID
+// @Tags         roles
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string true  "Role ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | role.not_found"
+// @Router       /roles/{id}/restore [post]
+// @Security     BearerAuth
+func (h *RoleHandler) Restore(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Role)
+	if !ok {
+		return
+	}
+
+	err := h.db.Ent.Role.UpdateOneID(id).
+		Where(role.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Exec(softdelete.IncludeDeleted(c.Request.Context()))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.RoleNotFound, "Deleted role not found")
+			return
+		}
+		logger.Errorf("Failed to restore role: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to restore role")
+		return
+	}
+
+	response.OkWithMessage(c, "Role restored successfully", nil)
+}
+
+// exists returns a check of whether the role exists and is not deleted
+func (h *RoleHandler) exists(c *gin.Context, id int) func() (bool, error) {
+	return func() (bool, error) {
+		return h.db.Ent.Role.Query().Where(role.ID(id)).Exist(c.Request.Context())
+	}
+}
+
+// sharedRole returns the role shared by all organizations with the given
+// name, creating it if it does not exist yet. Shared role names are unique,
+// so a concurrent call creating the same role makes the creation fail and
+// the role is looked up again; within a PostgreSQL transaction, the failure
+// aborts the transaction instead.
+func sharedRole(ctx context.Context, client *ent.Client, name, description string) (*ent.Role, error) {
+	query := client.Role.Query().
+		Where(role.NameEQ(name), role.OrganizationIDIsNil())
+	r, err := query.Clone().Only(ctx)
+	if err == nil || !ent.IsNotFound(err) {
+		return r, err
+	}
+
+	r, err = client.Role.Create().
+		SetName(name).
+		SetDescription(description).
+		Save(ctx)
+	if ent.IsConstraintError(err) {
+		return query.Only(ctx)
+	}
+	return r, err
 }
@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"go-template/ent"
+	"go-template/internal/api/response"
+	"go-template/internal/database"
+	"go-template/internal/sqlconsole"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SQLConsoleHandler handles the ad-hoc SQL of administrators
+type SQLConsoleHandler struct {
+	console *sqlconsole.Service
+}
+
+// NewSQLConsoleHandler creates a new SQL console handler
+func NewSQLConsoleHandler(console *sqlconsole.Service) *SQLConsoleHandler {
+	return &SQLConsoleHandler{console: console}
+}
+
+// SQLQueryInput represents a console query
+type SQLQueryInput struct {
+	Query   string `json:"query" binding:"required,max=10000" example:"SELECT name, COUNT(*) FROM roles GROUP BY name"`
+	Format  string `json:"format" binding:"omitempty,oneof=json csv ndjson" example:"json"`
+	MaxRows int    `json:"max_rows" binding:"omitempty,min=1" example:"100"`
+}
+
+// SQLQueryResult is the result of a console query in JSON. Rows hold the
+// values in the order of the columns.
+type SQLQueryResult struct {
+	ID         string            `json:"id" example:"sql_4nd8w2k0qe6ry"`
+	Columns    []database.Column `json:"columns"`
+	Rows       [][]any           `json:"rows" swaggertype:"array,object"`
+	RowCount   int               `json:"row_count"`
+	Truncated  bool              `json:"truncated"`
+	DurationMs int64             `json:"duration_ms"`
+}
+
+// Run godoc
+// @Summary      Run a SQL query
+// @Description  Run a single SELECT statement on the allowed tables in a read-only transaction, bounded in time and rows (admin only). The query is saved in the admin's history, refused queries included.
+// @Description  In JSON the result holds the columns and the rows. CSV starts with a header of the column names; NDJSON starts with a line holding the columns, followed by an object per row. Both are streamed, with the X-Row-Count and X-Truncated trailers.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        input body SQLQueryInput true "Query"
+// @Success      200  {object}   response.Response{data=SQLQueryResult} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden | sql.rejected | sql.failed"
+// @Router       /admin/sql [post]
+// @Security     BearerAuth
+func (h *SQLConsoleHandler) Run(c *gin.Context) {
+	var input SQLQueryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+	if input.Format == "" {
+		input.Format = "json"
+	}
+
+	var out sqlOutput
+	switch input.Format {
+	case "json":
+		out = &jsonSQLOutput{}
+	case "csv":
+		out = &csvSQLOutput{streamSQLOutput: streamSQLOutput{c: c, contentType: "text/csv; charset=utf-8"}}
+	case "ndjson":
+		out = &ndjsonSQLOutput{streamSQLOutput: streamSQLOutput{c: c, contentType: "application/x-ndjson"}}
+	}
+
+	result, err := h.console.Run(c.Request.Context(), c.GetInt("userID"), input.Query, input.Format, input.MaxRows, out)
+	if err != nil {
+		switch {
+		case sqlconsole.IsRejected(err):
+			response.Err(c, errcode.SQLRejected, err.Error())
+		case out.started():
+			// The response is on its way, only the trailer can tell
+			logger.Warnf("Console query failed while streaming: %v", err)
+			c.Writer.Header().Set("X-Query-Error", err.Error())
+		case result == nil:
+			logger.Errorf("Failed to run console query: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to run query")
+		default:
+			response.Err(c, errcode.SQLFailed, err.Error())
+		}
+		return
+	}
+
+	out.finish(c, result)
+}
+
+// sqlOutput writes the result of a console query to a response
+type sqlOutput interface {
+	sqlconsole.Output
+	// started reports whether the response was started
+	started() bool
+	// finish completes the response of a query that succeeded
+	finish(c *gin.Context, result *sqlconsole.Result)
+}
+
+// jsonSQLOutput collects the result, which the row cap bounds, for a JSON
+// response
+type jsonSQLOutput struct {
+	columns []database.Column
+	rows    [][]any
+}
+
+func (o *jsonSQLOutput) Columns(columns []database.Column) error {
+	o.columns = columns
+	return nil
+}
+
+func (o *jsonSQLOutput) Row(values []any) error {
+	o.rows = append(o.rows, values)
+	return nil
+}
+
+func (o *jsonSQLOutput) started() bool {
+	return false
+}
+
+func (o *jsonSQLOutput) finish(c *gin.Context, result *sqlconsole.Result) {
+	rows := o.rows
+	if rows == nil {
+		rows = [][]any{}
+	}
+	response.Ok(c, SQLQueryResult{
+		ID:         publicid.Encode(publicid.ConsoleQuery, result.ID),
+		Columns:    o.columns,
+		Rows:       rows,
+		RowCount:   result.RowCount,
+		Truncated:  result.Truncated,
+		DurationMs: result.Duration.Milliseconds(),
+	})
+}
+
+// streamSQLOutput starts a streamed response once the columns are known, so
+// that queries failing before can still get an error response
+type streamSQLOutput struct {
+	c           *gin.Context
+	contentType string
+	begun       bool
+}
+
+func (o *streamSQLOutput) begin() {
+	o.begun = true
+	h := o.c.Writer.Header()
+	h.Set("Content-Type", o.contentType)
+	h.Set("Trailer", "X-Row-Count, X-Truncated, X-Query-Error")
+	o.c.Status(http.StatusOK)
+}
+
+func (o *streamSQLOutput) started() bool {
+	return o.begun
+}
+
+func (o *streamSQLOutput) finish(c *gin.Context, result *sqlconsole.Result) {
+	h := c.Writer.Header()
+	h.Set("X-Row-Count", strconv.Itoa(result.RowCount))
+	h.Set("X-Truncated", strconv.FormatBool(result.Truncated))
+}
+
+// csvSQLOutput streams the result as CSV
+type csvSQLOutput struct {
+	streamSQLOutput
+	w *csv.Writer
+}
+
+func (o *csvSQLOutput) Columns(columns []database.Column) error {
+	o.begin()
+	o.w = csv.NewWriter(o.c.Writer)
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	return o.w.Write(header)
+}
+
+func (o *csvSQLOutput) Row(values []any) error {
+	if err := o.w.Write(database.CSVRecord(values)); err != nil {
+		return err
+	}
+	// Rows reach the client as they are read
+	o.w.Flush()
+	return o.w.Error()
+}
+
+// ndjsonSQLOutput streams the result as newline-delimited JSON
+type ndjsonSQLOutput struct {
+	streamSQLOutput
+	enc *database.ObjectEncoder
+}
+
+func (o *ndjsonSQLOutput) Columns(columns []database.Column) error {
+	var err error
+	if o.enc, err = database.NewObjectEncoder(columns); err != nil {
+		return err
+	}
+	o.begin()
+	line, err := json.Marshal(gin.H{"columns": columns})
+	if err != nil {
+		return err
+	}
+	_, err = o.c.Writer.Write(append(line, '\n'))
+	return err
+}
+
+func (o *ndjsonSQLOutput) Row(values []any) error {
+	if err := o.enc.Encode(o.c.Writer, values); err != nil {
+		return err
+	}
+	_, err := o.c.Writer.Write([]byte{'\n'})
+	return err
+}
+
+// SQLQueryEntry is a query of the console history
+type SQLQueryEntry struct {
+	ID         string    `json:"id" example:"sql_4nd8w2k0qe6ry"`
+	Query      string    `json:"query"`
+	Format     string    `json:"format" example:"json"`
+	Status     string    `json:"status" example:"succeeded"`
+	Error      string    `json:"error,omitempty"`
+	RowCount   int       `json:"row_count"`
+	Truncated  bool      `json:"truncated"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SQLHistoryResult is a page of the console history
+type SQLHistoryResult struct {
+	Items    []SQLQueryEntry `json:"items"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// newSQLQueryEntry converts a console query to its history entry
+func newSQLQueryEntry(q *ent.ConsoleQuery) SQLQueryEntry {
+	return SQLQueryEntry{
+		ID:         publicid.Encode(publicid.ConsoleQuery, q.ID),
+		Query:      q.Query,
+		Format:     string(q.Format),
+		Status:     string(q.Status),
+		Error:      q.Error,
+		RowCount:   q.RowCount,
+		Truncated:  q.Truncated,
+		DurationMs: q.DurationMs,
+		CreatedAt:  q.CreatedAt,
+	}
+}
+
+// History godoc
+// @Summary      List SQL query history
+// @Description  List the console queries of the current admin, newest first, with their outcome (admin only)
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        page       query  int  false  "Page number" default(1)
+// @Param        page_size  query  int  false  "Page size, at most 100" default(20)
+// @Success      200  {object}   response.Response{data=SQLHistoryResult} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | auth.access.denied | auth.impersonation.forbidden"
+// @Router       /admin/sql/history [get]
+// @Security     BearerAuth
+func (h *SQLConsoleHandler) History(c *gin.Context) {
+	page, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+
+	queries, total, err := h.console.History(c.Request.Context(), c.GetInt("userID"), page.Offset(), page.PageSize)
+	if err != nil {
+		logger.Errorf("Failed to list console queries: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch query history")
+		return
+	}
+
+	result := SQLHistoryResult{
+		Items:    make([]SQLQueryEntry, len(queries)),
+		Total:    total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+	for i, q := range queries {
+		result.Items[i] = newSQLQueryEntry(q)
+	}
+	response.Ok(c, result)
+}
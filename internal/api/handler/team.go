@@ -0,0 +1,502 @@
+package handler
+
+import (
+	"context"
+	"go-template/ent"
+	"go-template/ent/membership"
+	"go-template/ent/role"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/response"
+	"go-template/internal/database"
+	"go-template/pkg/errcode"
+	"go-template/pkg/logger"
+	"go-template/pkg/publicid"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TeamHandler handles team-related HTTP requests. All queries run in the
+// organization selected with X-Org-ID.
+type TeamHandler struct {
+	db *database.Client
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(db *database.Client) *TeamHandler {
+	return &TeamHandler{db: db}
+}
+
+// TeamRoleInfo represents a role granted to a team
+type TeamRoleInfo struct {
+	ID   string `json:"id" example:"rol_3kq8x0v1mz7ta"`
+	Name string `json:"name"`
+}
+
+// TeamInfo represents a team of an organization
+type TeamInfo struct {
+	ID          string         `json:"id" example:"team_0c6yb4r8sx2fn"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Roles       []TeamRoleInfo `json:"roles"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	CreatedBy   *string        `json:"created_by,omitempty"`
+	UpdatedBy   *string        `json:"updated_by,omitempty"`
+}
+
+// TeamMemberInfo represents a member of a team
+type TeamMemberInfo struct {
+	UserID string `json:"user_id" example:"usr_q7vm9zp2hc54e"`
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+}
+
+// newTeamInfo builds the response for a team loaded with its roles
+func newTeamInfo(t *ent.Team) TeamInfo {
+	roles := make([]TeamRoleInfo, 0, len(t.Edges.Roles))
+	for _, r := range t.Edges.Roles {
+		roles = append(roles, TeamRoleInfo{ID: publicid.Encode(publicid.Role, r.ID), Name: r.Name})
+	}
+
+	return TeamInfo{
+		ID:          publicid.Encode(publicid.Team, t.ID),
+		Name:        t.Name,
+		Description: t.Description,
+		Roles:       roles,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+		CreatedBy:   publicid.EncodeOptional(publicid.User, t.CreatedBy),
+		UpdatedBy:   publicid.EncodeOptional(publicid.User, t.UpdatedBy),
+	}
+}
+
+// List godoc
+// @Summary      List teams
+// @Description  Get the teams of the organization selected with X-Org-ID
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Success      200  {object}   response.Response{data=[]TeamInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | org.access.denied"
+// @Router       /orgs/current/teams [get]
+// @Security     BearerAuth
+func (h *TeamHandler) List(c *gin.Context) {
+	teams, err := h.db.Ent.Team.Query().
+		WithRoles().
+		Order(ent.Asc(team.FieldName)).
+		All(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to fetch teams: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch teams")
+		return
+	}
+
+	infos := make([]TeamInfo, 0, len(teams))
+	for _, t := range teams {
+		infos = append(infos, newTeamInfo(t))
+	}
+
+	response.Ok(c, infos)
+}
+
+// TeamCreateInput represents the input for creating a team
+type TeamCreateInput struct {
+	Name        string   `json:"name" binding:"required" example:"Engineering"`
+	Description string   `json:"description" example:"Product engineering team"`
+	RoleIDs     []string `json:"role_ids" example:"rol_3kq8x0v1mz7ta"`
+}
+
+// Create godoc
+// @Summary      Create a team
+// @Description  Create a team in the organization selected with X-Org-ID
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string           true  "Organization ID"
+// @Param        team      body    TeamCreateInput  true  "Team Info"
+// @Success      200  {object}   response.Response{data=TeamInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | role.not_found | team.name.taken"
+// @Router       /orgs/current/teams [post]
+// @Security     BearerAuth
+func (h *TeamHandler) Create(c *gin.Context) {
+	var input TeamCreateInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	roleIDs, ok := binder.IDs(c, publicid.Role, input.RoleIDs)
+	if !ok {
+		return
+	}
+	roleIDs = dedupeIDs(roleIDs)
+
+	ctx := c.Request.Context()
+
+	ok, err := h.rolesVisible(ctx, roleIDs)
+	if err != nil {
+		logger.Errorf("Failed to check roles: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create team")
+		return
+	}
+	if !ok {
+		response.Err(c, errcode.RoleNotFound)
+		return
+	}
+
+	t, err := h.db.Ent.Team.Create().
+		SetName(input.Name).
+		SetDescription(input.Description).
+		AddRoleIDs(roleIDs...).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.TeamNameTaken)
+			return
+		}
+		logger.Errorf("Failed to create team: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create team")
+		return
+	}
+
+	t.Edges.Roles, err = t.QueryRoles().All(ctx)
+	if err != nil {
+		logger.Errorf("Failed to fetch team roles: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to create team")
+		return
+	}
+
+	response.Ok(c, newTeamInfo(t))
+}
+
+// Delete godoc
+// @Summary      Delete a team
+// @Description  Delete a team of the organization selected with X-Org-ID
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        id        path    string true  "Team ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | team.not_found"
+// @Router       /orgs/current/teams/{id} [delete]
+// @Security     BearerAuth
+func (h *TeamHandler) Delete(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Team)
+	if !ok {
+		return
+	}
+
+	err := h.db.Ent.Team.DeleteOneID(id).Exec(c.Request.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+		logger.Errorf("Failed to delete team: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to delete team")
+		return
+	}
+
+	response.OkWithMessage(c, "Team deleted successfully", nil)
+}
+
+// TeamRolesInput represents the input for setting the roles of a team
+type TeamRolesInput struct {
+	RoleIDs []string `json:"role_ids" example:"rol_3kq8x0v1mz7ta"`
+}
+
+// SetRoles godoc
+// @Summary      Set team roles
+// @Description  Replace the roles granted to the members of a team
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string          true  "Organization ID"
+// @Param        id        path    string          true  "Team ID"
+// @Param        roles     body    TeamRolesInput  true  "Role IDs"
+// @Success      200  {object}   response.Response{data=TeamInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | team.not_found | role.not_found"
+// @Router       /orgs/current/teams/{id}/roles [put]
+// @Security     BearerAuth
+func (h *TeamHandler) SetRoles(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Team)
+	if !ok {
+		return
+	}
+
+	var input TeamRolesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	roleIDs, ok := binder.IDs(c, publicid.Role, input.RoleIDs)
+	if !ok {
+		return
+	}
+	roleIDs = dedupeIDs(roleIDs)
+
+	ctx := c.Request.Context()
+
+	ok, err := h.rolesVisible(ctx, roleIDs)
+	if err != nil {
+		logger.Errorf("Failed to check roles: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update team roles")
+		return
+	}
+	if !ok {
+		response.Err(c, errcode.RoleNotFound)
+		return
+	}
+
+	t, err := h.db.Ent.Team.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch team: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update team roles")
+		return
+	}
+
+	t, err = t.Update().
+		ClearRoles().
+		AddRoleIDs(roleIDs...).
+		Save(ctx)
+	if err != nil {
+		logger.Errorf("Failed to update team roles: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update team roles")
+		return
+	}
+
+	t.Edges.Roles, err = t.QueryRoles().All(ctx)
+	if err != nil {
+		logger.Errorf("Failed to fetch team roles: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to update team roles")
+		return
+	}
+
+	response.Ok(c, newTeamInfo(t))
+}
+
+// ListMembers godoc
+// @Summary      List team members
+// @Description  Get the members of a team
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        id        path    string true  "Team ID"
+// @Success      200  {object}   response.Response{data=[]TeamMemberInfo} "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | team.not_found"
+// @Router       /orgs/current/teams/{id}/members [get]
+// @Security     BearerAuth
+func (h *TeamHandler) ListMembers(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Team)
+	if !ok {
+		return
+	}
+
+	t, err := h.db.Ent.Team.Query().
+		Where(team.ID(id)).
+		WithMembers().
+		Only(c.Request.Context())
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch team members: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch team members")
+		return
+	}
+
+	members := make([]TeamMemberInfo, 0, len(t.Edges.Members))
+	for _, u := range t.Edges.Members {
+		members = append(members, TeamMemberInfo{
+			UserID: publicid.Encode(publicid.User, u.ID),
+			Name:   u.Name,
+			Email:  u.Email,
+		})
+	}
+
+	response.Ok(c, members)
+}
+
+// TeamMemberAddInput represents the input for adding a member to a team
+type TeamMemberAddInput struct {
+	UserID string `json:"user_id" binding:"required" example:"usr_q7vm9zp2hc54e"`
+}
+
+// AddMember godoc
+// @Summary      Add a team member
+// @Description  Add a member of the organization to one of its teams
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string              true  "Organization ID"
+// @Param        id        path    string              true  "Team ID"
+// @Param        member    body    TeamMemberAddInput  true  "Member Info"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | team.not_found | org.member.not_found | team.member.exists"
+// @Router       /orgs/current/teams/{id}/members [post]
+// @Security     BearerAuth
+func (h *TeamHandler) AddMember(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Team)
+	if !ok {
+		return
+	}
+
+	var input TeamMemberAddInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		response.Err(c, errcode.InvalidParams, err.Error())
+		return
+	}
+
+	userID, ok := binder.ID(c, publicid.User, input.UserID)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	// Teams only hold members of their organization
+	isMember, err := h.db.Ent.Membership.Query().
+		Where(membership.UserID(userID)).
+		Exist(ctx)
+	if err != nil {
+		logger.Errorf("Failed to check membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to add team member")
+		return
+	}
+	if !isMember {
+		response.Err(c, errcode.OrgMemberNotFound)
+		return
+	}
+
+	inTeam, err := h.hasMember(ctx, id, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+		logger.Errorf("Failed to check team membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to add team member")
+		return
+	}
+	if inTeam {
+		response.Err(c, errcode.TeamMemberExists)
+		return
+	}
+
+	err = h.db.Ent.Team.UpdateOneID(id).
+		AddMemberIDs(userID).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.TeamMemberExists)
+			return
+		}
+		logger.Errorf("Failed to add team member: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to add team member")
+		return
+	}
+
+	response.OkWithMessage(c, "Team member added successfully", nil)
+}
+
+// RemoveMember godoc
+// @Summary      Remove a team member
+// @Description  Remove a user from a team
+// @Tags         teams
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header  string true  "Organization ID"
+// @Param        id        path    string true  "Team ID"
+// @Param        user_id   path    string true  "User ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error | invalid.params | team.not_found | team.member.not_found"
+// @Router       /orgs/current/teams/{id}/members/{user_id} [delete]
+// @Security     BearerAuth
+func (h *TeamHandler) RemoveMember(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.Team)
+	if !ok {
+		return
+	}
+	userID, ok := binder.PathID(c, "user_id", publicid.User)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	inTeam, err := h.hasMember(ctx, id, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.TeamNotFound)
+			return
+		}
+		logger.Errorf("Failed to check team membership: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to remove team member")
+		return
+	}
+	if !inTeam {
+		response.Err(c, errcode.TeamMemberNotFound)
+		return
+	}
+
+	err = h.db.Ent.Team.UpdateOneID(id).
+		RemoveMemberIDs(userID).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf("Failed to remove team member: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to remove team member")
+		return
+	}
+
+	response.OkWithMessage(c, "Team member removed successfully", nil)
+}
+
+// hasMember reports whether the user belongs to the team, returning a not
+// found error if the team does not exist in the organization
+func (h *TeamHandler) hasMember(ctx context.Context, teamID, userID int) (bool, error) {
+	t, err := h.db.Ent.Team.Get(ctx, teamID)
+	if err != nil {
+		return false, err
+	}
+	return t.QueryMembers().Where(user.ID(userID)).Exist(ctx)
+}
+
+// rolesVisible reports whether all roles exist in the organization, either
+// as shared roles or as the organization's own. ids must not contain duplicates.
+func (h *TeamHandler) rolesVisible(ctx context.Context, ids []int) (bool, error) {
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	n, err := h.db.Ent.Role.Query().Where(role.IDIn(ids...)).Count(ctx)
+	if err != nil {
+		return false, err
+	}
+	return n == len(ids), nil
+}
+
+// dedupeIDs returns ids without duplicates, keeping the first occurrence
+func dedupeIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	out := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
@@ -1,76 +1,252 @@
 package handler
 
 import (
+	"context"
+	"errors"
 	"go-template/ent"
 	"go-template/ent/role"
 	"go-template/ent/user"
+	"go-template/internal/api/binder"
+	"go-template/internal/api/patch"
+	"go-template/internal/api/policy"
 	"go-template/internal/api/response"
 	"go-template/internal/database"
+	"go-template/internal/session"
+	"go-template/internal/softdelete"
+	"go-template/internal/userbulk"
+	"go-template/internal/usersearch"
 	"go-template/pkg/errcode"
 	"go-template/pkg/logger"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"golang.org/x/crypto/bcrypt"
 )
 
-func hashPassword(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return "", err
+// PublicUserView is the projection of a user visible to other users
+type PublicUserView struct {
+	ID   string `json:"id" example:"usr_q7vm9zp2hc54e"`
+	Name string `json:"name"`
+}
+
+// UserView is the projection of a user visible to that user
+type UserView struct {
+	ID        string    `json:"id" example:"usr_q7vm9zp2hc54e"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// UserDetail is the full record of a user, visible to admins
+type UserDetail struct {
+	ID        string      `json:"id" example:"usr_q7vm9zp2hc54e"`
+	Name      string      `json:"name"`
+	Email     string      `json:"email"`
+	Status    user.Status `json:"status"`
+	RoleID    *string     `json:"role_id" example:"rol_3kq8x0v1mz7ta"`
+	Role      *RoleInfo   `json:"role,omitempty"`
+	Version   int         `json:"version"` // Also sent as the ETag header of single-user responses
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	CreatedBy *string     `json:"created_by,omitempty"`
+	UpdatedBy *string     `json:"updated_by,omitempty"`
+	DeletedAt *time.Time  `json:"deleted_at,omitempty"`
+}
+
+// newUserDetail converts a user, with its role if loaded, to its full record
+func newUserDetail(u *ent.User) UserDetail {
+	d := UserDetail{
+		ID:        publicid.Encode(publicid.User, u.ID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Status:    u.Status,
+		Version:   u.Version,
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+		CreatedBy: publicid.EncodeOptional(publicid.User, u.CreatedBy),
+		UpdatedBy: publicid.EncodeOptional(publicid.User, u.UpdatedBy),
+		DeletedAt: u.DeletedAt,
+	}
+	if u.RoleID != 0 {
+		roleID := publicid.Encode(publicid.Role, u.RoleID)
+		d.RoleID = &roleID
 	}
-	return string(hashedPassword), nil
+	if u.Edges.Role != nil {
+		info := newRoleInfo(u.Edges.Role)
+		d.Role = &info
+	}
+	return d
+}
+
+// newUserDetails converts users to their full records
+func newUserDetails(users []*ent.User) []UserDetail {
+	details := make([]UserDetail, 0, len(users))
+	for _, u := range users {
+		details = append(details, newUserDetail(u))
+	}
+	return details
+}
+
+// presentUser projects a user according to the access level. Only full
+// access exposes administrative fields such as status and role.
+func presentUser(u *ent.User, access policy.Access) interface{} {
+	switch access {
+	case policy.AccessFull:
+		return newUserDetail(u)
+	case policy.AccessSelf:
+		return UserView{
+			ID:        publicid.Encode(publicid.User, u.ID),
+			Name:      u.Name,
+			Email:     u.Email,
+			CreatedAt: u.CreatedAt,
+			UpdatedAt: u.UpdatedAt,
+		}
+	case policy.AccessPublic:
+		return PublicUserView{
+			ID:   publicid.Encode(publicid.User, u.ID),
+			Name: u.Name,
+		}
+	}
+	return nil
 }
 
 // UserHandler handles user-related HTTP requests
 type UserHandler struct {
-	db *database.Client
+	db       *database.Client
+	sessions *session.Store
 }
 
 // NewUserHandler creates a new user handler
-func NewUserHandler(db *database.Client) *UserHandler {
-	return &UserHandler{db: db}
+func NewUserHandler(db *database.Client, sessions *session.Store) *UserHandler {
+	return &UserHandler{db: db, sessions: sessions}
 }
 
 // List godoc
 // @Summary      List users
-// @Description  get user list
+// @Description  get user list. Admins receive full records (UserDetail); other users receive their own record as UserView (id, name, email, created_at, updated_at) and others as PublicUserView (id, name).
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}   response.Response{data=[]ent.User} "ok"
-// @Failure      500  {object}   response.Response "server.error"
+// @Param        include_deleted  query  bool  false  "Include soft-deleted users (admin only)"
+// @Success      200  {object}   response.Response{data=[]UserDetail} "ok"
+// @Failure      500  {object}   response.Response "server.error | auth.access.denied"
 // @Router       /users [get]
 // @Security     BearerAuth
 func (h *UserHandler) List(c *gin.Context) {
-	users, err := h.db.Ent.User.Query().WithRole().All(c.Request.Context())
+	query, ctx, ok := h.listQuery(c)
+	if !ok {
+		return
+	}
+
+	users, err := query.All(ctx)
 	if err != nil {
 		logger.Errorf("Failed to fetch users: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to fetch users")
 		return
 	}
-	response.Ok(c, users)
+
+	views := make([]interface{}, 0, len(users))
+	for _, u := range users {
+		if access := policy.Check(c, u.ID); access != policy.AccessNone {
+			views = append(views, presentUser(u, access))
+		}
+	}
+	response.Ok(c, views)
+}
+
+// UserSearchHit is a user matching a search
+type UserSearchHit struct {
+	User UserDetail `json:"user"`
+	// Matched fields with the matches wrapped in <mark> tags, HTML-escaped
+	Highlights map[string]string `json:"highlights" example:"name:<mark>Jo</mark>hn Doe"`
+}
+
+// UserSearchResult is a page of search results
+type UserSearchResult struct {
+	Items    []UserSearchHit `json:"items"`
+	Total    int             `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// Search godoc
+// @Summary      Search users
+// @Description  find users by partial name or email, most relevant first. Misspelled queries match by trigram similarity when the database supports it. While personal data is encrypted, only exact email matches are found.
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        q                query  string  true   "Search query"
+// @Param        page             query  int     false  "Page number" default(1)
+// @Param        page_size        query  int     false  "Page size, at most 100" default(20)
+// @Param        include_deleted  query  bool    false  "Include soft-deleted users"
+// @Success      200  {object}   response.Response{data=UserSearchResult} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params"
+// @Router       /users/search [get]
+// @Security     BearerAuth
+func (h *UserHandler) Search(c *gin.Context) {
+	page, ok := bindPagination(c)
+	if !ok {
+		return
+	}
+	query, ctx, ok := h.listQuery(c)
+	if !ok {
+		return
+	}
+
+	hits, total, err := usersearch.Search(ctx, h.db, query, c.Query("q"), page.Offset(), page.PageSize)
+	if err != nil {
+		if errors.Is(err, usersearch.ErrEmptyQuery) {
+			response.Err(c, errcode.InvalidParams, "Search query must contain letters or digits")
+			return
+		}
+		logger.Errorf("Failed to search users: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to search users")
+		return
+	}
+
+	result := UserSearchResult{
+		Items:    make([]UserSearchHit, len(hits)),
+		Total:    total,
+		Page:     page.Page,
+		PageSize: page.PageSize,
+	}
+	for i, hit := range hits {
+		result.Items[i] = UserSearchHit{User: newUserDetail(hit.User), Highlights: hit.Highlights}
+	}
+	response.Ok(c, result)
 }
 
 // Get godoc
 // @Summary      Get a user
-// @Description  get user by ID
+// @Description  get user by ID. Admins receive the full record (UserDetail); other users receive their own record as UserView (id, name, email, created_at, updated_at) or the public PublicUserView (id, name).
+// @Description  The ETag header carries the user's version; send it in If-None-Match to get 304 while the user is unchanged.
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "User ID"
-// @Success      200  {object}   response.Response{data=ent.User} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.not_found"
+// @Param        id   path      string true  "User ID"
+// @Param        include_deleted  query  bool  false  "Include soft-deleted users (admin only)"
+// @Param        If-None-Match    header string false "ETag of a previous response"
+// @Success      200  {object}   response.Response{data=UserDetail} "ok"
+// @Success      304  "not modified"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ user.not_found | auth.access.denied"
 // @Router       /users/{id} [get]
 // @Security     BearerAuth
 func (h *UserHandler) Get(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid user ID")
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
+		return
+	}
+
+	ctx, ok := deletedScope(c)
+	if !ok {
 		return
 	}
 
-	user, err := h.db.Ent.User.Query().WithRole().Where(user.ID(id)).Only(c.Request.Context())
+	user, err := h.db.Ent.User.Query().WithRole().Where(user.ID(id)).Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			response.Err(c, errcode.UserNotFound)
@@ -81,15 +257,23 @@ func (h *UserHandler) Get(c *gin.Context) {
 		return
 	}
 
-	response.Ok(c, user)
+	access := policy.Check(c, user.ID)
+	if access == policy.AccessNone {
+		response.Err(c, errcode.AuthAccessDenied, "Insufficient permissions")
+		return
+	}
+	if notModified(c, user.Version) {
+		return
+	}
+	response.Ok(c, presentUser(user, access))
 }
 
 // UserCreateInput represents the input for creating a user
 type UserCreateInput struct {
 	Name     string      `json:"name" binding:"required" example:"John Doe"`
 	Email    string      `json:"email" binding:"required,email" example:"john@example.com"`
-	Password string      `json:"password" binding:"required,min=6" example:"secret123"`
-	RoleID   int         `json:"role_id" binding:"required" example:"1"`
+	Password string      `json:"password" binding:"required" example:"secret123"`
+	RoleID   string      `json:"role_id" binding:"required" example:"rol_3kq8x0v1mz7ta"`
 	Status   user.Status `json:"status" example:"active"`
 }
 
@@ -100,8 +284,8 @@ type UserCreateInput struct {
 // @Accept       json
 // @Produce      json
 // @Param        user  body      UserCreateInput  true  "User Info"
-// @Success      200  {object}   response.Response{data=ent.User} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params"
+// @Success      200  {object}   response.Response{data=UserDetail} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params ｜ role.not_found | user.password.weak | user.email.taken"
 // @Router       /users [post]
 // @Security     BearerAuth
 func (h *UserHandler) Create(c *gin.Context) {
@@ -112,7 +296,31 @@ func (h *UserHandler) Create(c *gin.Context) {
 		return
 	}
 
-	hashedPassword, err := hashPassword(input.Password)
+	roleID, ok := binder.ID(c, publicid.Role, input.RoleID)
+	if !ok {
+		return
+	}
+
+	if err := password.Validate(input.Password); err != nil {
+		response.Err(c, errcode.UserPasswordWeak, err.Error())
+		return
+	}
+
+	// Check the role first, so a constraint error means a taken email
+	exists, err := h.db.Ent.Role.Query().
+		Where(role.ID(roleID)).
+		Exist(c.Request.Context())
+	if err != nil {
+		logger.Errorf("Failed to check role existence: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to validate role")
+		return
+	}
+	if !exists {
+		response.Err(c, errcode.RoleNotFound)
+		return
+	}
+
+	hashedPassword, err := password.Hash(input.Password)
 	if err != nil {
 		logger.Errorf("Failed to hash password: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to process user data")
@@ -123,7 +331,7 @@ func (h *UserHandler) Create(c *gin.Context) {
 		SetName(input.Name).
 		SetEmail(input.Email).
 		SetPassword(hashedPassword).
-		SetRoleID(input.RoleID)
+		SetRoleID(roleID)
 
 	// Set status if provided, otherwise it will use default value
 	if input.Status != "" {
@@ -133,12 +341,16 @@ func (h *UserHandler) Create(c *gin.Context) {
 	// Save the user
 	user, err := create.Save(c.Request.Context())
 	if err != nil {
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.UserEmailTaken)
+			return
+		}
 		logger.Errorf("Failed to create user: %v", err)
 		response.Err(c, errcode.ServerError, "Failed to create user")
 		return
 	}
 
-	response.Ok(c, user)
+	response.Ok(c, newUserDetail(user))
 }
 
 // UserUpdateInput represents the input for updating a user
@@ -146,26 +358,27 @@ type UserUpdateInput struct {
 	Name     string      `json:"name" example:"John Doe"`
 	Email    string      `json:"email" example:"john@example.com"`
 	Password string      `json:"password" example:"newsecret123"`
-	RoleID   *int        `json:"role_id" example:"2"`
+	RoleID   *string     `json:"role_id" example:"rol_3kq8x0v1mz7ta"` // An empty string clears the role
 	Status   user.Status `json:"status" example:"active"`
 }
 
 // Update godoc
 // @Summary      Update a user
-// @Description  update an existing user
+// @Description  update an existing user. With If-Match, the update is rejected with 412 if the user changed since it was read.
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id    path      int              true  "User ID"
-// @Param        user  body      UserUpdateInput  true  "User Info"
-// @Success      200  {object}   response.Response{data=ent.User} "ok"
-// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | user.not_found | role.not_found"
+// @Param        id        path      string           true   "User ID"
+// @Param        If-Match  header    string           false  "ETag of the version being updated"
+// @Param        user      body      UserUpdateInput  true   "User Info"
+// @Success      200  {object}   response.Response{data=UserDetail} "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | user.not_found | role.not_found | user.password.weak | user.email.taken"
 // @Router       /users/{id} [put]
 // @Security     BearerAuth
 func (h *UserHandler) Update(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid user ID")
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
 		return
 	}
 
@@ -179,6 +392,12 @@ func (h *UserHandler) Update(c *gin.Context) {
 	// Start building the update query
 	update := h.db.Ent.User.UpdateOneID(id)
 
+	// Only update the version the client has seen
+	versions := ifMatchVersions(c)
+	if versions != nil {
+		update = update.Where(user.VersionIn(versions...))
+	}
+
 	// Only set fields that were provided
 	if input.Name != "" {
 		update = update.SetName(input.Name)
@@ -187,7 +406,11 @@ func (h *UserHandler) Update(c *gin.Context) {
 		update = update.SetEmail(input.Email)
 	}
 	if input.Password != "" {
-		hashedPassword, err := hashPassword(input.Password)
+		if err := password.Validate(input.Password); err != nil {
+			response.Err(c, errcode.UserPasswordWeak, err.Error())
+			return
+		}
+		hashedPassword, err := password.Hash(input.Password)
 		if err != nil {
 			logger.Errorf("Failed to hash password: %v", err)
 			response.Err(c, errcode.ServerError, "Failed to process user data")
@@ -200,10 +423,14 @@ func (h *UserHandler) Update(c *gin.Context) {
 	}
 	// Handle role relationship
 	if input.RoleID != nil {
-		if *input.RoleID > 0 {
+		roleID, ok := binder.OptionalID(c, publicid.Role, input.RoleID)
+		if !ok {
+			return
+		}
+		if roleID != nil {
 			// Check if the role exists
 			exists, err := h.db.Ent.Role.Query().
-				Where(role.ID(*input.RoleID)).
+				Where(role.ID(*roleID)).
 				Exist(c.Request.Context())
 
 			if err != nil {
@@ -218,17 +445,21 @@ func (h *UserHandler) Update(c *gin.Context) {
 			}
 
 			// Set role ID
-			update = update.SetRoleID(*input.RoleID)
+			update = update.SetRoleID(*roleID)
 		} else {
-			// If role ID is 0 or negative, clear the role relationship
+			// An empty role ID clears the role relationship
 			update = update.ClearRole()
 		}
 	}
 	// Execute update
-	user, err := update.Save(c.Request.Context())
+	u, err := update.Save(c.Request.Context())
 	if err != nil {
 		if ent.IsNotFound(err) {
-			response.Err(c, errcode.UserNotFound)
+			notFoundOrModified(c, versions != nil, h.exists(c, id), errcode.UserNotFound)
+			return
+		}
+		if ent.IsConstraintError(err) {
+			response.Err(c, errcode.UserEmailTaken)
 			return
 		}
 		logger.Errorf("Failed to update user: %v", err)
@@ -236,31 +467,187 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
-	response.Ok(c, user)
+	setETag(c, u.Version)
+	response.Ok(c, newUserDetail(u))
+}
+
+// userPatchFields are the fields a user patch may change
+var userPatchFields = patch.Fields{
+	"name":     {},
+	"email":    {},
+	"password": {WriteOnly: true},
+	"status":   {},
+	"role_id":  {Nullable: true},
+}
+
+// UserPatchInput lists the fields of a user patch. A null role_id clears the role.
+type UserPatchInput struct {
+	Name     *string      `json:"name" binding:"omitempty,min=1" example:"John Doe"`
+	Email    *string      `json:"email" binding:"omitempty,email" example:"john@example.com"`
+	Password *string      `json:"password" example:"newsecret123"`
+	Status   *user.Status `json:"status" binding:"omitempty,oneof=active disabled" example:"active"`
+	RoleID   *string      `json:"role_id" example:"rol_3kq8x0v1mz7ta"`
+}
+
+// Patch godoc
+// @Summary      Patch a user
+// @Description  partially update a user with a JSON Merge Patch (RFC 7396) or a JSON Patch (RFC 6902).
+// @Description  An explicit null, or a remove operation, clears role_id. All changes are applied at once or not at all.
+// @Description  With If-Match, the patch is rejected with 412 if the user changed since it was read.
+// @Tags         users
+// @Accept       application/merge-patch+json
+// @Accept       application/json-patch+json
+// @Produce      json
+// @Param        id        path      string          true   "User ID"
+// @Param        If-Match  header    string          false  "ETag of the version being patched"
+// @Param        patch     body      UserPatchInput  true   "Merge patch, or an array of JSON Patch operations on these fields"
+// @Success      200  {object}   response.Response{data=UserDetail} "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
+// @Failure      415  {object}   response.Response "invalid.params"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | user.not_found | role.not_found | user.password.weak | user.email.taken"
+// @Router       /users/{id} [patch]
+// @Security     BearerAuth
+func (h *UserHandler) Patch(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	u, err := h.db.Ent.User.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.UserNotFound)
+			return
+		}
+		logger.Errorf("Failed to fetch user: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to fetch user")
+		return
+	}
+	if !versionMatches(c, u.Version) {
+		return
+	}
+
+	doc := patch.Document{
+		"name":    u.Name,
+		"email":   u.Email,
+		"status":  u.Status,
+		"role_id": nil,
+	}
+	if u.RoleID != 0 {
+		doc["role_id"] = publicid.Encode(publicid.Role, u.RoleID)
+	}
+
+	p, ok := bindPatch(c, userPatchFields, doc)
+	if !ok {
+		return
+	}
+	var input UserPatchInput
+	if !decodePatch(c, p, &input) {
+		return
+	}
+	if p.Empty() {
+		setETag(c, u.Version)
+		response.Ok(c, newUserDetail(u))
+		return
+	}
+
+	// The patch was computed from this version, so it must still be current
+	update := h.db.Ent.User.UpdateOneID(id).Where(user.Version(u.Version))
+
+	if input.Name != nil {
+		update = update.SetName(*input.Name)
+	}
+	if input.Email != nil {
+		update = update.SetEmail(*input.Email)
+	}
+	if input.Password != nil {
+		if err := password.Validate(*input.Password); err != nil {
+			response.Err(c, errcode.UserPasswordWeak, err.Error())
+			return
+		}
+		hashedPassword, err := password.Hash(*input.Password)
+		if err != nil {
+			logger.Errorf("Failed to hash password: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to process user data")
+			return
+		}
+		update = update.SetPassword(hashedPassword)
+	}
+	if input.Status != nil {
+		update = update.SetStatus(*input.Status)
+	}
+	if p.IsNull("role_id") {
+		update = update.ClearRole()
+	} else if input.RoleID != nil {
+		roleID, ok := binder.ID(c, publicid.Role, *input.RoleID)
+		if !ok {
+			return
+		}
+		exists, err := h.db.Ent.Role.Query().Where(role.ID(roleID)).Exist(ctx)
+		if err != nil {
+			logger.Errorf("Failed to check role existence: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to validate role")
+			return
+		}
+		if !exists {
+			response.Err(c, errcode.RoleNotFound)
+			return
+		}
+		update = update.SetRoleID(roleID)
+	}
+
+	u, err = update.Save(ctx)
+	if err != nil {
+		switch {
+		case ent.IsNotFound(err):
+			notFoundOrModified(c, true, h.exists(c, id), errcode.UserNotFound)
+		case ent.IsConstraintError(err):
+			response.Err(c, errcode.UserEmailTaken)
+		case ent.IsValidationError(err):
+			response.Err(c, errcode.InvalidParams, err.Error())
+		default:
+			logger.Errorf("Failed to patch user: %v", err)
+			response.Err(c, errcode.ServerError, "Failed to update user")
+		}
+		return
+	}
+
+	setETag(c, u.Version)
+	response.Ok(c, newUserDetail(u))
 }
 
 // Delete godoc
 // @Summary      Delete a user
-// @Description  delete a user by ID
+// @Description  soft-delete a user by ID. The user can be restored until it is purged.
+// @Description  With If-Match, the deletion is rejected with 412 if the user changed since it was read.
 // @Tags         users
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "User ID"
+// @Param        id        path    string  true   "User ID"
+// @Param        If-Match  header  string  false  "ETag of the version being deleted"
 // @Success      200  {object}   response.Response "ok"
+// @Failure      412  {object}   response.Response "resource.modified"
 // @Failure      500  {object}   response.Response "server.error ｜ invalid.params | user.not_found"
 // @Router       /users/{id} [delete]
 // @Security     BearerAuth
 func (h *UserHandler) Delete(c *gin.Context) {
-	id, err := strconv.Atoi(c.Param("id"))
-	if err != nil {
-		response.Err(c, errcode.InvalidParams, "Invalid user ID")
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
 		return
 	}
 
-	err = h.db.Ent.User.DeleteOneID(id).Exec(c.Request.Context())
+	del := h.db.Ent.User.DeleteOneID(id)
+	versions := ifMatchVersions(c)
+	if versions != nil {
+		del = del.Where(user.VersionIn(versions...))
+	}
+
+	err := del.Exec(c.Request.Context())
 	if err != nil {
 		if ent.IsNotFound(err) {
-			response.Err(c, errcode.UserNotFound)
+			notFoundOrModified(c, versions != nil, h.exists(c, id), errcode.UserNotFound)
 			return
 		}
 		logger.Errorf("Failed to delete user: %v", err)
@@ -268,5 +655,206 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		return
 	}
 
+	// Deleted users keep their rows, so their sessions must be ended explicitly
+	if _, err := h.sessions.RevokeAll(c.Request.Context(), id, 0); err != nil {
+		logger.Errorf("Failed to revoke sessions of deleted user %d: %v", id, err)
+	}
+
 	response.OkWithMessage(c, "User deleted successfully", nil)
 }
+
+// Restore godoc
+// @Summary      Restore a user
+// @Description  restore a soft-deleted user by ID
+// @Tags         users
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string true  "User ID"
+// @Success      200  {object}   response.Response "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params | user.not_found"
+// @Router       /users/{id}/restore [post]
+// @Security     BearerAuth
+func (h *UserHandler) Restore(c *gin.Context) {
+	id, ok := binder.PathID(c, "id", publicid.User)
+	if !ok {
+		return
+	}
+
+	err := h.db.Ent.User.UpdateOneID(id).
+		Where(user.DeletedAtNotNil()).
+		ClearDeletedAt().
+		Exec(softdelete.IncludeDeleted(c.Request.Context()))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			response.Err(c, errcode.UserNotFound, "Deleted user not found")
+			return
+		}
+		logger.Errorf("Failed to restore user: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to restore user")
+		return
+	}
+
+	response.OkWithMessage(c, "User restored successfully", nil)
+}
+
+// exists returns a check of whether the user exists and is not deleted
+func (h *UserHandler) exists(c *gin.Context, id int) func() (bool, error) {
+	return func() (bool, error) {
+		return h.db.Ent.User.Query().Where(user.ID(id)).Exist(c.Request.Context())
+	}
+}
+
+// listQuery returns the query of the user list with the request's filters
+// applied. It writes an error response and returns false if a filter is not
+// allowed for the current user.
+func (h *UserHandler) listQuery(c *gin.Context) (*ent.UserQuery, context.Context, bool) {
+	ctx, ok := deletedScope(c)
+	if !ok {
+		return nil, nil, false
+	}
+	return h.db.Ent.User.Query().WithRole(), ctx, true
+}
+
+// maxImportSize bounds the size of user import files
+const maxImportSize = 10 << 20
+
+// exportPageSize is the number of users fetched per query of an export
+const exportPageSize = 500
+
+// Import godoc
+// @Summary      Import users
+// @Description  create or update users from a CSV file with a header row or from newline-delimited JSON.
+// @Description  Columns: name, email, password, role (name of a shared role, "user" by default) and status; other columns are ignored.
+// @Description  Invalid rows are reported and skipped. Valid rows are written in batches, each in a transaction; a failed write fails its whole batch.
+// @Tags         users
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Produce      json
+// @Param        format      query  string  false  "File format, by default from the Content-Type" Enums(csv, ndjson)
+// @Param        mode        query  string  false  "create fails rows of existing emails, upsert updates those users" Enums(create, upsert)
+// @Param        dry_run     query  bool    false  "Validate and report without writing"
+// @Param        batch_size  query  int     false  "Rows per transaction, 0 for a single transaction"
+// @Param        file        body   string  true   "Import file"
+// @Success      200  {object}   response.Response{data=userbulk.Report} "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params"
+// @Router       /users/import [post]
+// @Security     BearerAuth
+func (h *UserHandler) Import(c *gin.Context) {
+	format, err := importFormat(c)
+	if err != nil {
+		response.Err(c, errcode.InvalidParams, "Format must be csv or ndjson")
+		return
+	}
+
+	opts := userbulk.Options{
+		Mode:   c.DefaultQuery("mode", userbulk.ModeCreate),
+		DryRun: c.Query("dry_run") == "true",
+	}
+	if opts.Mode != userbulk.ModeCreate && opts.Mode != userbulk.ModeUpsert {
+		response.Err(c, errcode.InvalidParams, "Mode must be create or upsert")
+		return
+	}
+	if opts.BatchSize, err = strconv.Atoi(c.DefaultQuery("batch_size", "0")); err != nil || opts.BatchSize < 0 {
+		response.Err(c, errcode.InvalidParams, "Invalid batch size")
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	report, err := userbulk.Import(c.Request.Context(), h.db, body, format, opts)
+	if err != nil {
+		if errors.Is(err, userbulk.ErrInvalidFile) {
+			response.Err(c, errcode.InvalidParams, err.Error())
+			return
+		}
+		logger.Errorf("Failed to import users: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to import users")
+		return
+	}
+
+	response.Ok(c, report)
+}
+
+// importFormat returns the format of an import file, from the format query
+// parameter or else the Content-Type
+func importFormat(c *gin.Context) (userbulk.Format, error) {
+	if name := c.Query("format"); name != "" {
+		return userbulk.ParseFormat(name)
+	}
+	switch c.ContentType() {
+	case "text/csv":
+		return userbulk.FormatCSV, nil
+	case "application/x-ndjson", "application/jsonl":
+		return userbulk.FormatNDJSON, nil
+	}
+	return "", userbulk.ErrFormat
+}
+
+// Export godoc
+// @Summary      Export users
+// @Description  stream the user list, with the list filters applied, as CSV or newline-delimited JSON
+// @Tags         users
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format           query  string  false  "File format" Enums(csv, ndjson) default(csv)
+// @Param        include_deleted  query  bool    false  "Include soft-deleted users"
+// @Success      200  {array}    userbulk.ExportRow "ok"
+// @Failure      500  {object}   response.Response "server.error ｜ invalid.params"
+// @Router       /users/export [get]
+// @Security     BearerAuth
+func (h *UserHandler) Export(c *gin.Context) {
+	format, err := userbulk.ParseFormat(c.DefaultQuery("format", string(userbulk.FormatCSV)))
+	if err != nil {
+		response.Err(c, errcode.InvalidParams, "Format must be csv or ndjson")
+		return
+	}
+
+	query, ctx, ok := h.listQuery(c)
+	if !ok {
+		return
+	}
+
+	// Users are fetched in pages by ID, so large exports are streamed
+	page := func(afterID int) ([]*ent.User, error) {
+		return query.Clone().
+			Where(user.IDGT(afterID)).
+			Order(ent.Asc(user.FieldID)).
+			Limit(exportPageSize).
+			All(ctx)
+	}
+
+	// Errors of the first page can still be reported as a response
+	users, err := page(0)
+	if err != nil {
+		logger.Errorf("Failed to fetch users: %v", err)
+		response.Err(c, errcode.ServerError, "Failed to export users")
+		return
+	}
+
+	w, _ := userbulk.NewWriter(c.Writer, format)
+	c.Header("Content-Type", w.ContentType())
+	c.Header("Content-Disposition", `attachment; filename="users.`+string(format)+`"`)
+	c.Status(http.StatusOK)
+
+	for {
+		for _, u := range users {
+			if err := w.Write(userbulk.NewExportRow(u)); err != nil {
+				logger.Errorf("Failed to write user export: %v", err)
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			logger.Errorf("Failed to write user export: %v", err)
+			return
+		}
+		c.Writer.Flush()
+
+		if len(users) < exportPageSize {
+			return
+		}
+		if users, err = page(users[len(users)-1].ID); err != nil {
+			// The response is already streaming, so it can only be cut short
+			logger.Errorf("Failed to fetch users: %v", err)
+			return
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"go-template/internal/actor"
 	"go-template/internal/api/response"
+	"go-template/internal/session"
 	"go-template/pkg/auth"
 	"go-template/pkg/errcode"
 	"go-template/pkg/logger"
@@ -10,8 +12,9 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// JWTAuthMiddleware validates JWT tokens and adds user information to the context
-func JWTAuthMiddleware(config auth.JWTConfig) gin.HandlerFunc {
+// JWTAuthMiddleware validates JWT tokens and adds user information to the context.
+// Tokens bound to a session are rejected once that session is revoked.
+func JWTAuthMiddleware(config auth.JWTConfig, sessions *session.Store) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -43,10 +46,44 @@ func JWTAuthMiddleware(config auth.JWTConfig) gin.HandlerFunc {
 			return
 		}
 
+		// Check the session has not been revoked
+		if claims.SessionID != 0 && sessions != nil {
+			active, err := sessions.IsActive(c.Request.Context(), claims.SessionID)
+			if err != nil {
+				logger.Errorf("Failed to check session: %v", err)
+				response.Err(c, errcode.ServerError)
+				c.Abort()
+				return
+			}
+			if !active {
+				response.Err(c, errcode.AuthSessionInvalid)
+				c.Abort()
+				return
+			}
+		}
+
 		// Add claims to context
 		c.Set("userID", claims.UserID)
 		c.Set("username", claims.Username)
 		c.Set("userRole", claims.Role)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("orgID", claims.OrgID)
+
+		// Expose the real actor of impersonation tokens
+		if claims.Actor != nil {
+			c.Set("actorID", claims.Actor.UserID)
+			c.Set("actorName", claims.Actor.Username)
+			if l := logger.With("actor_id", claims.Actor.UserID, "user_id", claims.UserID); l != nil {
+				l.Infof("Impersonated request: %s %s", c.Request.Method, c.Request.URL.Path)
+			}
+		}
+
+		// Rows changed by the request record the real actor as their author
+		actorID := claims.UserID
+		if claims.Actor != nil {
+			actorID = claims.Actor.UserID
+		}
+		c.Request = c.Request.WithContext(actor.NewContext(c.Request.Context(), actorID))
 
 		c.Next()
 	}
@@ -82,3 +119,17 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// DenyImpersonation rejects requests made with an impersonation token,
+// guarding sensitive operations such as password changes
+func DenyImpersonation() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetInt("actorID") != 0 {
+			response.Err(c, errcode.AuthImpersonation)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -1,7 +1,9 @@
 package middleware
 
 import (
+	"go-template/internal/database"
 	"go-template/pkg/logger"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +26,65 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
+// PrimaryForWrites sends the queries of requests that may write to the
+// primary database, so they read their own writes and update current rows.
+// Queries of safe requests may go to read replicas.
+func PrimaryForWrites() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+		default:
+			c.Request = c.Request.WithContext(database.WithPrimary(c.Request.Context()))
+		}
+		c.Next()
+	}
+}
+
+// QueryStats counts the database queries of each request, warning about
+// statements repeated as in N+1 queries. With header set, the statistics are
+// reported in the X-Query-Stats response header, for debugging; queries made
+// after the response is written are only counted in the request log.
+func QueryStats(header bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, stats := database.WithStats(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+		c.Set("QueryStats", stats)
+		if header {
+			c.Writer = &statsWriter{ResponseWriter: c.Writer, stats: stats}
+		}
+		c.Next()
+	}
+}
+
+// statsWriter sets the X-Query-Stats header before the response is written
+type statsWriter struct {
+	gin.ResponseWriter
+	stats   *database.Stats
+	written bool
+}
+
+func (w *statsWriter) setHeader() {
+	if !w.written && !w.ResponseWriter.Written() {
+		w.Header().Set("X-Query-Stats", w.stats.String())
+	}
+	w.written = true
+}
+
+func (w *statsWriter) WriteHeaderNow() {
+	w.setHeader()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *statsWriter) Write(data []byte) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *statsWriter) WriteString(s string) (int, error) {
+	w.setHeader()
+	return w.ResponseWriter.WriteString(s)
+}
+
 // RequestLog logs requests using the structured logger
 func RequestLog() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -57,6 +118,10 @@ func RequestLog() gin.HandlerFunc {
 			"bytes", c.Writer.Size(),
 		)
 
+		if stats, ok := c.Get("QueryStats"); ok {
+			logger = logger.With("queries", stats.(*database.Stats).String())
+		}
+
 		if len(c.Errors) > 0 {
 			// Append errors if any
 			logger.With("errors", c.Errors.String()).Error("Request processing failed")
@@ -0,0 +1,253 @@
+// Package patch applies RFC 7396 JSON Merge Patch and RFC 6902 JSON Patch
+// documents to the flat set of updatable fields of an entity. The result is
+// the list of changed fields, which handlers apply in a single ent mutation.
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strings"
+)
+
+// Media types of the supported patch formats
+const (
+	MergePatchType = "application/merge-patch+json"
+	JSONPatchType  = "application/json-patch+json"
+)
+
+var (
+	ErrInvalid    = errors.New("invalid patch")
+	ErrMediaType  = errors.New("unsupported patch media type")
+	ErrTestFailed = errors.New("patch test operation failed")
+)
+
+// Field describes an updatable field of an entity
+type Field struct {
+	Nullable  bool // An explicit null or a remove operation clears the field
+	WriteOnly bool // The field cannot be read, so it cannot be tested or copied from
+}
+
+// Fields maps the JSON names of the updatable fields to their description
+type Fields map[string]Field
+
+// Document holds the current values of the readable updatable fields,
+// keyed by JSON name. It is what JSON Patch operations are evaluated against.
+type Document map[string]interface{}
+
+// Patch is the set of fields changed by a patch document
+type Patch struct {
+	values map[string]json.RawMessage
+}
+
+// Parse applies the patch document in body, in the format given by the
+// content type, to the document. Plain application/json is treated as a
+// merge patch.
+func Parse(contentType string, body []byte, fields Fields, doc Document) (*Patch, error) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrMediaType
+	}
+
+	current, err := normalize(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mediaType {
+	case MergePatchType, "application/json":
+		return mergePatch(body, fields)
+	case JSONPatchType:
+		return jsonPatch(body, fields, current)
+	}
+	return nil, ErrMediaType
+}
+
+// Empty reports whether the patch changes no field
+func (p *Patch) Empty() bool {
+	return len(p.values) == 0
+}
+
+// Has reports whether the patch changes the field
+func (p *Patch) Has(name string) bool {
+	_, ok := p.values[name]
+	return ok
+}
+
+// IsNull reports whether the patch clears the field
+func (p *Patch) IsNull(name string) bool {
+	v, ok := p.values[name]
+	return ok && isNull(v)
+}
+
+// Decode unmarshals the non-null changed values into v, a pointer to a
+// struct whose fields are pointers tagged with the JSON names
+func (p *Patch) Decode(v interface{}) error {
+	values := make(map[string]json.RawMessage, len(p.values))
+	for name, raw := range p.values {
+		if !isNull(raw) {
+			values[name] = raw
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return nil
+}
+
+// mergePatch applies an RFC 7396 merge patch. Nested objects are not
+// supported, as all updatable fields are scalars.
+func mergePatch(body []byte, fields Fields) (*Patch, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(body, &values); err != nil || values == nil {
+		return nil, fmt.Errorf("%w: a merge patch must be a JSON object", ErrInvalid)
+	}
+
+	for name, raw := range values {
+		if err := checkWrite(fields, name, raw); err != nil {
+			return nil, err
+		}
+	}
+	return &Patch{values: values}, nil
+}
+
+// operation is an RFC 6902 JSON Patch operation
+type operation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"` // Holds "null" for an explicit null, nil when absent
+}
+
+// jsonPatch applies an RFC 6902 JSON Patch. Operations run in order against
+// the document, so a test sees the changes of the operations before it.
+func jsonPatch(body []byte, fields Fields, doc map[string]json.RawMessage) (*Patch, error) {
+	var ops []operation
+	if err := json.Unmarshal(body, &ops); err != nil {
+		return nil, fmt.Errorf("%w: a JSON patch must be an array of operations", ErrInvalid)
+	}
+
+	values := make(map[string]json.RawMessage)
+	set := func(name string, raw json.RawMessage) error {
+		if err := checkWrite(fields, name, raw); err != nil {
+			return err
+		}
+		values[name] = raw
+		if !fields[name].WriteOnly {
+			doc[name] = raw
+		}
+		return nil
+	}
+
+	for i, op := range ops {
+		name, err := field(op.Path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalid, i, err)
+		}
+
+		switch op.Op {
+		case "add", "replace":
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d: missing value", ErrInvalid, i)
+			}
+			err = set(name, op.Value)
+		case "remove":
+			err = set(name, json.RawMessage("null"))
+		case "copy", "move":
+			from, ferr := field(op.From)
+			if ferr != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalid, i, ferr)
+			}
+			raw, ok := doc[from]
+			if !ok {
+				return nil, fmt.Errorf("%w: operation %d: cannot read %q", ErrInvalid, i, from)
+			}
+			if err = set(name, raw); err == nil && op.Op == "move" && from != name {
+				err = set(from, json.RawMessage("null"))
+			}
+		case "test":
+			raw, ok := doc[name]
+			if !ok {
+				return nil, fmt.Errorf("%w: operation %d: cannot read %q", ErrInvalid, i, name)
+			}
+			if op.Value == nil {
+				return nil, fmt.Errorf("%w: operation %d: missing value", ErrInvalid, i)
+			}
+			equal, terr := jsonEqual(raw, op.Value)
+			if terr != nil {
+				return nil, fmt.Errorf("%w: operation %d: %v", ErrInvalid, i, terr)
+			}
+			if !equal {
+				return nil, fmt.Errorf("%w: %q does not match", ErrTestFailed, name)
+			}
+		default:
+			return nil, fmt.Errorf("%w: operation %d: unknown op %q", ErrInvalid, i, op.Op)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Patch{values: values}, nil
+}
+
+// field returns the field named by a JSON pointer to a top-level member
+func field(pointer string) (string, error) {
+	if !strings.HasPrefix(pointer, "/") || strings.Count(pointer, "/") != 1 {
+		return "", fmt.Errorf("path %q must name a top-level field", pointer)
+	}
+	name := strings.NewReplacer("~1", "/", "~0", "~").Replace(pointer[1:])
+	return name, nil
+}
+
+// checkWrite checks that the field may be set to the value
+func checkWrite(fields Fields, name string, raw json.RawMessage) error {
+	f, ok := fields[name]
+	if !ok {
+		return fmt.Errorf("%w: field %q cannot be updated", ErrInvalid, name)
+	}
+	if isNull(raw) && !f.Nullable {
+		return fmt.Errorf("%w: field %q cannot be cleared", ErrInvalid, name)
+	}
+	return nil
+}
+
+// normalize converts the document to raw JSON values
+func normalize(doc Document) (map[string]json.RawMessage, error) {
+	out := make(map[string]json.RawMessage, len(doc))
+	for name, v := range doc {
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding field %q: %w", name, err)
+		}
+		out[name] = raw
+	}
+	return out, nil
+}
+
+// jsonEqual reports whether two JSON values are equal
+func jsonEqual(a, b json.RawMessage) (bool, error) {
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(va, vb), nil
+}
+
+// isNull reports whether the raw value is JSON null
+func isNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
+}
@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Access is the level of access a request has to a resource
+type Access int
+
+const (
+	// AccessNone denies the resource entirely
+	AccessNone Access = iota
+	// AccessPublic allows the reduced public projection of the resource
+	AccessPublic
+	// AccessSelf allows the owner's view of their own resource
+	AccessSelf
+	// AccessFull allows every field, including administrative ones
+	AccessFull
+)
+
+// ruleKey is the context key of the route's declared rule
+const ruleKey = "policyRule"
+
+// Subject is the authenticated user a policy is evaluated for
+type Subject struct {
+	UserID int
+	Role   string
+}
+
+// IsAdmin reports whether the subject has the admin role
+func (s Subject) IsAdmin() bool {
+	return s.Role == "admin"
+}
+
+// Rule decides the access a subject has to a resource owned by ownerID
+type Rule func(s Subject, ownerID int) Access
+
+// AdminOnly grants full access to admins and nothing to anyone else
+func AdminOnly(s Subject, ownerID int) Access {
+	if s.IsAdmin() {
+		return AccessFull
+	}
+	return AccessNone
+}
+
+// AdminOrSelf grants full access to admins and self access to the owner
+func AdminOrSelf(s Subject, ownerID int) Access {
+	switch {
+	case s.IsAdmin():
+		return AccessFull
+	case s.UserID != 0 && s.UserID == ownerID:
+		return AccessSelf
+	}
+	return AccessNone
+}
+
+// AdminSelfOrPublic is AdminOrSelf, additionally granting the public
+// projection to any other authenticated user
+func AdminSelfOrPublic(s Subject, ownerID int) Access {
+	if access := AdminOrSelf(s, ownerID); access != AccessNone {
+		return access
+	}
+	if s.UserID != 0 {
+		return AccessPublic
+	}
+	return AccessNone
+}
+
+// SubjectFrom returns the subject set by the JWT middleware
+func SubjectFrom(c *gin.Context) Subject {
+	return Subject{
+		UserID: c.GetInt("userID"),
+		Role:   c.GetString("userRole"),
+	}
+}
+
+// Declare stores the route's rule on the context
+func Declare(c *gin.Context, rule Rule) {
+	c.Set(ruleKey, rule)
+}
+
+// Check evaluates the route's declared rule for a resource owned by ownerID.
+// Routes without a declared rule are denied.
+func Check(c *gin.Context, ownerID int) Access {
+	v, ok := c.Get(ruleKey)
+	if !ok {
+		return AccessNone
+	}
+	rule, ok := v.(Rule)
+	if !ok {
+		return AccessNone
+	}
+	return rule(SubjectFrom(c), ownerID)
+}
@@ -65,6 +65,23 @@ func Err(c *gin.Context, code string, customMsg ...string) {
 	})
 }
 
+// ErrWithStatus sends an error response with a specific HTTP status, for
+// errors that clients and caches act on by status, such as failed preconditions
+// Optional custom message can be provided to override the default message
+func ErrWithStatus(c *gin.Context, status int, code string, customMsg ...string) {
+	message := errcode.GetMessage(code)
+	if len(customMsg) > 0 && customMsg[0] != "" {
+		message = customMsg[0]
+	}
+
+	c.JSON(status, Response{
+		Code:      code,
+		Message:   message,
+		Timestamp: time.Now().UnixMilli(),
+		RequestID: getRequestID(c),
+	})
+}
+
 // ErrWithData sends an error response with additional data
 // Optional custom message can be provided to override the default message
 func ErrWithData(c *gin.Context, code string, data interface{}, customMsg ...string) {
@@ -3,8 +3,16 @@ package router
 import (
 	"go-template/internal/api/handler"
 	"go-template/internal/api/middleware"
+	"go-template/internal/api/policy"
+	"go-template/internal/audit"
 	"go-template/internal/config"
 	"go-template/internal/database"
+	"go-template/internal/invitation"
+	"go-template/internal/privacy"
+	"go-template/internal/report"
+	"go-template/internal/session"
+	"go-template/internal/sqlconsole"
+	"go-template/pkg/mailer"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -17,43 +25,70 @@ import (
 func SetupRoutes(r *gin.Engine, db *database.Client, cfg *config.Config) {
 	r.Use(middleware.CORS())
 	r.Use(middleware.RequestID())
+	r.Use(middleware.PrimaryForWrites())
+	r.Use(middleware.QueryStats(cfg.Database.QueryStatsHeader))
 
 	// Swagger routes
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	// Health check for load balancers and orchestrators
+	r.GET("/health", handler.NewHealthHandler(db).Check)
+
+	sessions := session.NewStore(db, cfg.Session)
+	auditor := audit.NewRecorder(db)
+	invitations := invitation.NewSender(cfg.Invitation, cfg.JWT, mailer.Default())
+	privacyService := privacy.NewService(db, cfg.Privacy, cfg.JWT)
+
 	// API v1 routes
 	v1 := r.Group("/api/v1")
 	{
 		v1.GET("/", handler.Welcome)
 		// auth routes
-		authHandler := handler.NewAuthHandler(db, cfg.JWT)
+		authHandler := handler.NewAuthHandler(db, cfg.JWT, sessions, auditor)
 		auth := v1.Group("/auth")
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/refresh", authHandler.RefreshToken)
+			auth.POST("/invitations/accept", authHandler.AcceptInvitation)
+
+			privacyHandler := handler.NewPrivacyHandler(db, privacyService, sessions, auditor)
+			auth.GET("/exports/download", privacyHandler.DownloadExport)
 
 			authRequired := auth.Group("")
-			authRequired.Use(middleware.JWTAuthMiddleware(cfg.JWT))
+			authRequired.Use(middleware.JWTAuthMiddleware(cfg.JWT, sessions))
 			{
 				authRequired.GET("/me", authHandler.GetUserInfo)
+				authRequired.PATCH("/me", middleware.DenyImpersonation(), authHandler.UpdateProfile)
+				authRequired.POST("/me/password", middleware.DenyImpersonation(), authHandler.ChangePassword)
+				authRequired.DELETE("/me", middleware.DenyImpersonation(), authHandler.DeleteAccount)
+				authRequired.POST("/me/export", middleware.DenyImpersonation(), privacyHandler.RequestExport)
+				authRequired.GET("/me/exports/:id", privacyHandler.GetExport)
+				authRequired.POST("/me/erase", middleware.DenyImpersonation(), privacyHandler.EraseAccount)
+				authRequired.GET("/sessions", authHandler.ListSessions)
+				authRequired.DELETE("/sessions/:id", middleware.DenyImpersonation(), authHandler.RevokeSession)
 			}
 		}
 
 		protected := v1.Group("")
-		protected.Use(middleware.JWTAuthMiddleware(cfg.JWT))
+		protected.Use(middleware.JWTAuthMiddleware(cfg.JWT, sessions), middleware.Tenant(db))
 		// User routes
-		userHandler := handler.NewUserHandler(db)
+		userHandler := handler.NewUserHandler(db, sessions)
 		users := protected.Group("/users")
 		{
-			users.GET("", userHandler.List)
-			users.GET("/:id", userHandler.Get)
+			users.GET("", middleware.Authorize(policy.AdminSelfOrPublic, ""), userHandler.List)
+			users.GET("/:id", middleware.Authorize(policy.AdminSelfOrPublic, "id"), userHandler.Get)
 			adminOnly := users.Group("")
-			adminOnly.Use(middleware.RequireRole("admin"))
+			adminOnly.Use(middleware.RequireRole("admin"), middleware.DenyImpersonation())
 			{
 				adminOnly.POST("", userHandler.Create)
+				adminOnly.POST("/import", userHandler.Import)
+				adminOnly.GET("/export", userHandler.Export)
+				adminOnly.GET("/search", userHandler.Search)
 				adminOnly.PUT("/:id", userHandler.Update)
+				adminOnly.PATCH("/:id", userHandler.Patch)
 				adminOnly.DELETE("/:id", userHandler.Delete)
+				adminOnly.POST("/:id/restore", userHandler.Restore)
 			}
 		}
 
@@ -66,11 +101,73 @@ func SetupRoutes(r *gin.Engine, db *database.Client, cfg *config.Config) {
 			roles.GET("/:id", roleHandler.Get)
 			roles.POST("", roleHandler.Create)
 			roles.PUT("/:id", roleHandler.Update)
+			roles.PATCH("/:id", roleHandler.Patch)
 			roles.DELETE("/:id", roleHandler.Delete)
+			roles.POST("/:id/restore", roleHandler.Restore)
 			roles.GET("/:id/users", roleHandler.GetUsers) // Get users with this role
 		}
 
-		// Raw Query routes
-		setupRawQueryRoutes(v1, db)
+		// Organization routes
+		orgHandler := handler.NewOrganizationHandler(db)
+		orgs := protected.Group("/orgs")
+		{
+			orgs.GET("", orgHandler.List)
+			orgs.POST("", orgHandler.Create)
+			orgs.GET("/current/members", middleware.RequireOrg(), orgHandler.ListMembers)
+			orgAdmin := orgs.Group("/current/members")
+			orgAdmin.Use(middleware.RequireOrgRole("admin"))
+			{
+				orgAdmin.DELETE("/:user_id", orgHandler.RemoveMember)
+			}
+		}
+
+		// Team routes
+		teamHandler := handler.NewTeamHandler(db)
+		teams := protected.Group("/orgs/current/teams")
+		{
+			teams.GET("", middleware.RequireOrg(), teamHandler.List)
+			teams.GET("/:id/members", middleware.RequireOrg(), teamHandler.ListMembers)
+			teamAdmin := teams.Group("")
+			teamAdmin.Use(middleware.RequireOrgRole("admin"))
+			{
+				teamAdmin.POST("", teamHandler.Create)
+				teamAdmin.DELETE("/:id", teamHandler.Delete)
+				teamAdmin.PUT("/:id/roles", teamHandler.SetRoles)
+				teamAdmin.POST("/:id/members", teamHandler.AddMember)
+				teamAdmin.DELETE("/:id/members/:user_id", teamHandler.RemoveMember)
+			}
+		}
+
+		// Invitation routes
+		invitationHandler := handler.NewInvitationHandler(db, invitations)
+		invites := protected.Group("/orgs/current/invitations")
+		invites.Use(middleware.RequireOrgRole("admin"))
+		{
+			invites.GET("", invitationHandler.List)
+			invites.POST("", invitationHandler.Create)
+			invites.POST("/:id/resend", invitationHandler.Resend)
+			invites.DELETE("/:id", invitationHandler.Revoke)
+		}
+
+		// Admin routes
+		adminHandler := handler.NewAdminHandler(db, cfg.JWT, auditor)
+		sqlConsoleHandler := handler.NewSQLConsoleHandler(sqlconsole.NewService(db, cfg.SQLConsole))
+		admin := protected.Group("/admin")
+		admin.Use(middleware.RequireRole("admin"), middleware.DenyImpersonation())
+		{
+			admin.POST("/users/:id/impersonate", adminHandler.Impersonate)
+			admin.POST("/sql", sqlConsoleHandler.Run)
+			admin.GET("/sql/history", sqlConsoleHandler.History)
+		}
+
+		// Report routes
+		reportHandler := handler.NewReportHandler(report.NewService(db, auditor, cfg.Report))
+		reports := protected.Group("/reports")
+		reports.Use(middleware.RequireRole("admin"))
+		{
+			reports.GET("/user-stats", reportHandler.GetUserStats)
+			reports.GET("/role-user-counts", reportHandler.GetRoleUserCounts)
+			reports.POST("/role-users/activate", middleware.DenyImpersonation(), reportHandler.ActivateRoleUsers)
+		}
 	}
 }
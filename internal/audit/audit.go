@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"go-template/internal/database"
+	"go-template/pkg/logger"
+)
+
+// Audited actions
+const (
+	ActionImpersonate     = "user.impersonate"
+	ActionPasswordChange  = "user.password.change"
+	ActionAccountDeletion = "user.self_delete"
+	ActionDataExport      = "user.data_export"
+	ActionAccountErasure  = "user.erase"
+
+	ActionRoleUsersActivate = "role.users.activate"
+)
+
+// Entry describes an audited action
+type Entry struct {
+	ActorID    int                    // User performing the action, zero for the system
+	Action     string                 // Action identifier, e.g. "user.impersonate"
+	TargetType string                 // Type of the affected resource, e.g. "user"
+	TargetID   string                 // ID of the affected resource
+	IP         string                 // Client IP address
+	UserAgent  string                 // Client user agent
+	RequestID  string                 // Request ID for correlation with request logs
+	Metadata   map[string]interface{} // Additional action details
+}
+
+// Recorder writes audit entries to the database and the application log
+type Recorder struct {
+	db *database.Client
+}
+
+// NewRecorder creates a new audit recorder
+func NewRecorder(db *database.Client) *Recorder {
+	return &Recorder{db: db}
+}
+
+// Record persists an audit entry. Callers performing security-sensitive
+// actions should fail the action when recording fails.
+func (r *Recorder) Record(ctx context.Context, e Entry) error {
+	if l := logger.With(
+		"audit", e.Action,
+		"actor_id", e.ActorID,
+		"target_type", e.TargetType,
+		"target_id", e.TargetID,
+		"ip", e.IP,
+		"request_id", e.RequestID,
+	); l != nil {
+		l.Info("Audit event")
+	}
+
+	err := r.db.Ent.AuditLog.Create().
+		SetActorID(e.ActorID).
+		SetAction(e.Action).
+		SetTargetType(e.TargetType).
+		SetTargetID(e.TargetID).
+		SetIP(e.IP).
+		SetUserAgent(e.UserAgent).
+		SetRequestID(e.RequestID).
+		SetMetadata(e.Metadata).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("recording audit entry: %w", err)
+	}
+	return nil
+}
@@ -3,8 +3,18 @@ package config
 import (
 	"fmt"
 	"go-template/internal/database"
+	"go-template/internal/invitation"
+	"go-template/internal/privacy"
+	"go-template/internal/purge"
+	"go-template/internal/report"
+	"go-template/internal/session"
+	"go-template/internal/sqlconsole"
 	"go-template/pkg/auth"
+	"go-template/pkg/fieldcrypt"
 	"go-template/pkg/logger"
+	"go-template/pkg/mailer"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
 	"os"
 	"path/filepath"
 
@@ -13,10 +23,20 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig    `mapstructure:"server"`
-	Log      logger.Config   `mapstructure:"log"`
-	Database database.Config `mapstructure:"database"`
-	JWT      auth.JWTConfig  `mapstructure:"jwt"`
+	Server     ServerConfig      `mapstructure:"server"`
+	Log        logger.Config     `mapstructure:"log"`
+	Database   database.Config   `mapstructure:"database"`
+	JWT        auth.JWTConfig    `mapstructure:"jwt"`
+	Password   password.Config   `mapstructure:"password"`
+	Session    session.Config    `mapstructure:"session"`
+	Mail       mailer.Config     `mapstructure:"mail"`
+	Invitation invitation.Config `mapstructure:"invitation"`
+	Purge      purge.Config      `mapstructure:"purge"`
+	PublicID   publicid.Config   `mapstructure:"public_id"`
+	Privacy    privacy.Config    `mapstructure:"privacy"`
+	Encryption fieldcrypt.Config `mapstructure:"encryption"`
+	Report     report.Config     `mapstructure:"report"`
+	SQLConsole sqlconsole.Config `mapstructure:"sql_console"`
 }
 
 type ServerConfig struct {
@@ -72,6 +92,19 @@ func Load(cfgFile string) (*Config, error) {
 	v.SetDefault("server.write_timeout", 10)
 	v.SetDefault("server.shutdown_timeout", 5)
 
+	// database defaults
+	v.SetDefault("database.slow_query_threshold", "200ms")
+	v.SetDefault("database.n_plus_one_threshold", 10)
+	v.SetDefault("database.max_open_conns", 25)
+	v.SetDefault("database.max_idle_conns", 10)
+	v.SetDefault("database.conn_max_lifetime", "30m")
+	v.SetDefault("database.conn_max_idle_time", "5m")
+	v.SetDefault("database.connect_retries", 5)
+	v.SetDefault("database.connect_backoff", "1s")
+	v.SetDefault("database.statement_timeout", "30s")
+	v.SetDefault("database.tx_retries", 3)
+	v.SetDefault("database.replica_check_interval", "5s")
+
 	// Log defaults
 	v.SetDefault("log.level", "info")
 	v.SetDefault("log.output", "console")
@@ -86,6 +119,52 @@ func Load(cfgFile string) (*Config, error) {
 	v.SetDefault("jwt.issuer", "go-template-api")
 	v.SetDefault("jwt.expiration", "24h")
 	v.SetDefault("jwt.refresh_expire", "168h")
+	v.SetDefault("jwt.impersonation_expire", "15m")
+
+	// password defaults
+	v.SetDefault("password.algorithm", "argon2id")
+	v.SetDefault("password.argon2.memory", 65536)
+	v.SetDefault("password.argon2.iterations", 3)
+	v.SetDefault("password.argon2.parallelism", 2)
+	v.SetDefault("password.argon2.salt_length", 16)
+	v.SetDefault("password.argon2.key_length", 32)
+	v.SetDefault("password.bcrypt_cost", 10)
+	v.SetDefault("password.policy.min_length", 8)
+	v.SetDefault("password.policy.max_length", 128)
+
+	// session defaults
+	v.SetDefault("session.cache_ttl", "30s")
+	v.SetDefault("session.touch_interval", "5m")
+
+	// mail defaults
+	v.SetDefault("mail.driver", "log")
+	v.SetDefault("mail.from", "noreply@example.com")
+	v.SetDefault("mail.port", 587)
+
+	// invitation defaults
+	v.SetDefault("invitation.expire", "72h")
+	v.SetDefault("invitation.accept_url", "http://localhost:8080/invitations/accept")
+
+	// purge defaults
+	v.SetDefault("purge.retention", "720h")
+
+	// public id defaults
+	v.SetDefault("public_id.key", "go-template-public-id")
+
+	// privacy defaults
+	v.SetDefault("privacy.export_dir", "data/exports")
+	v.SetDefault("privacy.export_ttl", "72h")
+	v.SetDefault("privacy.download_url", "http://localhost:8080/api/v1/auth/exports/download")
+
+	// report defaults
+	v.SetDefault("report.timeout", "10s")
+
+	// SQL console defaults
+	v.SetDefault("sql_console.max_rows", 1000)
+	v.SetDefault("sql_console.timeout", "10s")
+
+	// encryption defaults
+	v.SetDefault("encryption.keyring_file", "")
 
 	// Read config
 	if err := v.ReadInConfig(); err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"go-template/ent"
 	"go-template/ent/migrate"
+	_ "go-template/ent/runtime"
 	"go-template/pkg/logger"
 	"os"
 	"strings"
@@ -14,63 +15,95 @@ import (
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/schema"
-	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
 type Config struct {
-	Driver string `mapstructure:"driver"` // Database driver type
+	Driver string `mapstructure:"driver"` // Database driver: postgres, mysql or sqlite3
 	DSN    string `mapstructure:"dsn"`    // Database connection string
 	Debug  bool   `mapstructure:"debug"`  // Enable SQL statement logging
+
+	QueriesDir string `mapstructure:"queries_dir"` // In debug mode, named queries are loaded from this directory and reloaded on change
+
+	SlowQueryThreshold time.Duration `mapstructure:"slow_query_threshold"` // Statements taking longer are logged, 0 disables it
+	NPlusOneThreshold  int           `mapstructure:"n_plus_one_threshold"` // Warn when a statement runs more often in one request, 0 disables it
+	QueryStatsHeader   bool          `mapstructure:"query_stats_header"`   // Report the query statistics of requests in the X-Query-Stats header
+
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`     // Maximum open connections, 0 for unlimited
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`     // Maximum idle connections kept in the pool
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`  // Connections are closed after this long, 0 keeps them
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"` // Idle connections are closed after this long, 0 keeps them
+
+	ConnectRetries   int           `mapstructure:"connect_retries"`   // Retries of the first connection before giving up at startup
+	ConnectBackoff   time.Duration `mapstructure:"connect_backoff"`   // Wait before the first connection retry, doubled after each
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"` // Timeout of statements run without a deadline, 0 for none
+	TxRetries        int           `mapstructure:"tx_retries"`        // Retries of transactions failing with transient errors
+
+	Replicas             []string      `mapstructure:"replicas"`               // DSNs of read replicas; reads go to the primary without any
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"` // Interval of replica health checks, 0 disables them
 }
 
+// maxConnectBackoff caps the wait between connection retries at startup
+const maxConnectBackoff = 30 * time.Second
+
 // Client represents the database client
 type Client struct {
-	Ent *ent.Client
-	db  *sql.DB
+	Ent       *ent.Client
+	db        *sql.DB
+	dialect   string
+	fullText  bool          // Set when the user search objects exist
+	timeout   time.Duration // Default statement timeout
+	txRetries int
+	replicas  *replicaSet
+	observer  *observer
+	queries   *queryRegistry
 }
 
 // New creates a new database client
 func New(cfg *Config) (*Client, error) {
-	RegisterPgxDriver()
-	logger.Info("Connecting to database...")
+	drv, err := lookupDriver(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("Connecting to %s database...", drv.dialect)
+
+	queries, err := loadQueries(drv.dialect, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading named queries: %w", err)
+	}
 
 	// Connect to the database using the specified driver and DSN
-	db, err := sql.Open(cfg.Driver, cfg.DSN)
+	db, err := sql.Open(drv.name, cfg.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("failed opening connection to database: %w", err)
 	}
+	configurePool(db, cfg)
 
-	// options for the client
-	var opts []ent.Option
-	if cfg.Debug {
-		opts = append(opts, ent.Debug())
-		opts = append(opts, ent.Log(func(a ...any) {
-			message := fmt.Sprint(a...)
-			// filter initialization queries
-			if strings.Contains(message, "INFORMATION_SCHEMA") ||
-				strings.Contains(message, "pg_catalog") ||
-				strings.Contains(message, "server_version_num") ||
-				strings.Contains(message, "pg_namespace") ||
-				strings.Contains(message, "pg_constraint") ||
-				strings.Contains(message, "pg_class") {
-				return
-			}
-			logger.Info(message)
-		}))
+	if err := connect(db, cfg); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed connecting to database: %w", err)
+	}
+
+	replicas, err := openReplicas(drv, cfg)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
 	}
-	// Create an ent.Driver from `db`
-	drv := entsql.OpenDB(dialect.Postgres, db)
-	opts = append(opts, ent.Driver(drv))
 
-	client := ent.NewClient(opts...)
+	// Create an ent.Driver from `db`
+	primary := withTimeout(entsql.OpenDB(drv.dialect, db), cfg.StatementTimeout)
+	o := &observer{slow: cfg.SlowQueryThreshold, nPlusOne: cfg.NPlusOneThreshold, logAll: cfg.Debug}
+	client := ent.NewClient(ent.Driver(&observingDriver{
+		Driver: &routingDriver{Driver: primary, replicas: replicas},
+		o:      o,
+	}))
 
-	// Test the connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// Migration statements are not observed
+	ctx, cancel := context.WithTimeout(quiet(context.Background()), 5*time.Second)
 	defer cancel()
 
 	unsafeMigrate := strings.ToLower(os.Getenv("DB_UNSAFE_MIGRATE")) == "true"
 	// Configure migration options
-	migrateOpts := []schema.MigrateOption{migrate.WithForeignKeys(false)}
+	migrateOpts := []schema.MigrateOption{migrate.WithForeignKeys(false), schema.WithDiffHook(keepSearch, keepSharedRoles)}
 
 	if unsafeMigrate {
 		logger.Warn("UNSAFE DATABASE MIGRATE ENABLED - This should only be used in development")
@@ -79,17 +112,77 @@ func New(cfg *Config) (*Client, error) {
 	if err := client.Schema.Create(ctx,
 		migrateOpts...,
 	); err != nil {
+		_ = replicas.close()
+		_ = db.Close()
 		return nil, fmt.Errorf("failed creating schema resources: %w", err)
 	}
 
+	// Existing duplicate shared roles must be removed before the index applies
+	if err := migrateSharedRoles(ctx, db, drv.dialect); err != nil {
+		logger.Warnf("Shared role names are not unique: %v", err)
+	}
+
+	// Full-text search objects only exist on PostgreSQL
+	fullText := drv.dialect == dialect.Postgres
+	if fullText {
+		if err := migrateSearch(ctx, db); err != nil {
+			logger.Warnf("User search falls back to ILIKE: %v", err)
+			fullText = false
+		}
+	}
+
 	logger.Info("Database connection established")
-	return &Client{Ent: client, db: db}, nil
+	return &Client{
+		Ent:       client,
+		db:        db,
+		dialect:   drv.dialect,
+		fullText:  fullText,
+		timeout:   cfg.StatementTimeout,
+		txRetries: cfg.TxRetries,
+		replicas:  replicas,
+		observer:  o,
+		queries:   queries,
+	}, nil
+}
+
+// configurePool applies the pool settings of the configuration
+func configurePool(db *sql.DB, cfg *Config) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// connect waits for the database to accept connections, retrying with
+// exponential backoff so the service can start before the database is up
+func connect(db *sql.DB, cfg *Config) error {
+	backoff := cfg.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		err := db.PingContext(ctx)
+		cancel()
+		if err == nil || attempt >= cfg.ConnectRetries {
+			return err
+		}
+
+		logger.Warnf("Database is not reachable, retrying in %s (%d/%d): %v", backoff, attempt+1, cfg.ConnectRetries, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// Ping checks that the database is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
 }
 
 // Close closes the database connection
 func (c *Client) Close() error {
 	if c.Ent != nil {
 		logger.Info("Closing database connection")
+		if err := c.replicas.close(); err != nil {
+			logger.Errorf("Error closing replica connections: %v", err)
+		}
 		return c.Ent.Close()
 	}
 	return nil
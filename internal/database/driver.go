@@ -1,22 +1,69 @@
 package database
 
 import (
-	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
 
-	"go-template/pkg/logger"
-
-	"github.com/jackc/pgx/v5/stdlib"
+	"entgo.io/ent/dialect"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "github.com/mattn/go-sqlite3"
 )
 
-// RegisterPgxDriver registers the pgx driver as "postgres" so Ent can use it.
-func RegisterPgxDriver() {
-	driverName := "postgres"
+// sqlDriver is a database/sql driver and the ent dialect of its databases
+type sqlDriver struct {
+	name    string // Name the driver is registered under
+	dialect string
+}
+
+// drivers maps the supported database.driver values to their drivers
+var drivers = map[string]sqlDriver{
+	"postgres": {name: "pgx", dialect: dialect.Postgres},
+	"pgx":      {name: "pgx", dialect: dialect.Postgres},
+	"mysql":    {name: "mysql", dialect: dialect.MySQL},
+	"sqlite3":  {name: "sqlite3", dialect: dialect.SQLite},
+	"sqlite":   {name: "sqlite3", dialect: dialect.SQLite},
+}
 
-	// Create pgx driver
-	pgxDriver := stdlib.GetDefaultDriver()
+// lookupDriver returns the driver configured by database.driver
+func lookupDriver(name string) (sqlDriver, error) {
+	drv, ok := drivers[strings.ToLower(name)]
+	if !ok {
+		return sqlDriver{}, fmt.Errorf("unsupported database driver %q, use postgres, mysql or sqlite3", name)
+	}
+	return drv, nil
+}
+
+// Dialect returns the ent dialect of the database: dialect.Postgres,
+// dialect.MySQL or dialect.SQLite
+func (c *Client) Dialect() string {
+	return c.dialect
+}
 
-	// Register the driver
-	sql.Register(driverName, pgxDriver)
+// Rebind rewrites the "?" placeholders of a raw query into the placeholders
+// of the database, e.g. "$1" on PostgreSQL. Raw queries written with "?"
+// run on every supported database. Question marks in quoted strings are
+// left alone.
+func (c *Client) Rebind(query string) string {
+	if c.dialect != dialect.Postgres {
+		return query
+	}
 
-	logger.Info("Registered pgx driver as 'postgres'")
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n, quoted := 0, false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
 }
@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"go-template/pkg/logger"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// quietKey is the context key of statements that are not observed
+type quietKey struct{}
+
+// quiet returns a context whose statements are neither logged nor counted,
+// for migrations and their catalog queries
+func quiet(ctx context.Context) context.Context {
+	return context.WithValue(ctx, quietKey{}, true)
+}
+
+// isQuiet reports whether the statements of the context are not observed
+func isQuiet(ctx context.Context) bool {
+	q, _ := ctx.Value(quietKey{}).(bool)
+	return q
+}
+
+// statsKey is the context key of the query statistics of a request
+type statsKey struct{}
+
+// Stats counts the queries of a request
+type Stats struct {
+	mu       sync.Mutex
+	count    int
+	duration time.Duration
+	slow     int
+	repeats  map[string]int // Runs by statement
+	repeated int            // Statements run more often than the N+1 threshold
+}
+
+// WithStats returns a context counting the queries made with it
+func WithStats(ctx context.Context) (context.Context, *Stats) {
+	stats := &Stats{repeats: make(map[string]int)}
+	return context.WithValue(ctx, statsKey{}, stats), stats
+}
+
+// statsFromContext returns the query statistics of the context, if any
+func statsFromContext(ctx context.Context) *Stats {
+	stats, _ := ctx.Value(statsKey{}).(*Stats)
+	return stats
+}
+
+// add counts a query, reporting whether the statement just ran more often
+// than the N+1 threshold
+func (s *Stats) add(query string, elapsed time.Duration, slow bool, nPlusOne int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.count++
+	s.duration += elapsed
+	if slow {
+		s.slow++
+	}
+	s.repeats[query]++
+	if nPlusOne > 0 && s.repeats[query] == nPlusOne+1 {
+		s.repeated++
+		return true
+	}
+	return false
+}
+
+// String formats the statistics, e.g. "queries=12 duration=35.2ms slow=1 repeated=1"
+func (s *Stats) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fmt.Sprintf("queries=%d duration=%s slow=%d repeated=%d",
+		s.count, s.duration.Round(100*time.Microsecond), s.slow, s.repeated)
+}
+
+// observer logs slow statements, or all of them in debug mode, and counts
+// them in the statistics of their request
+type observer struct {
+	slow     time.Duration // 0 disables slow query logging
+	nPlusOne int           // 0 disables N+1 warnings
+	logAll   bool
+}
+
+// record observes a statement that took elapsed and returned or changed
+// rows, -1 when unknown
+func (o *observer) record(ctx context.Context, query string, args any, elapsed time.Duration, rows int64) {
+	if isQuiet(ctx) {
+		return
+	}
+	slow := o.slow > 0 && elapsed >= o.slow
+
+	if slow || o.logAll {
+		if l := logger.With(
+			"duration", elapsed,
+			"rows", rows,
+			"caller", caller(),
+			"args", redact(args),
+		); l != nil {
+			if slow {
+				l.Warnf("Slow query: %s", query)
+			} else {
+				l.Infof("Query: %s", query)
+			}
+		}
+	}
+
+	stats := statsFromContext(ctx)
+	if stats == nil {
+		return
+	}
+	if stats.add(query, elapsed, slow, o.nPlusOne) {
+		if l := logger.With("caller", caller()); l != nil {
+			l.Warnf("Possible N+1 query, run over %d times in one request: %s", o.nPlusOne, query)
+		}
+	}
+}
+
+// redact describes statement arguments by type only, so logs never hold
+// the personal data or secrets they may carry
+func redact(args any) string {
+	values, ok := args.([]any)
+	if !ok || len(values) == 0 {
+		return "[]"
+	}
+	types := make([]string, len(values))
+	for i, v := range values {
+		types[i] = fmt.Sprintf("%T", v)
+	}
+	return "[" + strings.Join(types, " ") + "]"
+}
+
+// callerSkips are the packages between application code and the driver
+var callerSkips = []string{
+	"go-template/ent",
+	"go-template/internal/database.",
+	"entgo.io/",
+	"database/sql.",
+	"sync.",
+	"runtime.",
+}
+
+// caller returns the application function that made the current statement,
+// e.g. "handler.(*UserHandler).List (user.go:123)"
+func caller() string {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame.Function) {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function[strings.LastIndex(frame.Function, "/")+1:], filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+// skipFrame reports whether a function belongs to the database layers
+func skipFrame(function string) bool {
+	for _, prefix := range callerSkips {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// observingDriver is an ent driver observing each statement
+type observingDriver struct {
+	dialect.Driver
+	o *observer
+}
+
+// Exec executes a statement and observes it
+func (d *observingDriver) Exec(ctx context.Context, query string, args, v any) error {
+	return observeExec(ctx, d.o, query, args, v, d.Driver.Exec)
+}
+
+// Query runs a query and observes it once its rows are closed
+func (d *observingDriver) Query(ctx context.Context, query string, args, v any) error {
+	return observeQuery(ctx, d.o, query, args, v, d.Driver.Query)
+}
+
+// Tx starts a transaction whose statements are observed
+func (d *observingDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &observingTx{Tx: tx, o: d.o}, nil
+}
+
+// BeginTx starts a transaction with options whose statements are observed
+func (d *observingDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("database: driver does not support BeginTx")
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &observingTx{Tx: tx, o: d.o}, nil
+}
+
+// observingTx is a transaction observing each statement
+type observingTx struct {
+	dialect.Tx
+	o *observer
+}
+
+// Exec executes a statement and observes it
+func (tx *observingTx) Exec(ctx context.Context, query string, args, v any) error {
+	return observeExec(ctx, tx.o, query, args, v, tx.Tx.Exec)
+}
+
+// Query runs a query and observes it once its rows are closed
+func (tx *observingTx) Query(ctx context.Context, query string, args, v any) error {
+	return observeQuery(ctx, tx.o, query, args, v, tx.Tx.Query)
+}
+
+// statement runs a statement through an ent driver
+type statement func(ctx context.Context, query string, args, v any) error
+
+// observeExec runs a statement and records it with the rows it changed
+func observeExec(ctx context.Context, o *observer, query string, args, v any, exec statement) error {
+	start := time.Now()
+	err := exec(ctx, query, args, v)
+	rows := int64(-1)
+	if res, ok := v.(*sql.Result); ok && err == nil && *res != nil {
+		if n, err := (*res).RowsAffected(); err == nil {
+			rows = n
+		}
+	}
+	o.record(ctx, query, args, time.Since(start), rows)
+	return err
+}
+
+// observeQuery runs a query and records it when its rows are closed, with
+// the time spent reading them and their count. Quiet queries, such as those
+// of migrations which need the plain rows, are run unchanged.
+func observeQuery(ctx context.Context, o *observer, query string, args, v any, run statement) error {
+	rows, ok := v.(*entsql.Rows)
+	if !ok || isQuiet(ctx) {
+		return run(ctx, query, args, v)
+	}
+
+	start := time.Now()
+	if err := run(ctx, query, args, v); err != nil {
+		o.record(ctx, query, args, time.Since(start), -1)
+		return err
+	}
+	rows.ColumnScanner = &countingRows{ColumnScanner: rows.ColumnScanner, done: func(n int64) {
+		o.record(ctx, query, args, time.Since(start), n)
+	}}
+	return nil
+}
+
+// countingRows are rows counting the rows read and reporting them once closed
+type countingRows struct {
+	entsql.ColumnScanner
+	n    int64
+	done func(n int64)
+	once sync.Once
+}
+
+func (r *countingRows) Next() bool {
+	if r.ColumnScanner.Next() {
+		r.n++
+		return true
+	}
+	return false
+}
+
+func (r *countingRows) Close() error {
+	err := r.ColumnScanner.Close()
+	r.once.Do(func() { r.done(r.n) })
+	return err
+}
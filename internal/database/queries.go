@@ -0,0 +1,469 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go-template/pkg/logger"
+)
+
+// queryFiles are the named queries shipped with the service
+//
+//go:embed queries/*.sql
+var queryFiles embed.FS
+
+// queryReloadInterval is the minimum interval between checks of the query
+// files for changes in debug mode
+const queryReloadInterval = time.Second
+
+// Params are the parameters of a named query, by name
+type Params map[string]any
+
+// Named queries are loaded from the .sql files of internal/database/queries.
+// Each query starts with a name annotation and may declare the dialect it is
+// written for and its parameters, which it references as :name:
+//
+//	-- name: active_users_by_role
+//	-- dialect: postgres
+//	-- param: role_id int
+//	SELECT COUNT(*) FROM users
+//	WHERE role_id = :role_id AND status = 'active' AND deleted_at IS NULL
+//
+// Queries without a dialect run on every database; a query may have a
+// variant per dialect instead. Parameter types are string, int, float, bool
+// and time, with a "?" suffix when the parameter may be nil. Other lines
+// starting with "--" are comments.
+
+// namedQuery is a query of the registry
+type namedQuery struct {
+	name   string
+	sql    string
+	params map[string]paramType // Declared parameters
+	refs   []string             // Parameters in the order of their placeholders
+}
+
+// paramType is the declared type of a query parameter
+type paramType struct {
+	kind     string
+	nullable bool
+}
+
+// queryRegistry holds the named queries of the dialect of the database. In
+// debug mode, queries loaded from a directory are reloaded when its files
+// change.
+type queryRegistry struct {
+	dialect string
+	dir     string // Reloaded directory, empty for the embedded files
+
+	mu        sync.RWMutex
+	queries   map[string]*namedQuery
+	checked   time.Time
+	signature string // Names and modification times of the loaded files
+}
+
+// loadQueries loads and validates the named queries, from cfg.QueriesDir
+// in debug mode and from the embedded files otherwise
+func loadQueries(dialect string, cfg *Config) (*queryRegistry, error) {
+	r := &queryRegistry{dialect: dialect}
+	var files fs.FS
+	if cfg.Debug && cfg.QueriesDir != "" {
+		r.dir = cfg.QueriesDir
+		files = os.DirFS(r.dir)
+		r.signature, _ = dirSignature(r.dir)
+		r.checked = time.Now()
+	} else {
+		files, _ = fs.Sub(queryFiles, "queries")
+	}
+
+	queries, err := parseQueries(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+	r.queries = queries
+	return r, nil
+}
+
+// lookup returns the query of the name, reloading the query files first
+// when they changed
+func (r *queryRegistry) lookup(name string) (*namedQuery, error) {
+	if r.dir != "" {
+		r.reload()
+	}
+
+	r.mu.RLock()
+	q, ok := r.queries[name]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", name)
+	}
+	return q, nil
+}
+
+// reload loads the query files again when they changed since they were
+// loaded. Invalid files are reported and the previous queries kept.
+func (r *queryRegistry) reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if time.Since(r.checked) < queryReloadInterval {
+		return
+	}
+	r.checked = time.Now()
+
+	signature, err := dirSignature(r.dir)
+	if err != nil || signature == r.signature {
+		return
+	}
+	r.signature = signature
+
+	queries, err := parseQueries(os.DirFS(r.dir), r.dialect)
+	if err != nil {
+		logger.Errorf("Keeping previous named queries: %v", err)
+		return
+	}
+	r.queries = queries
+	logger.Infof("Reloaded %d named queries from %s", len(queries), r.dir)
+}
+
+// dirSignature describes the .sql files of a directory and their
+// modification times, to detect changes
+func dirSignature(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "%s:%d;", entry.Name(), info.ModTime().UnixNano())
+	}
+	return b.String(), nil
+}
+
+// parseQueries parses the .sql files of files and keeps the queries of the
+// dialect, checking that every query is valid
+func parseQueries(files fs.FS, dialect string) (map[string]*namedQuery, error) {
+	names, err := fs.Glob(files, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	queries := make(map[string]*namedQuery)
+	specific := make(map[string]bool) // Queries written for the dialect
+	seen := make(map[string]string)   // Files of the queries by name and dialect
+	for _, file := range names {
+		data, err := fs.ReadFile(files, file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := parseQueryFile(file, string(data))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range parsed {
+			key := p.query.name + "@" + p.dialect
+			if other, ok := seen[key]; ok {
+				return nil, fmt.Errorf("%s: query %s is already defined in %s", file, p.query.name, other)
+			}
+			seen[key] = file
+
+			switch {
+			case p.dialect == dialect:
+				queries[p.query.name] = p.query
+				specific[p.query.name] = true
+			case p.dialect == "" && !specific[p.query.name]:
+				queries[p.query.name] = p.query
+			}
+		}
+	}
+
+	// A query written only for other dialects cannot run on this database
+	for key, file := range seen {
+		name := key[:strings.Index(key, "@")]
+		if _, ok := queries[name]; !ok {
+			return nil, fmt.Errorf("%s: query %s has no variant for %s", file, name, dialect)
+		}
+	}
+	return queries, nil
+}
+
+// parsedQuery is a query of a file and the dialect it is written for
+type parsedQuery struct {
+	query   *namedQuery
+	dialect string
+}
+
+// parseQueryFile parses the queries of a .sql file
+func parseQueryFile(file, data string) ([]parsedQuery, error) {
+	var (
+		parsed []parsedQuery
+		cur    *parsedQuery
+		body   strings.Builder
+		line   int
+	)
+	finish := func() error {
+		if cur == nil {
+			return nil
+		}
+		cur.query.sql = strings.TrimSuffix(strings.TrimSpace(body.String()), ";")
+		body.Reset()
+		if err := cur.query.validate(); err != nil {
+			return fmt.Errorf("%s: query %s: %w", file, cur.query.name, err)
+		}
+		parsed = append(parsed, *cur)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(text, "--") {
+			if text != "" && cur == nil {
+				return nil, fmt.Errorf("%s:%d: statement outside of a named query", file, line)
+			}
+			body.WriteString(scanner.Text())
+			body.WriteByte('\n')
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimSpace(strings.TrimPrefix(text, "--")), ":")
+		value = strings.TrimSpace(value)
+		switch {
+		case ok && key == "name":
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			if value == "" {
+				return nil, fmt.Errorf("%s:%d: query without a name", file, line)
+			}
+			cur = &parsedQuery{query: &namedQuery{name: value, params: make(map[string]paramType)}}
+		case ok && (key == "dialect" || key == "param") && cur == nil:
+			return nil, fmt.Errorf("%s:%d: %s annotation outside of a named query", file, line, key)
+		case ok && key == "dialect":
+			drv, err := lookupDriver(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+			cur.dialect = drv.dialect
+		case ok && key == "param":
+			name, kind, err := parseParam(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", file, line, err)
+			}
+			if _, dup := cur.query.params[name]; dup {
+				return nil, fmt.Errorf("%s:%d: parameter %s is declared twice", file, line, name)
+			}
+			cur.query.params[name] = kind
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// parseParam parses a parameter declaration, e.g. "since time?"
+func parseParam(decl string) (string, paramType, error) {
+	fields := strings.Fields(decl)
+	if len(fields) != 2 {
+		return "", paramType{}, fmt.Errorf("parameter %q is not declared as \"name type\"", decl)
+	}
+	kind := paramType{kind: strings.TrimSuffix(fields[1], "?"), nullable: strings.HasSuffix(fields[1], "?")}
+	switch kind.kind {
+	case "string", "int", "float", "bool", "time":
+		return fields[0], kind, nil
+	default:
+		return "", paramType{}, fmt.Errorf("parameter %s has unknown type %q", fields[0], fields[1])
+	}
+}
+
+// validate checks that the query is not empty and that its placeholders
+// and declared parameters match
+func (q *namedQuery) validate() error {
+	if q.sql == "" {
+		return fmt.Errorf("empty statement")
+	}
+	q.sql, q.refs = placeholders(q.sql)
+
+	used := make(map[string]bool)
+	for _, ref := range q.refs {
+		if _, ok := q.params[ref]; !ok {
+			return fmt.Errorf("parameter %s is not declared", ref)
+		}
+		used[ref] = true
+	}
+	for name := range q.params {
+		if !used[name] {
+			return fmt.Errorf("parameter %s is declared but not used", name)
+		}
+	}
+	return nil
+}
+
+// placeholders replaces the :name placeholders of a statement with "?",
+// returning the names in order. Quoted text, comments and PostgreSQL casts
+// such as ::text are left alone.
+func placeholders(stmt string) (string, []string) {
+	var (
+		b    strings.Builder
+		refs []string
+	)
+	for i := 0; i < len(stmt); i++ {
+		c := stmt[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(stmt[i+1:], c)
+			if end < 0 {
+				end = len(stmt) - i - 1
+			}
+			b.WriteString(stmt[i : i+end+2])
+			i += end + 1
+			continue
+		case c == '-' && strings.HasPrefix(stmt[i:], "--"):
+			end := strings.IndexByte(stmt[i:], '\n')
+			if end < 0 {
+				end = len(stmt) - i
+			}
+			b.WriteString(stmt[i : i+end])
+			i += end - 1
+			continue
+		case c == ':' && i+1 < len(stmt) && stmt[i+1] == ':':
+			b.WriteString("::")
+			i++
+			continue
+		case c == ':' && i+1 < len(stmt) && isIdentStart(stmt[i+1]):
+			j := i + 1
+			for j < len(stmt) && isIdent(stmt[j]) {
+				j++
+			}
+			refs = append(refs, stmt[i+1:j])
+			b.WriteByte('?')
+			i = j - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String(), refs
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdent(c byte) bool {
+	return isIdentStart(c) || c >= '0' && c <= '9'
+}
+
+// bind checks the parameters against their declarations and returns the
+// arguments of the placeholders
+func (q *namedQuery) bind(params Params) ([]any, error) {
+	for name := range params {
+		if _, ok := q.params[name]; !ok {
+			return nil, fmt.Errorf("query %s: unknown parameter %s", q.name, name)
+		}
+	}
+	for name, kind := range q.params {
+		value, ok := params[name]
+		if !ok {
+			return nil, fmt.Errorf("query %s: missing parameter %s", q.name, name)
+		}
+		if !kind.accepts(value) {
+			return nil, fmt.Errorf("query %s: parameter %s must be of type %s, got %T", q.name, name, kind, value)
+		}
+	}
+
+	args := make([]any, len(q.refs))
+	for i, ref := range q.refs {
+		args[i] = params[ref]
+	}
+	return args, nil
+}
+
+// accepts reports whether a value has the type of the parameter
+func (t paramType) accepts(value any) bool {
+	switch value.(type) {
+	case nil:
+		return t.nullable
+	case string:
+		return t.kind == "string"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+		return t.kind == "int" || t.kind == "float"
+	case float32, float64:
+		return t.kind == "float"
+	case bool:
+		return t.kind == "bool"
+	case time.Time:
+		return t.kind == "time"
+	}
+	return false
+}
+
+func (t paramType) String() string {
+	if t.nullable {
+		return t.kind + "?"
+	}
+	return t.kind
+}
+
+// Bind returns the statement of a named query for the database and the
+// arguments of its parameters, e.g. to run it in a transaction
+func (c *Client) Bind(name string, params Params) (string, []any, error) {
+	q, err := c.queries.lookup(name)
+	if err != nil {
+		return "", nil, err
+	}
+	args, err := q.bind(params)
+	if err != nil {
+		return "", nil, err
+	}
+	return c.Rebind(q.sql), args, nil
+}
+
+// Named runs a named query and returns its rows
+func (c *Client) Named(ctx context.Context, name string, params Params) (*Rows, error) {
+	query, args, err := c.Bind(name, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.QueryContext(ctx, query, args...)
+}
+
+// NamedScan runs a named query returning a single row and scans it into
+// dest, returning sql.ErrNoRows when there is no row
+func (c *Client) NamedScan(ctx context.Context, name string, params Params, dest ...any) error {
+	query, args, err := c.Bind(name, params)
+	if err != nil {
+		return err
+	}
+	return c.QueryRowContext(ctx, query, args...).Scan(dest...)
+}
+
+// NamedExec executes a named statement
+func (c *Client) NamedExec(ctx context.Context, name string, params Params) (sql.Result, error) {
+	query, args, err := c.Bind(name, params)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,30 @@
+-- Named queries on roles, see internal/database/queries.go for the format.
+-- Users hold shared roles, the roles without an organization; organization
+-- roles only apply to memberships and are left out, as are soft-deleted
+-- users and roles.
+
+-- name: role_id_by_name
+-- Shared role names are unique among roles that are not deleted
+-- param: role_name string
+SELECT id FROM roles
+WHERE name = :role_name AND organization_id IS NULL AND deleted_at IS NULL;
+
+-- name: role_user_counts
+SELECT
+	r.id AS role_id,
+	r.name AS role_name,
+	r.description,
+	COUNT(u.id) AS user_count
+FROM roles r
+LEFT JOIN users u ON r.id = u.role_id AND u.deleted_at IS NULL
+WHERE r.organization_id IS NULL AND r.deleted_at IS NULL
+GROUP BY r.id, r.name, r.description
+ORDER BY user_count DESC;
+
+-- name: users_per_role
+SELECT r.name AS role, COUNT(*) AS count
+FROM users u
+LEFT JOIN roles r ON u.role_id = r.id
+WHERE u.deleted_at IS NULL
+GROUP BY r.id, r.name
+ORDER BY count DESC;
@@ -0,0 +1,32 @@
+-- Named queries on users, see internal/database/queries.go for the format.
+-- Soft-deleted and erased users have deleted_at set and are left out.
+
+-- name: user_stats
+-- Counts the users of each status
+SELECT
+	COUNT(*) AS total_users,
+	COALESCE(SUM(CASE WHEN status = 'active' THEN 1 ELSE 0 END), 0) AS active_users,
+	COALESCE(SUM(CASE WHEN status = 'disabled' THEN 1 ELSE 0 END), 0) AS disabled_users
+FROM users
+WHERE deleted_at IS NULL;
+
+-- name: newest_user_date
+-- Selecting the column rather than MAX(created_at) keeps its type, which
+-- SQLite loses in aggregates
+SELECT created_at FROM users WHERE deleted_at IS NULL ORDER BY created_at DESC LIMIT 1;
+
+-- name: active_users_by_role
+-- param: role_id int
+SELECT COUNT(*)
+FROM users
+WHERE role_id = :role_id AND status = 'active' AND deleted_at IS NULL;
+
+-- name: activate_role_users
+-- Bumps the version like ent updates do, so ETags and If-Match checks see
+-- the change. updated_by is kept when no actor is given.
+-- param: role_id int
+-- param: updated_by int?
+UPDATE users
+SET status = 'active', version = version + 1, updated_at = CURRENT_TIMESTAMP,
+	updated_by = COALESCE(:updated_by, updated_by)
+WHERE role_id = :role_id AND status = 'disabled' AND deleted_at IS NULL;
@@ -4,35 +4,158 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
+	"go-template/ent"
 	"go-template/pkg/logger"
 )
 
-// RawDB returns the underlying sql.DB instance
+// RawDB returns the underlying sql.DB instance of the primary
 func (c *Client) RawDB() *sql.DB {
 	return c.db
 }
 
 // ExecContext executes a raw SQL query
 func (c *Client) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
-	logger.Debugf("Executing raw SQL: %s", query)
+	start := time.Now()
+	res, err := c.execContext(ctx, query, args...)
+	rows := int64(-1)
+	if err == nil {
+		if n, err := res.RowsAffected(); err == nil {
+			rows = n
+		}
+	}
+	c.observer.record(ctx, query, args, time.Since(start), rows)
+	return res, err
+}
+
+// execContext executes a raw SQL query within the statement timeout
+func (c *Client) execContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	ctx, cancel := statementContext(ctx, c.timeout)
+	defer cancel()
+	if st := txFromContext(ctx); st != nil {
+		return st.tx.ExecContext(ctx, query, args...)
+	}
 	return c.db.ExecContext(ctx, query, args...)
 }
 
-// QueryContext executes a raw SQL query and returns the rows
-func (c *Client) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
-	logger.Debugf("Querying raw SQL: %s", query)
+// Rows are the rows of a raw query. Closing them releases the statement
+// timeout of the query.
+type Rows struct {
+	*sql.Rows
+	cancel context.CancelFunc
+}
+
+// Close closes the rows and releases their context
+func (r *Rows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// Row is the result of a raw query returning a single row
+type Row struct {
+	rows *Rows
+	err  error
+}
+
+// Scan copies the columns of the row into dest and closes it, returning
+// sql.ErrNoRows when there is no row
+func (r *Row) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	defer r.rows.Close()
+
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err := r.rows.Scan(dest...); err != nil {
+		return err
+	}
+	return r.rows.Close()
+}
+
+// Err returns the error of the query, if any
+func (r *Row) Err() error {
+	return r.err
+}
+
+// QueryContext executes a raw SQL query and returns the rows, which must be
+// closed. The query is bounded by the statement timeout until then. SELECT
+// queries run on a replica unless the context forces the primary. The
+// returned rows are not counted, so the query is observed until it returns.
+func (c *Client) QueryContext(ctx context.Context, query string, args ...interface{}) (*Rows, error) {
+	defer c.observe(ctx, query, args, time.Now())
+	ctx, cancel := statementContext(ctx, c.timeout)
+	rows, err := c.queryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Rows{Rows: rows, cancel: cancel}, nil
+}
+
+// queryContext executes a raw SQL query in the transaction of the context,
+// on a replica or on the primary
+func (c *Client) queryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if st := txFromContext(ctx); st != nil {
+		return st.tx.QueryContext(ctx, query, args...)
+	}
+	if r := c.replicas.reader(ctx, query); r != nil {
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err == nil || !isConnectionError(err) {
+			return rows, err
+		}
+		c.replicas.eject(r, err)
+	}
 	return c.db.QueryContext(ctx, query, args...)
 }
 
-// QueryRowContext executes a raw SQL query that returns a single row
-func (c *Client) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
-	logger.Debugf("Querying raw SQL row: %s", query)
-	return c.db.QueryRowContext(ctx, query, args...)
+// QueryRowContext executes a raw SQL query that returns a single row, run
+// as by QueryContext. Errors surface when scanning.
+func (c *Client) QueryRowContext(ctx context.Context, query string, args ...interface{}) *Row {
+	rows, err := c.QueryContext(ctx, query, args...)
+	return &Row{rows: rows, err: err}
+}
+
+// observe records a raw query started at start whose rows are unknown
+func (c *Client) observe(ctx context.Context, query string, args []any, start time.Time) {
+	c.observer.record(ctx, query, args, time.Since(start), -1)
 }
 
-// Transaction executes the given function within a transaction
+// Transaction executes the given function within a transaction. Transactions
+// failing with transient errors, such as serialization failures or dropped
+// connections, are retried from the start, so fn must be safe to run again.
+// Timeouts are not retried, nor commits whose connection failed.
+// Within a context carrying a transaction, see WithTx, fn runs in a
+// savepoint of it and is not retried.
 func (c *Client) Transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	if st := txFromContext(ctx); st != nil {
+		return st.savepoint(ctx, func(context.Context, *ent.Tx) error { return fn(st.tx) })
+	}
+
+	backoff := txBackoff
+	for attempt := 0; ; attempt++ {
+		err := c.transaction(ctx, fn)
+		if err == nil || attempt >= c.txRetries || !retryable(err) {
+			return err
+		}
+
+		logger.Warnf("Retrying transaction in %s (%d/%d): %v", backoff, attempt+1, c.txRetries, err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// transaction runs fn within a single transaction
+func (c *Client) transaction(ctx context.Context, fn func(tx *sql.Tx) error) error {
 	tx, err := c.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -40,9 +163,12 @@ func (c *Client) Transaction(ctx context.Context, fn func(tx *sql.Tx) error) err
 
 	if err := fn(tx); err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
-			return fmt.Errorf("tx err: %v, rb err: %v", err, rbErr)
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
 		}
 		return err
 	}
-	return tx.Commit()
+	if err := tx.Commit(); err != nil {
+		return &commitError{err: err}
+	}
+	return nil
 }
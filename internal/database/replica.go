@@ -0,0 +1,224 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-template/pkg/logger"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// replicaPingTimeout bounds the health checks of replicas
+const replicaPingTimeout = 2 * time.Second
+
+// primaryKey is the context key forcing queries to the primary
+type primaryKey struct{}
+
+// WithPrimary returns a context whose queries go to the primary, for reads
+// that must see writes made just before
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// usesPrimary reports whether the context forces queries to the primary
+func usesPrimary(ctx context.Context) bool {
+	forced, _ := ctx.Value(primaryKey{}).(bool)
+	return forced
+}
+
+// isRead reports whether a statement only reads and may run on a replica.
+// ent also runs inserts and updates with RETURNING as queries, so the
+// statement itself is checked.
+func isRead(query string) bool {
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// replica is a read replica connection
+type replica struct {
+	name    string
+	db      *sql.DB
+	drv     dialect.Driver
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads across the healthy replicas by round-robin and
+// ejects replicas failing their health checks until they recover
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint32
+	stop     chan struct{}
+	done     sync.WaitGroup
+	closed   sync.Once
+}
+
+// openReplicas connects to the replicas of the configuration. Replicas that
+// are down at startup are ejected until their health check passes.
+func openReplicas(drv sqlDriver, cfg *Config) (*replicaSet, error) {
+	set := &replicaSet{stop: make(chan struct{})}
+	for i, dsn := range cfg.Replicas {
+		db, err := sql.Open(drv.name, dsn)
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("failed opening connection to replica %d: %w", i+1, err)
+		}
+		configurePool(db, cfg)
+		set.replicas = append(set.replicas, &replica{
+			name: fmt.Sprintf("replica %d", i+1),
+			db:   db,
+			drv:  withTimeout(entsql.OpenDB(drv.dialect, db), cfg.StatementTimeout),
+		})
+	}
+
+	set.check()
+	for _, r := range set.replicas {
+		if !r.healthy.Load() {
+			logger.Warnf("Database %s is not reachable, it is skipped until it recovers", r.name)
+		}
+	}
+	if len(set.replicas) > 0 && cfg.ReplicaCheckInterval > 0 {
+		set.done.Add(1)
+		go set.watch(cfg.ReplicaCheckInterval)
+	}
+	return set, nil
+}
+
+// pick returns the next healthy replica, or nil when none is
+func (s *replicaSet) pick() *replica {
+	n := uint32(len(s.replicas))
+	for range n {
+		r := s.replicas[s.next.Add(1)%n]
+		if r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// reader returns the replica a read should go to, or nil for the primary
+func (s *replicaSet) reader(ctx context.Context, query string) *replica {
+	if s == nil || len(s.replicas) == 0 || usesPrimary(ctx) || !isRead(query) {
+		return nil
+	}
+	return s.pick()
+}
+
+// eject takes a replica out of rotation after a connection error, until
+// its health check passes again
+func (s *replicaSet) eject(r *replica, err error) {
+	if r.healthy.Swap(false) {
+		logger.Warnf("Database %s ejected: %v", r.name, err)
+	}
+}
+
+// watch checks the replicas at every interval until the set is closed
+func (s *replicaSet) watch(interval time.Duration) {
+	defer s.done.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// check pings every replica, ejecting those that fail and restoring those
+// that recovered
+func (s *replicaSet) check() {
+	for _, r := range s.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+		err := r.db.PingContext(ctx)
+		cancel()
+		if err != nil {
+			s.eject(r, err)
+			continue
+		}
+		if !r.healthy.Swap(true) {
+			logger.Infof("Database %s is healthy", r.name)
+		}
+	}
+}
+
+// close stops the health checks and closes the replica connections. Later
+// calls do nothing.
+func (s *replicaSet) close() error {
+	var firstErr error
+	s.closed.Do(func() {
+		close(s.stop)
+		s.done.Wait()
+		for _, r := range s.replicas {
+			if err := r.db.Close(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+	})
+	return firstErr
+}
+
+// routingDriver is an ent driver sending reads to the replicas and writes
+// and transactions to the primary. Statements made with a context carrying
+// a transaction run in it.
+type routingDriver struct {
+	dialect.Driver // Primary
+	replicas       *replicaSet
+}
+
+// Exec executes a statement on the primary
+func (d *routingDriver) Exec(ctx context.Context, query string, args, v any) error {
+	if st := txFromContext(ctx); st != nil {
+		return st.dialectTx().Exec(ctx, query, args, v)
+	}
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query runs a query on a replica when it only reads. Reads failing on a
+// replica's connection eject it and run on the primary instead.
+func (d *routingDriver) Query(ctx context.Context, query string, args, v any) error {
+	if st := txFromContext(ctx); st != nil {
+		return st.dialectTx().Query(ctx, query, args, v)
+	}
+	if r := d.replicas.reader(ctx, query); r != nil {
+		err := r.drv.Query(ctx, query, args, v)
+		if err == nil || !isConnectionError(err) {
+			return err
+		}
+		d.replicas.eject(r, err)
+	}
+	return d.Driver.Query(ctx, query, args, v)
+}
+
+// Tx starts a transaction on the primary
+func (d *routingDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a transaction with options on the primary. Within a
+// context carrying a transaction, it starts a savepoint of that transaction
+// instead; the transaction being started by WithTx is returned as is.
+func (d *routingDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	if st := txFromContext(ctx); st != nil {
+		if st.ent == nil {
+			return st.dialectTx(), nil
+		}
+		return st.begin(ctx)
+	}
+
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("database: driver does not support BeginTx")
+	}
+	return drv.BeginTx(ctx, opts)
+}
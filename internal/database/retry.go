@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// txBackoff is the wait before the first retry of a transaction, doubled
+// after each retry
+const txBackoff = 20 * time.Millisecond
+
+// IsTransient reports whether an error is likely to go away when the
+// transaction is retried: serialization failures, deadlocks, lock timeouts
+// and dropped connections
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isConnectionError(err) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code == "40001" || pgErr.Code == "40P01" // serialization_failure, deadlock_detected
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == 1213 || myErr.Number == 1205 // deadlock, lock wait timeout
+	}
+
+	// SQLite errors are only typed in cgo builds, so they are matched by
+	// message: SQLITE_BUSY and SQLITE_LOCKED
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
+
+// commitError is a failure to commit a transaction
+type commitError struct {
+	err error
+}
+
+func (e *commitError) Error() string { return "committing transaction: " + e.err.Error() }
+func (e *commitError) Unwrap() error { return e.err }
+
+// retryable reports whether a failed transaction may run again. A commit
+// whose connection failed may have been applied, so it is not retried.
+func retryable(err error) bool {
+	var ce *commitError
+	if errors.As(err, &ce) && isConnectionError(err) {
+		return false
+	}
+	return IsTransient(err)
+}
+
+// isConnectionError reports whether an error comes from a connection to the
+// database failing rather than from the statement. Timeouts and
+// cancellations are not: their error types also implement net.Error.
+func isConnectionError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, mysql.ErrInvalidConn) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return strings.HasPrefix(pgErr.Code, "08") // connection exceptions
+	}
+	return false
+}
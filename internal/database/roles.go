@@ -0,0 +1,73 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql/schema"
+	"github.com/go-sql-driver/mysql"
+)
+
+// sharedRoleIndex is the unique index of the names of shared roles, the
+// roles without an organization. The unique index on name and organization
+// does not cover them, as NULL organizations are distinct.
+const sharedRoleIndex = "roles_shared_name_idx"
+
+// sharedRoleDDL creates the shared role index, which ent schemas cannot
+// describe. MySQL has no partial indexes, so the name is indexed through an
+// expression that is NULL for the other roles.
+var sharedRoleDDL = map[string]string{
+	dialect.Postgres: `CREATE UNIQUE INDEX IF NOT EXISTS ` + sharedRoleIndex + ` ON roles (name)
+		WHERE organization_id IS NULL AND deleted_at IS NULL`,
+	dialect.SQLite: `CREATE UNIQUE INDEX IF NOT EXISTS ` + sharedRoleIndex + ` ON roles (name)
+		WHERE organization_id IS NULL AND deleted_at IS NULL`,
+	dialect.MySQL: `CREATE UNIQUE INDEX ` + sharedRoleIndex + ` ON roles
+		((CASE WHEN organization_id IS NULL AND deleted_at IS NULL THEN name END))`,
+}
+
+// migrateSharedRoles creates the shared role index if it does not exist
+func migrateSharedRoles(ctx context.Context, db *sql.DB, dialectName string) error {
+	stmt, ok := sharedRoleDDL[dialectName]
+	if !ok {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, stmt)
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) && myErr.Number == 1061 { // duplicate key name
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("creating shared role index: %w", err)
+	}
+	return nil
+}
+
+// keepSharedRoles removes the shared role index from the changes of a
+// migration. ent does not know it and would drop it on unsafe migrations.
+func keepSharedRoles(next schema.Differ) schema.Differ {
+	return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+		changes, err := next.Diff(current, desired)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			modify, ok := change.(*atlas.ModifyTable)
+			if !ok || modify.T.Name != "roles" {
+				continue
+			}
+			kept := modify.Changes[:0]
+			for _, c := range modify.Changes {
+				if drop, ok := c.(*atlas.DropIndex); ok && drop.I.Name == sharedRoleIndex {
+					continue
+				}
+				kept = append(kept, c)
+			}
+			modify.Changes = kept
+		}
+		return changes, nil
+	})
+}
@@ -0,0 +1,588 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// Rows of raw queries are scanned into structs whose fields are mapped to
+// columns by their db tags, e.g. `db:"user_count"`. Untagged fields match
+// the snake_case of their name and `db:"-"` fields are ignored; fields of
+// embedded structs are promoted. A json option, e.g. `db:"settings,json"`,
+// decodes the column as JSON; columns of JSON types decode into struct, map
+// and slice fields without it.
+//
+// NULLs leave fields at their zero value, or nil for pointers. Slices also
+// decode PostgreSQL arrays. Times are returned in UTC, including the textual
+// times of SQLite expressions.
+//
+// Rows may also be scanned into map[string]any, with the values of their
+// columns normalized for JSON as by WriteJSON, and single columns into
+// plain types, e.g. QueryAll[int64].
+
+// QueryAll runs a query and scans all its rows into T
+func QueryAll[T any](ctx context.Context, db *Client, query string, args ...any) ([]T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return ScanAll[T](rows)
+}
+
+// QueryOne runs a query and scans its first row into T, returning
+// sql.ErrNoRows when there is none
+func QueryOne[T any](ctx context.Context, db *Client, query string, args ...any) (T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return ScanOne[T](rows)
+}
+
+// NamedAll runs a named query and scans all its rows into T
+func NamedAll[T any](ctx context.Context, db *Client, name string, params Params) ([]T, error) {
+	rows, err := db.Named(ctx, name, params)
+	if err != nil {
+		return nil, err
+	}
+	return ScanAll[T](rows)
+}
+
+// NamedOne runs a named query and scans its first row into T, returning
+// sql.ErrNoRows when there is none
+func NamedOne[T any](ctx context.Context, db *Client, name string, params Params) (T, error) {
+	rows, err := db.Named(ctx, name, params)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return ScanOne[T](rows)
+}
+
+// ScanAll scans all the rows into T and closes them
+func ScanAll[T any](rows *Rows) ([]T, error) {
+	results := []T{}
+	err := Each(rows, func(v T) error {
+		results = append(results, v)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ScanOne scans the first row into T and closes the rows, returning
+// sql.ErrNoRows when there is none
+func ScanOne[T any](rows *Rows) (T, error) {
+	var (
+		result T
+		found  bool
+	)
+	err := Each(rows, func(v T) error {
+		result, found = v, true
+		return errStop
+	})
+	if err == nil && !found {
+		err = sql.ErrNoRows
+	}
+	return result, err
+}
+
+// errStop stops Each without error
+var errStop = errors.New("stop")
+
+// Each scans the rows one at a time into T and calls fn with each, without
+// holding them all in memory. The rows are closed when it returns.
+func Each[T any](rows *Rows, fn func(T) error) error {
+	defer rows.Close()
+
+	scan, err := newScanner[T](rows.Rows)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		v, err := scan(rows.Rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(v); err != nil {
+			if errors.Is(err, errStop) {
+				return nil
+			}
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// newScanner returns the function scanning a row of the rows into T
+func newScanner[T any](rows *sql.Rows) (func(*sql.Rows) (T, error), error) {
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	typ := reflect.TypeFor[T]()
+	if typ == reflect.TypeFor[map[string]any]() {
+		return func(rows *sql.Rows) (T, error) {
+			var result T
+			if err := rows.Scan(dest...); err != nil {
+				return result, err
+			}
+			row := make(map[string]any, len(columns))
+			for i, col := range columns {
+				row[col.Name()] = normalize(col, values[i])
+			}
+			reflect.ValueOf(&result).Elem().Set(reflect.ValueOf(row))
+			return result, nil
+		}, nil
+	}
+
+	if typ.Kind() != reflect.Struct || typ == timeType || reflect.PointerTo(typ).Implements(scannerType) {
+		if len(columns) != 1 {
+			return nil, fmt.Errorf("%d columns cannot be scanned into %s", len(columns), typ)
+		}
+		return func(rows *sql.Rows) (T, error) {
+			var result T
+			if err := rows.Scan(dest...); err != nil {
+				return result, err
+			}
+			err := assign(reflect.ValueOf(&result).Elem(), values[0], isJSONColumn(columns[0]) && decodesJSON(typ))
+			if err != nil {
+				err = fmt.Errorf("column %s: %w", columns[0].Name(), err)
+			}
+			return result, err
+		}, nil
+	}
+
+	fields := structFields(typ)
+	targets := make([]structField, len(columns))
+	for i, col := range columns {
+		f, ok := fields[strings.ToLower(col.Name())]
+		if !ok {
+			return nil, fmt.Errorf("column %s has no field in %s", col.Name(), typ)
+		}
+		f.json = f.json || isJSONColumn(col) && decodesJSON(f.typ)
+		targets[i] = f
+	}
+
+	return func(rows *sql.Rows) (T, error) {
+		var result T
+		if err := rows.Scan(dest...); err != nil {
+			return result, err
+		}
+		v := reflect.ValueOf(&result).Elem()
+		for i, f := range targets {
+			if err := assign(fieldByIndex(v, f.index), values[i], f.json); err != nil {
+				return result, fmt.Errorf("column %s: %w", columns[i].Name(), err)
+			}
+		}
+		return result, nil
+	}, nil
+}
+
+// structField is the field a column maps to
+type structField struct {
+	index []int
+	typ   reflect.Type
+	json  bool
+}
+
+// fieldCache holds the fields of the struct types scanned, by column name
+var fieldCache sync.Map // map[reflect.Type]map[string]structField
+
+// structFields returns the fields of a struct type by lowercase column name
+func structFields(typ reflect.Type) map[string]structField {
+	if cached, ok := fieldCache.Load(typ); ok {
+		return cached.(map[string]structField)
+	}
+
+	fields := make(map[string]structField)
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := range t.NumField() {
+			f := t.Field(i)
+			tag := f.Tag.Get("db")
+			if tag == "-" || !f.IsExported() && (!f.Anonymous || f.Type.Kind() != reflect.Struct) {
+				continue
+			}
+			path := append(append([]int(nil), index...), i)
+			if f.Anonymous && tag == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, path)
+					continue
+				}
+			}
+
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "" {
+				name = snakeCase(f.Name)
+			}
+			name = strings.ToLower(name)
+			if _, ok := fields[name]; ok && len(path) > 1 {
+				continue // Fields of the outer struct win
+			}
+			fields[name] = structField{index: path, typ: f.Type, json: opts == "json"}
+		}
+	}
+	walk(typ, nil)
+
+	cached, _ := fieldCache.LoadOrStore(typ, fields)
+	return cached.(map[string]structField)
+}
+
+// fieldByIndex returns the field of the index path, allocating embedded
+// struct pointers on the way
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// snakeCase converts a Go name to snake_case, e.g. UserID to user_id
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isJSONColumn reports whether a column has a JSON type
+func isJSONColumn(col *sql.ColumnType) bool {
+	name := strings.ToUpper(col.DatabaseTypeName())
+	return name == "JSON" || name == "JSONB"
+}
+
+// decodesJSON reports whether values of a type decode from JSON columns:
+// structs, maps, slices and interfaces, rather than the text of the JSON
+func decodesJSON(typ reflect.Type) bool {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Map, reflect.Interface:
+		return true
+	case reflect.Slice:
+		return !isBytes(typ)
+	case reflect.Struct:
+		return typ != timeType && !reflect.PointerTo(typ).Implements(scannerType)
+	}
+	return false
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// isBytes reports whether a type is a byte slice, such as json.RawMessage
+func isBytes(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// assign sets v to the value src of a column, converting it to the type of v
+func assign(v reflect.Value, src any, asJSON bool) error {
+	if v.Kind() == reflect.Pointer {
+		if src == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := assign(elem.Elem(), src, asJSON); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	}
+
+	if asJSON && !isBytes(v.Type()) {
+		if src == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		data, ok := textOf(src)
+		if !ok {
+			return fmt.Errorf("cannot decode %T as JSON", src)
+		}
+		return json.Unmarshal([]byte(data), v.Addr().Interface())
+	}
+
+	if reflect.PointerTo(v.Type()).Implements(scannerType) {
+		if t, ok := src.(time.Time); ok {
+			src = t.UTC()
+		}
+		return v.Addr().Interface().(sql.Scanner).Scan(src)
+	}
+
+	if src == nil {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+
+	switch {
+	case v.Type() == timeType:
+		t, err := toTime(src)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case isBytes(v.Type()):
+		data, ok := textOf(src)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to []byte", src)
+		}
+		v.SetBytes([]byte(data))
+		return nil
+	case v.Kind() == reflect.Interface && v.NumMethod() == 0:
+		if b, ok := src.([]byte); ok {
+			src = string(b)
+		} else if t, ok := src.(time.Time); ok {
+			src = t.UTC()
+		}
+		v.Set(reflect.ValueOf(src))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		switch s := src.(type) {
+		case string:
+			v.SetString(s)
+		case []byte:
+			v.SetString(string(s))
+		case time.Time:
+			v.SetString(s.UTC().Format(time.RFC3339Nano))
+		default:
+			v.SetString(fmt.Sprint(s))
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt(src)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(n) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt(src)
+		if err != nil {
+			return err
+		}
+		if n < 0 || v.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%d overflows %s", n, v.Type())
+		}
+		v.SetUint(uint64(n))
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(src)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Bool:
+		b, err := toBool(src)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Slice:
+		// Arrays arrive as text: JSON arrays or PostgreSQL array literals
+		text, ok := textOf(src)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to %s", src, v.Type())
+		}
+		if strings.HasPrefix(text, "[") {
+			return json.Unmarshal([]byte(text), v.Addr().Interface())
+		}
+		elems, err := parseArray(text)
+		if err != nil {
+			return err
+		}
+		slice := reflect.MakeSlice(v.Type(), len(elems), len(elems))
+		for i, elem := range elems {
+			if err := assign(slice.Index(i), elem, false); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		v.Set(slice)
+	case reflect.Map, reflect.Struct:
+		text, ok := textOf(src)
+		if !ok {
+			return fmt.Errorf("cannot convert %T to %s", src, v.Type())
+		}
+		return json.Unmarshal([]byte(text), v.Addr().Interface())
+	default:
+		return fmt.Errorf("unsupported field type %s", v.Type())
+	}
+	return nil
+}
+
+// textOf returns a textual column value
+func textOf(src any) (string, bool) {
+	switch s := src.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
+
+func toInt(src any) (int64, error) {
+	switch n := src.(type) {
+	case int64:
+		return n, nil
+	case float64:
+		if n != float64(int64(n)) {
+			return 0, fmt.Errorf("%v is not an integer", n)
+		}
+		return int64(n), nil
+	case bool:
+		if n {
+			return 1, nil
+		}
+		return 0, nil
+	}
+	if text, ok := textOf(src); ok {
+		return strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to an integer", src)
+}
+
+func toFloat(src any) (float64, error) {
+	switch n := src.(type) {
+	case float64:
+		return n, nil
+	case int64:
+		return float64(n), nil
+	}
+	if text, ok := textOf(src); ok {
+		return strconv.ParseFloat(strings.TrimSpace(text), 64)
+	}
+	return 0, fmt.Errorf("cannot convert %T to a float", src)
+}
+
+func toBool(src any) (bool, error) {
+	switch b := src.(type) {
+	case bool:
+		return b, nil
+	case int64:
+		return b != 0, nil
+	}
+	if text, ok := textOf(src); ok {
+		return strconv.ParseBool(strings.TrimSpace(text))
+	}
+	return false, fmt.Errorf("cannot convert %T to a bool", src)
+}
+
+// timeLayouts are the textual time formats of the supported databases
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+// toTime converts a column value to a time in UTC. Times without a zone are
+// taken as UTC.
+func toTime(src any) (time.Time, error) {
+	if t, ok := src.(time.Time); ok {
+		return t.UTC(), nil
+	}
+	text, ok := textOf(src)
+	if !ok {
+		return time.Time{}, fmt.Errorf("cannot convert %T to a time", src)
+	}
+	text = strings.TrimSpace(text)
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as a time", text)
+}
+
+// parseArray parses a one-dimensional PostgreSQL array literal, e.g.
+// {a,"b c",NULL}, into its elements, nil for NULL
+func parseArray(text string) ([]any, error) {
+	if len(text) < 2 || text[0] != '{' || text[len(text)-1] != '}' {
+		return nil, fmt.Errorf("cannot parse %q as an array", text)
+	}
+	body := text[1 : len(text)-1]
+	if body == "" {
+		return []any{}, nil
+	}
+
+	var (
+		elems  []any
+		b      strings.Builder
+		quoted bool // Element is quoted
+		inside bool // Inside quotes
+	)
+	flush := func() {
+		if !quoted && strings.EqualFold(b.String(), "NULL") {
+			elems = append(elems, nil)
+		} else {
+			elems = append(elems, b.String())
+		}
+		b.Reset()
+		quoted = false
+	}
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+		switch {
+		case c == '\\' && inside && i+1 < len(body):
+			i++
+			b.WriteByte(body[i])
+		case c == '"':
+			inside = !inside
+			quoted = true
+		case c == '{' && !inside:
+			return nil, errors.New("multi-dimensional arrays are not supported")
+		case c == ',' && !inside:
+			flush()
+		default:
+			b.WriteByte(c)
+		}
+	}
+	flush()
+	return elems, nil
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	atlas "ariga.io/atlas/sql/schema"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// SearchColumn is the generated tsvector column of the users table
+const SearchColumn = "search"
+
+// searchIndexes are the indexes backing user search
+var searchIndexes = map[string]bool{
+	"users_search_idx":     true,
+	"users_name_trgm_idx":  true,
+	"users_email_trgm_idx": true,
+}
+
+// searchDDL creates the user search column and indexes, which ent schemas
+// cannot describe. Emails are split at their separators so partial emails
+// match. Every statement is idempotent so it runs on each start.
+var searchDDL = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`ALTER TABLE users ADD COLUMN IF NOT EXISTS search tsvector
+		GENERATED ALWAYS AS (to_tsvector('simple', name || ' ' || translate(email, '@.-_+', '     '))) STORED`,
+	`CREATE INDEX IF NOT EXISTS users_search_idx ON users USING GIN (search)`,
+	`CREATE INDEX IF NOT EXISTS users_name_trgm_idx ON users USING GIN (name gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS users_email_trgm_idx ON users USING GIN (email gin_trgm_ops)`,
+}
+
+// migrateSearch creates the user search objects in a transaction, so a
+// missing extension leaves none of them behind
+func migrateSearch(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range searchDDL {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("creating search objects: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// keepSearch removes the user search objects from the changes of a
+// migration. ent does not know them and would drop them on unsafe migrations.
+func keepSearch(next schema.Differ) schema.Differ {
+	return schema.DiffFunc(func(current, desired *atlas.Schema) ([]atlas.Change, error) {
+		changes, err := next.Diff(current, desired)
+		if err != nil {
+			return nil, err
+		}
+		for _, change := range changes {
+			modify, ok := change.(*atlas.ModifyTable)
+			if !ok || modify.T.Name != "users" {
+				continue
+			}
+			kept := modify.Changes[:0]
+			for _, c := range modify.Changes {
+				switch c := c.(type) {
+				case *atlas.DropColumn:
+					if c.C.Name == SearchColumn {
+						continue
+					}
+				case *atlas.DropIndex:
+					if searchIndexes[c.I.Name] {
+						continue
+					}
+				}
+				kept = append(kept, c)
+			}
+			modify.Changes = kept
+		}
+		return changes, nil
+	})
+}
+
+// FullTextSearch reports whether user search can use the tsvector column and
+// trigram indexes. Without them, search falls back to ILIKE.
+func (c *Client) FullTextSearch() bool {
+	return c.fullText
+}
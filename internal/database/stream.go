@@ -0,0 +1,277 @@
+package database
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Column describes a column of a result set
+type Column struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`               // Database type, e.g. "INT8", empty when unknown
+	Nullable *bool  `json:"nullable,omitempty"` // Unset when the driver does not tell
+}
+
+// RowReader reads rows one at a time as values normalized for output:
+// numbers as numbers, JSON as json.RawMessage, PostgreSQL arrays as slices
+// and times in UTC
+type RowReader struct {
+	rows    *sql.Rows
+	columns []*sql.ColumnType
+	values  []any
+	dest    []any
+}
+
+// NewRowReader returns a reader of the rows
+func NewRowReader(rows *sql.Rows) (*RowReader, error) {
+	columns, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	r := &RowReader{
+		rows:    rows,
+		columns: columns,
+		values:  make([]any, len(columns)),
+		dest:    make([]any, len(columns)),
+	}
+	for i := range r.values {
+		r.dest[i] = &r.values[i]
+	}
+	return r, nil
+}
+
+// Columns describes the columns of the rows
+func (r *RowReader) Columns() []Column {
+	columns := make([]Column, len(r.columns))
+	for i, col := range r.columns {
+		columns[i] = Column{Name: col.Name(), Type: col.DatabaseTypeName()}
+		if nullable, ok := col.Nullable(); ok {
+			columns[i].Nullable = &nullable
+		}
+	}
+	return columns
+}
+
+// Next reads the next row, returning false at the end of the rows or on error
+func (r *RowReader) Next() bool {
+	return r.rows.Next()
+}
+
+// Values returns the normalized values of the current row
+func (r *RowReader) Values() ([]any, error) {
+	if err := r.rows.Scan(r.dest...); err != nil {
+		return nil, err
+	}
+	out := make([]any, len(r.columns))
+	for i, col := range r.columns {
+		out[i] = normalize(col, r.values[i])
+	}
+	return out, nil
+}
+
+// Err returns the error that ended the rows, if any
+func (r *RowReader) Err() error {
+	return r.rows.Err()
+}
+
+// WriteJSON writes the rows to w as a JSON array of objects, one per row
+// with the columns in order, and closes them. Rows are written as they are
+// read, so result sets of any size can be streamed to a response.
+func WriteJSON(w io.Writer, rows *Rows) error {
+	return writeRows(w, rows, "[", ",", "]")
+}
+
+// WriteNDJSON writes the rows to w as newline-delimited JSON objects, one
+// per row with the columns in order, and closes them
+func WriteNDJSON(w io.Writer, rows *Rows) error {
+	return writeRows(w, rows, "", "", "")
+}
+
+// writeRows writes the rows as JSON objects between open and close,
+// separated by sep, and closes them. Without open, each object ends a line.
+func writeRows(w io.Writer, rows *Rows, open, sep, close string) error {
+	defer rows.Close()
+
+	r, err := NewRowReader(rows.Rows)
+	if err != nil {
+		return err
+	}
+	obj, err := NewObjectEncoder(r.Columns())
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString(open)
+	for n := 0; r.Next(); n++ {
+		values, err := r.Values()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			bw.WriteString(sep)
+		}
+		if err := obj.Encode(bw, values); err != nil {
+			return err
+		}
+		if open == "" {
+			bw.WriteByte('\n')
+		}
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	bw.WriteString(close)
+	return bw.Flush()
+}
+
+// ObjectEncoder encodes rows as JSON objects keyed by column name, keeping
+// the columns in order
+type ObjectEncoder struct {
+	names [][]byte
+}
+
+// NewObjectEncoder returns an encoder of rows of the columns
+func NewObjectEncoder(columns []Column) (*ObjectEncoder, error) {
+	e := &ObjectEncoder{names: make([][]byte, len(columns))}
+	for i, col := range columns {
+		name, err := json.Marshal(col.Name)
+		if err != nil {
+			return nil, err
+		}
+		e.names[i] = name
+	}
+	return e, nil
+}
+
+// Encode writes the values of a row to w as a JSON object
+func (e *ObjectEncoder) Encode(w io.Writer, values []any) error {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, v := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		b.Write(e.names[i])
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteCSV writes the rows to w as CSV with a header of the column names,
+// and closes them
+func WriteCSV(w io.Writer, rows *Rows) error {
+	defer rows.Close()
+
+	r, err := NewRowReader(rows.Rows)
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	columns := r.Columns()
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Name
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for r.Next() {
+		values, err := r.Values()
+		if err != nil {
+			return err
+		}
+		if err := cw.Write(CSVRecord(values)); err != nil {
+			return err
+		}
+	}
+	if err := r.Err(); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// CSVRecord formats normalized values as CSV fields: NULL as an empty
+// field, times in RFC 3339, binary data in base64 and arrays as JSON
+func CSVRecord(values []any) []string {
+	record := make([]string, len(values))
+	for i, v := range values {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case json.RawMessage:
+			record[i] = string(v)
+		case []byte:
+			record[i] = base64.StdEncoding.EncodeToString(v)
+		case time.Time:
+			record[i] = v.Format(time.RFC3339Nano)
+		case []any:
+			data, _ := json.Marshal(v)
+			record[i] = string(data)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return record
+}
+
+// normalize converts the value of a column for JSON by its type. Drivers
+// return many types as text: numbers become numbers, JSON is kept as is,
+// PostgreSQL arrays become arrays and times are parsed. Decimals stay text
+// to keep their precision, binary data is kept as bytes and times are in UTC.
+func normalize(col *sql.ColumnType, v any) any {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return v.UTC()
+	case string, []byte:
+	default:
+		return v
+	}
+
+	text, _ := textOf(v)
+	typ := strings.ToUpper(col.DatabaseTypeName())
+	switch {
+	case typ == "JSON" || typ == "JSONB":
+		if json.Valid([]byte(text)) {
+			return json.RawMessage(text)
+		}
+	case strings.HasPrefix(typ, "_"):
+		if elems, err := parseArray(text); err == nil {
+			return elems
+		}
+	case strings.Contains(typ, "BLOB") || strings.Contains(typ, "BINARY") || typ == "BYTEA":
+		return []byte(text)
+	case strings.Contains(typ, "INT"):
+		if n, err := toInt(text); err == nil {
+			return n
+		}
+	case strings.Contains(typ, "FLOAT") || strings.Contains(typ, "DOUBLE") || typ == "REAL":
+		if f, err := toFloat(text); err == nil {
+			return f
+		}
+	case strings.Contains(typ, "DATE") || strings.Contains(typ, "TIME"):
+		if t, err := toTime(text); err == nil {
+			return t
+		}
+	}
+	return text
+}
@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// statementContext bounds a statement by the timeout, unless the context
+// already has a deadline
+func statementContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// timeoutDriver is an ent driver bounding each statement by a default timeout
+type timeoutDriver struct {
+	*entsql.Driver
+	timeout time.Duration
+}
+
+// withTimeout wraps an ent driver with a default statement timeout
+func withTimeout(drv *entsql.Driver, timeout time.Duration) dialect.Driver {
+	if timeout <= 0 {
+		return drv
+	}
+	return &timeoutDriver{Driver: drv, timeout: timeout}
+}
+
+// Exec executes a statement within the timeout
+func (d *timeoutDriver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, cancel := statementContext(ctx, d.timeout)
+	defer cancel()
+	return d.Driver.Exec(ctx, query, args, v)
+}
+
+// Query runs a query within the timeout
+func (d *timeoutDriver) Query(ctx context.Context, query string, args, v any) error {
+	return queryWithin(ctx, d.timeout, v, func(ctx context.Context) error {
+		return d.Driver.Query(ctx, query, args, v)
+	})
+}
+
+// Tx starts a transaction whose statements are bounded by the timeout
+func (d *timeoutDriver) Tx(ctx context.Context) (dialect.Tx, error) {
+	return d.BeginTx(ctx, nil)
+}
+
+// BeginTx starts a transaction with options whose statements are bounded
+// by the timeout. The transaction itself is not.
+func (d *timeoutDriver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	tx, err := d.Driver.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutTx{Tx: tx, timeout: d.timeout}, nil
+}
+
+// timeoutTx is a transaction bounding each statement by a default timeout
+type timeoutTx struct {
+	dialect.Tx
+	timeout time.Duration
+}
+
+// Exec executes a statement within the timeout
+func (tx *timeoutTx) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, cancel := statementContext(ctx, tx.timeout)
+	defer cancel()
+	return tx.Tx.Exec(ctx, query, args, v)
+}
+
+// Query runs a query within the timeout
+func (tx *timeoutTx) Query(ctx context.Context, query string, args, v any) error {
+	return queryWithin(ctx, tx.timeout, v, func(ctx context.Context) error {
+		return tx.Tx.Query(ctx, query, args, v)
+	})
+}
+
+// queryWithin runs a query into the rows v within the timeout. The rows are
+// read after the query returns, so the timeout is released when they are
+// closed. Queries with a deadline of their own, such as those of migrations
+// which need the plain rows, run unchanged.
+func queryWithin(ctx context.Context, timeout time.Duration, v any, query func(context.Context) error) error {
+	rows, ok := v.(*entsql.Rows)
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || !ok || timeout <= 0 {
+		return query(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	if err := query(ctx); err != nil {
+		cancel()
+		return err
+	}
+	rows.ColumnScanner = closeFuncRows{ColumnScanner: rows.ColumnScanner, cancel: cancel}
+	return nil
+}
+
+// closeFuncRows are rows cancelling their context when closed
+type closeFuncRows struct {
+	entsql.ColumnScanner
+	cancel context.CancelFunc
+}
+
+func (r closeFuncRows) Close() error {
+	defer r.cancel()
+	return r.ColumnScanner.Close()
+}
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-template/ent"
+	"sync/atomic"
+	"time"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// txKey is the context key of the transaction a context carries
+type txKey struct{}
+
+// txState is a transaction carried by a context. ent and raw SQL calls made
+// with the context run in it.
+type txState struct {
+	tx         *sql.Tx
+	ent        *ent.Tx
+	timeout    time.Duration
+	savepoints atomic.Int64
+}
+
+// txFromContext returns the transaction carried by the context, if any
+func txFromContext(ctx context.Context) *txState {
+	st, _ := ctx.Value(txKey{}).(*txState)
+	return st
+}
+
+// WithTx runs fn in a transaction on the primary, committing it when fn
+// returns nil and rolling it back when fn fails or panics. The context fn
+// receives carries the transaction, so ent and raw SQL calls made with it
+// join the transaction, whether through tx or through the client.
+//
+// Calls nested in fn, including ent transactions and Transaction, run in a
+// savepoint of the same transaction: their failure only rolls back their own
+// changes. Nested calls ignore their options.
+func (c *Client) WithTx(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context, tx *ent.Tx) error) error {
+	if st := txFromContext(ctx); st != nil {
+		return st.savepoint(ctx, fn)
+	}
+
+	sqlTx, err := c.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+	st := &txState{tx: sqlTx, timeout: c.timeout}
+	ctx = context.WithValue(ctx, txKey{}, st)
+
+	// The ent transaction wraps sqlTx, see routingDriver.BeginTx, so its
+	// commit hooks run
+	if st.ent, err = c.Ent.BeginTx(ctx, opts); err != nil {
+		_ = sqlTx.Rollback()
+		return err
+	}
+	return run(ctx, st.ent, fn)
+}
+
+// run calls fn and commits tx, or rolls it back when fn fails or panics
+func run(ctx context.Context, tx *ent.Tx, fn func(ctx context.Context, tx *ent.Tx) error) error {
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint runs fn in a savepoint of the transaction
+func (st *txState) savepoint(ctx context.Context, fn func(ctx context.Context, tx *ent.Tx) error) error {
+	sp, err := st.begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sp.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(ctx, st.ent); err != nil {
+		if rbErr := sp.Rollback(); rbErr != nil {
+			return fmt.Errorf("tx err: %w, rb err: %v", err, rbErr)
+		}
+		return err
+	}
+	return sp.Commit()
+}
+
+// begin starts a savepoint, returned as an ent transaction whose commit
+// releases it and whose rollback rolls back to it
+func (st *txState) begin(ctx context.Context) (dialect.Tx, error) {
+	name := fmt.Sprintf("sp_%d", st.savepoints.Add(1))
+	if _, err := st.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return nil, fmt.Errorf("starting savepoint: %w", err)
+	}
+	return &savepointTx{ExecQuerier: st.dialectTx(), tx: st.tx, name: name}, nil
+}
+
+// dialectTx returns the transaction as an ent transaction
+func (st *txState) dialectTx() dialect.Tx {
+	return &timeoutTx{
+		Tx:      &entsql.Tx{Conn: entsql.Conn{ExecQuerier: st.tx}, Tx: st.tx},
+		timeout: st.timeout,
+	}
+}
+
+// savepointTx is a savepoint of a transaction seen as a transaction
+type savepointTx struct {
+	dialect.ExecQuerier
+	tx   *sql.Tx
+	name string
+}
+
+// Commit releases the savepoint, keeping its changes in the transaction
+func (sp *savepointTx) Commit() error {
+	_, err := sp.tx.Exec("RELEASE SAVEPOINT " + sp.name)
+	return err
+}
+
+// Rollback discards the changes made since the savepoint
+func (sp *savepointTx) Rollback() error {
+	_, err := sp.tx.Exec("ROLLBACK TO SAVEPOINT " + sp.name)
+	return err
+}
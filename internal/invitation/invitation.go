@@ -0,0 +1,59 @@
+package invitation
+
+import (
+	"context"
+	"fmt"
+	"go-template/ent"
+	"go-template/pkg/auth"
+	"go-template/pkg/mailer"
+	"net/url"
+	"time"
+)
+
+// Config holds invitation configuration
+type Config struct {
+	Expire    time.Duration `mapstructure:"expire"`     // How long an invitation stays valid after it is sent
+	AcceptURL string        `mapstructure:"accept_url"` // Page that accepts invitations, the token is added as ?token=
+}
+
+// Sender issues invitation tokens and emails them to invitees
+type Sender struct {
+	cfg    Config
+	jwt    auth.JWTConfig
+	mailer mailer.Mailer
+}
+
+// NewSender creates a new invitation sender
+func NewSender(cfg Config, jwt auth.JWTConfig, m mailer.Mailer) *Sender {
+	return &Sender{cfg: cfg, jwt: jwt, mailer: m}
+}
+
+// ExpiresAt returns when an invitation sent now expires
+func (s *Sender) ExpiresAt() time.Time {
+	return time.Now().Add(s.cfg.Expire)
+}
+
+// Send emails a link with a freshly signed token for the invitation.
+// Tokens are bound to the invitation version, so bumping the version
+// on resend invalidates links sent earlier.
+func (s *Sender) Send(ctx context.Context, inv *ent.Invitation, orgName string) error {
+	token, err := auth.GenerateInvitationToken(inv.ID, inv.Version, inv.OrganizationID, inv.Email, inv.ExpiresAt, s.jwt)
+	if err != nil {
+		return fmt.Errorf("generating invitation token: %w", err)
+	}
+
+	link, err := url.Parse(s.cfg.AcceptURL)
+	if err != nil {
+		return fmt.Errorf("parsing invitation accept url: %w", err)
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      inv.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", orgName),
+		Body: fmt.Sprintf("You have been invited to join %s.\n\nAccept the invitation before %s:\n%s\n",
+			orgName, inv.ExpiresAt.Format(time.RFC1123), link.String()),
+	})
+}
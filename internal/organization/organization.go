@@ -0,0 +1,68 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/membership"
+	entorg "go-template/ent/organization"
+	"go-template/ent/role"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"go-template/internal/database"
+	"go-template/internal/tenant"
+)
+
+var (
+	ErrNotFound  = errors.New("organization not found")
+	ErrNotMember = errors.New("user is not a member of the organization")
+)
+
+// AdminRole is the role name granting full control of an organization.
+// Global admins hold it in every organization.
+const AdminRole = "admin"
+
+// ResolveRole returns the name of the user's role in the organization
+func ResolveRole(ctx context.Context, db *database.Client, userID int, globalRole string, orgID int) (string, error) {
+	// Membership lookups run before the tenant is established
+	ctx = tenant.WithSystem(ctx)
+
+	m, err := db.Ent.Membership.Query().
+		Where(membership.UserID(userID), membership.OrganizationID(orgID)).
+		WithRole().
+		Only(ctx)
+	if err == nil {
+		return m.Edges.Role.Name, nil
+	}
+	if !ent.IsNotFound(err) {
+		return "", fmt.Errorf("fetching membership: %w", err)
+	}
+
+	if globalRole != AdminRole {
+		return "", ErrNotMember
+	}
+	exists, err := db.Ent.Organization.Query().Where(entorg.ID(orgID)).Exist(ctx)
+	if err != nil {
+		return "", fmt.Errorf("checking organization: %w", err)
+	}
+	if !exists {
+		return "", ErrNotFound
+	}
+	return AdminRole, nil
+}
+
+// TeamRoles returns the names of the roles the user holds through the
+// organization's teams
+func TeamRoles(ctx context.Context, db *database.Client, userID, orgID int) ([]string, error) {
+	names, err := db.Ent.Team.Query().
+		Where(team.OrganizationID(orgID), team.HasMembersWith(user.ID(userID))).
+		QueryRoles().
+		Unique(true).
+		Select(role.FieldName).
+		Strings(tenant.WithSystem(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("fetching team roles: %w", err)
+	}
+	return names, nil
+}
@@ -0,0 +1,38 @@
+// Package pii looks up users by their encrypted personal data and rotates
+// the keys it is encrypted with.
+package pii
+
+import (
+	"go-template/ent/predicate"
+	"go-template/ent/user"
+	"go-template/pkg/fieldcrypt"
+	"strings"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// EmailIs matches the user with the email, case-insensitively, through the
+// blind index. Users stored before the index existed are matched by their
+// plaintext email until a key rotation fills in their index; the generated
+// email predicates cannot be used, as they encrypt their argument.
+func EmailIs(email string) predicate.User {
+	return EmailIn(email)
+}
+
+// EmailIn matches the users with any of the emails, like EmailIs. Users
+// stored before encryption was enabled also match by their index computed
+// without a keyring until a key rotation.
+func EmailIn(emails ...string) predicate.User {
+	var indexes []string
+	lowered := make([]any, len(emails))
+	for i, email := range emails {
+		indexes = append(indexes, fieldcrypt.BlindIndexes(email)...)
+		lowered[i] = strings.ToLower(strings.TrimSpace(email))
+	}
+	return user.Or(
+		user.EmailIndexIn(indexes...),
+		user.And(user.EmailIndexIsNil(), func(s *sql.Selector) {
+			s.Where(sql.In(sql.Lower(s.C(user.FieldEmail)), lowered...))
+		}),
+	)
+}
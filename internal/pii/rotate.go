@@ -0,0 +1,132 @@
+package pii
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-template/internal/database"
+	"go-template/pkg/fieldcrypt"
+	"go-template/pkg/logger"
+)
+
+// RotateResult counts the users checked and those re-encrypted, or that
+// would be re-encrypted in a dry run
+type RotateResult struct {
+	Scanned int
+	Updated int
+}
+
+// encryptedUser is the encrypted personal data of a user row
+type encryptedUser struct {
+	id         int
+	name       string
+	email      string
+	emailIndex sql.NullString
+}
+
+// Rotate re-encrypts the personal data of all users, deleted ones included,
+// with the primary key of the keyring, in batches of batchSize rows each
+// committed on its own. Plaintext values are encrypted and stale blind
+// indexes recomputed. Rows are updated with plain SQL, so rotation changes
+// neither their version nor their update time; an interrupted rotation is
+// resumed by running it again.
+func Rotate(ctx context.Context, db *database.Client, batchSize int, dryRun bool) (RotateResult, error) {
+	var res RotateResult
+	c := fieldcrypt.Default()
+	if !c.Enabled() {
+		return res, fieldcrypt.ErrNoPrimary
+	}
+	if batchSize <= 0 {
+		return res, fmt.Errorf("batch size must be positive, got %d", batchSize)
+	}
+
+	// Rows are written back as read, so they must not come from a lagging replica
+	ctx = database.WithPrimary(ctx)
+
+	lastID := 0
+	for {
+		batch, err := readBatch(ctx, db, lastID, batchSize)
+		if err != nil {
+			return res, err
+		}
+		if len(batch) == 0 {
+			return res, nil
+		}
+		lastID = batch[len(batch)-1].id
+		res.Scanned += len(batch)
+
+		var stale []encryptedUser
+		for _, u := range batch {
+			updated, changed, err := rotateUser(c, u)
+			if err != nil {
+				return res, fmt.Errorf("rotating user %d: %w", u.id, err)
+			}
+			if changed {
+				stale = append(stale, updated)
+			}
+		}
+		res.Updated += len(stale)
+		if dryRun || len(stale) == 0 {
+			continue
+		}
+
+		err = db.Transaction(ctx, func(tx *sql.Tx) error {
+			for _, u := range stale {
+				_, err := tx.ExecContext(ctx,
+					db.Rebind("UPDATE users SET name = ?, email = ?, email_index = ? WHERE id = ?"),
+					u.name, u.email, u.emailIndex.String, u.id)
+				if err != nil {
+					return fmt.Errorf("updating user %d: %w", u.id, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return res, err
+		}
+		logger.Infof("Re-encrypted %d users up to id %d", res.Updated, lastID)
+	}
+}
+
+// readBatch reads the users after lastID, in ID order
+func readBatch(ctx context.Context, db *database.Client, lastID, limit int) ([]encryptedUser, error) {
+	rows, err := db.QueryContext(ctx,
+		db.Rebind("SELECT id, name, email, email_index FROM users WHERE id > ? ORDER BY id LIMIT ?"),
+		lastID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fetching users: %w", err)
+	}
+	defer rows.Close()
+
+	var batch []encryptedUser
+	for rows.Next() {
+		var u encryptedUser
+		if err := rows.Scan(&u.id, &u.name, &u.email, &u.emailIndex); err != nil {
+			return nil, fmt.Errorf("scanning user: %w", err)
+		}
+		batch = append(batch, u)
+	}
+	return batch, rows.Err()
+}
+
+// rotateUser returns the user re-encrypted with the primary key, and
+// whether anything changed
+func rotateUser(c *fieldcrypt.Cipher, u encryptedUser) (encryptedUser, bool, error) {
+	email, err := c.Decrypt("users.email", u.email)
+	if err != nil {
+		return u, false, err
+	}
+	index := c.BlindIndex(email)
+	if !c.NeedsRotation(u.name) && !c.NeedsRotation(u.email) && u.emailIndex.String == index {
+		return u, false, nil
+	}
+
+	if u.name, err = c.Rotate("users.name", u.name); err != nil {
+		return u, false, err
+	}
+	if u.email, err = c.Rotate("users.email", u.email); err != nil {
+		return u, false, err
+	}
+	u.emailIndex = sql.NullString{String: index, Valid: true}
+	return u, true, nil
+}
@@ -0,0 +1,217 @@
+package privacy
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/auditlog"
+	"go-template/ent/membership"
+	entsession "go-template/ent/session"
+	"go-template/ent/user"
+	"go-template/internal/softdelete"
+	"go-template/internal/tenant"
+	"go-template/pkg/publicid"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Profile is the profile.json file of an archive
+type Profile struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Role is the role.json file of an archive
+type Role struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Session is an entry of the sessions.json file of an archive
+type Session struct {
+	ID         string     `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Membership is an entry of the memberships.json file of an archive
+type Membership struct {
+	OrganizationID   string    `json:"organization_id"`
+	OrganizationName string    `json:"organization_name"`
+	Role             string    `json:"role"`
+	JoinedAt         time.Time `json:"joined_at"`
+}
+
+// AuditEntry is an entry of the audit.json file of an archive. It lists the
+// actions performed by the user and those performed on the user.
+type AuditEntry struct {
+	Action     string                 `json:"action"`
+	ByUser     bool                   `json:"by_user"` // False for actions of others on the user
+	TargetType string                 `json:"target_type"`
+	IP         string                 `json:"ip"`
+	UserAgent  string                 `json:"user_agent"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// writeArchive collects the user's data and writes it as JSON files in a
+// zip archive. The archive is written under a temporary name and renamed,
+// so a partial archive is never served.
+func (s *Service) writeArchive(ctx context.Context, userID int, name string) error {
+	files, err := s.collect(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.cfg.ExportDir, 0o700); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	path := filepath.Join(s.cfg.ExportDir, name)
+	f, err := os.OpenFile(path+".part", os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	zw := zip.NewWriter(f)
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("adding %s: %w", file.name, err)
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(file.data); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("writing %s: %w", file.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing archive: %w", err)
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// archiveFile is a JSON file of an archive
+type archiveFile struct {
+	name string
+	data interface{}
+}
+
+// collect reads the data linked to the user, across all organizations
+func (s *Service) collect(ctx context.Context, userID int) ([]archiveFile, error) {
+	ctx = softdelete.IncludeDeleted(tenant.WithSystem(ctx))
+
+	u, err := s.db.Ent.User.Query().
+		Where(user.ID(userID)).
+		WithRole().
+		Only(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching user: %w", err)
+	}
+	files := []archiveFile{{"profile.json", Profile{
+		ID:        publicid.Encode(publicid.User, u.ID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Status:    string(u.Status),
+		CreatedAt: u.CreatedAt,
+		UpdatedAt: u.UpdatedAt,
+		DeletedAt: u.DeletedAt,
+	}}}
+
+	var role *Role
+	if r := u.Edges.Role; r != nil {
+		role = &Role{ID: publicid.Encode(publicid.Role, r.ID), Name: r.Name, Description: r.Description}
+	}
+	files = append(files, archiveFile{"role.json", role})
+
+	sessions, err := s.db.Ent.Session.Query().
+		Where(entsession.UserID(userID)).
+		Order(ent.Asc(entsession.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sessions: %w", err)
+	}
+	sessionFile := make([]Session, len(sessions))
+	for i, ses := range sessions {
+		sessionFile[i] = Session{
+			ID:         publicid.Encode(publicid.Session, ses.ID),
+			UserAgent:  ses.UserAgent,
+			IP:         ses.IP,
+			CreatedAt:  ses.CreatedAt,
+			LastSeenAt: ses.LastSeenAt,
+			ExpiresAt:  ses.ExpiresAt,
+			RevokedAt:  ses.RevokedAt,
+		}
+	}
+	files = append(files, archiveFile{"sessions.json", sessionFile})
+
+	memberships, err := s.db.Ent.Membership.Query().
+		Where(membership.UserID(userID)).
+		WithOrganization().
+		WithRole().
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching memberships: %w", err)
+	}
+	membershipFile := make([]Membership, len(memberships))
+	for i, m := range memberships {
+		membershipFile[i] = Membership{
+			OrganizationID: publicid.Encode(publicid.Organization, m.OrganizationID),
+			JoinedAt:       m.CreatedAt,
+		}
+		if m.Edges.Organization != nil {
+			membershipFile[i].OrganizationName = m.Edges.Organization.Name
+		}
+		if m.Edges.Role != nil {
+			membershipFile[i].Role = m.Edges.Role.Name
+		}
+	}
+	files = append(files, archiveFile{"memberships.json", membershipFile})
+
+	entries, err := s.db.Ent.AuditLog.Query().
+		Where(auditlog.Or(
+			auditlog.ActorID(userID),
+			auditlog.And(auditlog.TargetType("user"), auditlog.TargetID(strconv.Itoa(userID))),
+		)).
+		Order(ent.Asc(auditlog.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching audit entries: %w", err)
+	}
+	auditFile := make([]AuditEntry, len(entries))
+	for i, e := range entries {
+		auditFile[i] = AuditEntry{
+			Action:     e.Action,
+			ByUser:     e.ActorID == userID,
+			TargetType: e.TargetType,
+			CreatedAt:  e.CreatedAt,
+		}
+		// Details of others acting on the user are not the user's data
+		if e.ActorID == userID {
+			auditFile[i].IP, auditFile[i].UserAgent, auditFile[i].Metadata = e.IP, e.UserAgent, e.Metadata
+		}
+	}
+	files = append(files, archiveFile{"audit.json", auditFile})
+
+	return files, nil
+}
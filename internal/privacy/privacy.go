@@ -0,0 +1,266 @@
+// Package privacy exports the data linked to a user and erases the personal
+// data of users on request.
+package privacy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/dataexport"
+	"go-template/ent/invitation"
+	entsession "go-template/ent/session"
+	"go-template/ent/user"
+	"go-template/internal/database"
+	"go-template/internal/softdelete"
+	"go-template/internal/tenant"
+	"go-template/pkg/auth"
+	"go-template/pkg/logger"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrNotReady is returned for downloads of exports that are not built yet
+var ErrNotReady = errors.New("data export is not ready")
+
+// buildTimeout bounds how long an archive takes to build. Exports pending
+// for longer were interrupted, e.g. by a restart, and count as failed.
+const buildTimeout = 10 * time.Minute
+
+// Config holds privacy configuration
+type Config struct {
+	ExportDir   string        `mapstructure:"export_dir"`   // Directory export archives are written to
+	ExportTTL   time.Duration `mapstructure:"export_ttl"`   // How long archives and their download links stay available
+	DownloadURL string        `mapstructure:"download_url"` // Endpoint serving archives, the token is added as ?token=
+}
+
+// Service builds data exports and erases users
+type Service struct {
+	db  *database.Client
+	cfg Config
+	jwt auth.JWTConfig
+}
+
+// NewService creates a new privacy service
+func NewService(db *database.Client, cfg Config, jwt auth.JWTConfig) *Service {
+	return &Service{db: db, cfg: cfg, jwt: jwt}
+}
+
+// RequestExport starts building an archive of the user's data in the
+// background. An export still being built for the user is returned instead
+// of starting another one.
+func (s *Service) RequestExport(ctx context.Context, userID int) (*ent.DataExport, error) {
+	s.removeExpired(ctx)
+
+	pending, err := s.db.Ent.DataExport.Query().
+		Where(
+			dataexport.UserID(userID),
+			dataexport.StatusEQ(dataexport.StatusPending),
+			dataexport.CreatedAtGT(time.Now().Add(-buildTimeout)),
+		).
+		First(ctx)
+	if err == nil {
+		return pending, nil
+	}
+	if !ent.IsNotFound(err) {
+		return nil, fmt.Errorf("fetching pending export: %w", err)
+	}
+
+	export, err := s.db.Ent.DataExport.Create().
+		SetUserID(userID).
+		SetExpiresAt(time.Now().Add(s.cfg.ExportTTL)).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating export: %w", err)
+	}
+
+	// The request context ends with the response, the build outlives it
+	go s.build(export)
+
+	return export, nil
+}
+
+// GetExport returns an export of the user
+func (s *Service) GetExport(ctx context.Context, userID, exportID int) (*ent.DataExport, error) {
+	export, err := s.db.Ent.DataExport.Query().
+		Where(dataexport.ID(exportID), dataexport.UserID(userID)).
+		Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if export.Status == dataexport.StatusPending && time.Since(export.CreatedAt) > buildTimeout {
+		export.Status, export.Error = dataexport.StatusFailed, "export was interrupted"
+	}
+	return export, nil
+}
+
+// DownloadURL returns a signed link to the archive of a ready export. The
+// link expires with the archive.
+func (s *Service) DownloadURL(export *ent.DataExport) (string, error) {
+	token, err := auth.GenerateExportToken(export.ID, export.ExpiresAt, s.jwt)
+	if err != nil {
+		return "", fmt.Errorf("generating export token: %w", err)
+	}
+
+	link, err := url.Parse(s.cfg.DownloadURL)
+	if err != nil {
+		return "", fmt.Errorf("parsing export download url: %w", err)
+	}
+	q := link.Query()
+	q.Set("token", token)
+	link.RawQuery = q.Encode()
+	return link.String(), nil
+}
+
+// OpenDownload returns the path of the archive of a download token's export
+func (s *Service) OpenDownload(ctx context.Context, token string) (string, *ent.DataExport, error) {
+	claims, err := auth.ParseExportToken(token, s.jwt.Secret)
+	if err != nil {
+		return "", nil, err
+	}
+
+	export, err := s.db.Ent.DataExport.Get(ctx, claims.ExportID)
+	if err != nil {
+		return "", nil, err
+	}
+	if export.Status != dataexport.StatusReady || time.Now().After(export.ExpiresAt) {
+		return "", nil, ErrNotReady
+	}
+	return filepath.Join(s.cfg.ExportDir, export.File), export, nil
+}
+
+// build writes the archive of an export and records the outcome
+func (s *Service) build(export *ent.DataExport) {
+	ctx, cancel := context.WithTimeout(context.Background(), buildTimeout)
+	defer cancel()
+
+	file := fmt.Sprintf("%s-%d.zip", publicid.Encode(publicid.DataExport, export.ID), time.Now().Unix())
+	update := s.db.Ent.DataExport.UpdateOne(export)
+	if err := s.writeArchive(ctx, export.UserID, file); err != nil {
+		logger.Errorf("Failed to build data export %d: %v", export.ID, err)
+		update = update.SetStatus(dataexport.StatusFailed).SetError("failed to build export")
+	} else {
+		update = update.SetStatus(dataexport.StatusReady).SetFile(file)
+	}
+	if err := update.Exec(ctx); err != nil {
+		logger.Errorf("Failed to update data export %d: %v", export.ID, err)
+	}
+}
+
+// removeExpired deletes expired exports and their archives. Failures only
+// delay the cleanup to the next export, so they are logged.
+func (s *Service) removeExpired(ctx context.Context) {
+	expired, err := s.db.Ent.DataExport.Query().
+		Where(dataexport.ExpiresAtLT(time.Now())).
+		All(ctx)
+	if err != nil {
+		logger.Errorf("Failed to fetch expired exports: %v", err)
+		return
+	}
+	s.remove(ctx, expired)
+}
+
+// remove deletes exports and their archives
+func (s *Service) remove(ctx context.Context, exports []*ent.DataExport) {
+	ids := make([]int, 0, len(exports))
+	for _, export := range exports {
+		if s.removeArchive(export) {
+			ids = append(ids, export.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if _, err := s.db.Ent.DataExport.Delete().Where(dataexport.IDIn(ids...)).Exec(ctx); err != nil {
+		logger.Errorf("Failed to delete exports: %v", err)
+	}
+}
+
+// removeArchive deletes the archive file of an export, reporting success
+func (s *Service) removeArchive(export *ent.DataExport) bool {
+	if export.File == "" {
+		return true
+	}
+	err := os.Remove(filepath.Join(s.cfg.ExportDir, export.File))
+	if err != nil && !os.IsNotExist(err) {
+		logger.Errorf("Failed to remove export archive: %v", err)
+		return false
+	}
+	return true
+}
+
+// Erase anonymizes the personal data of a user. The user row is kept,
+// soft-deleted and disabled, so references to it stay valid; audit records
+// are kept as they are. Sessions, which hold devices and IP addresses,
+// invitations sent to the user's email and data exports are deleted.
+func (s *Service) Erase(ctx context.Context, userID int) error {
+	// Erasure reaches invitations of all organizations
+	ctx = softdelete.IncludeDeleted(tenant.WithSystem(ctx))
+
+	u, err := s.db.Ent.User.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// A password nobody knows, so the account can never sign in again
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("generating password: %w", err)
+	}
+	hashed, err := password.Hash(hex.EncodeToString(secret))
+	if err != nil {
+		return fmt.Errorf("hashing password: %w", err)
+	}
+
+	exports, err := s.db.Ent.DataExport.Query().Where(dataexport.UserID(userID)).All(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching exports: %w", err)
+	}
+
+	err = s.db.WithTx(ctx, nil, func(ctx context.Context, tx *ent.Tx) error {
+		return erase(ctx, tx, u, hashed)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Archives hold copies of the erased data
+	for _, export := range exports {
+		s.removeArchive(export)
+	}
+	return nil
+}
+
+// erase anonymizes the user and deletes the rows holding their personal data
+func erase(ctx context.Context, tx *ent.Tx, u *ent.User, hashed string) error {
+	publicID := publicid.Encode(publicid.User, u.ID)
+	now := time.Now()
+
+	err := tx.User.UpdateOneID(u.ID).
+		SetName("Erased user").
+		SetEmail(publicID + "@erased.invalid"). // Unique, and never deliverable
+		SetPassword(hashed).
+		SetStatus(user.StatusDisabled).
+		SetDeletedAt(now).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("anonymizing user: %w", err)
+	}
+
+	if _, err := tx.Session.Delete().Where(entsession.UserID(u.ID)).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting sessions: %w", err)
+	}
+	if _, err := tx.Invitation.Delete().Where(invitation.EmailEqualFold(u.Email)).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting invitations: %w", err)
+	}
+	if _, err := tx.DataExport.Delete().Where(dataexport.UserID(u.ID)).Exec(ctx); err != nil {
+		return fmt.Errorf("deleting exports: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package purge
+
+import (
+	"context"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/membership"
+	"go-template/ent/role"
+	entsession "go-template/ent/session"
+	"go-template/ent/team"
+	"go-template/ent/user"
+	"go-template/internal/database"
+	"go-template/internal/softdelete"
+	"go-template/internal/tenant"
+	"time"
+)
+
+// Config holds purge configuration
+type Config struct {
+	Retention time.Duration `mapstructure:"retention"` // How long soft-deleted rows are kept before purging
+}
+
+// Result counts the rows purged, or that would be purged in a dry run
+type Result struct {
+	Users int
+	Roles int
+}
+
+// Run permanently deletes users and roles soft-deleted before the cutoff.
+// Audit records reference users by ID only and are kept.
+func Run(ctx context.Context, db *database.Client, cutoff time.Time, dryRun bool) (Result, error) {
+	// Purging spans all tenants and must see soft-deleted rows, as they are
+	// on the primary
+	ctx = database.WithPrimary(softdelete.Hard(tenant.WithSystem(ctx)))
+
+	var res Result
+
+	userIDs, err := db.Ent.User.Query().
+		Where(user.DeletedAtLT(cutoff)).
+		IDs(ctx)
+	if err != nil {
+		return res, fmt.Errorf("fetching deleted users: %w", err)
+	}
+
+	// Roles still referenced by users, memberships or teams are kept until
+	// those go away
+	roleIDs, err := db.Ent.Role.Query().
+		Where(
+			role.DeletedAtLT(cutoff),
+			role.Not(role.HasUsers()),
+			role.Not(role.HasMemberships()),
+			role.Not(role.HasTeams()),
+		).
+		IDs(ctx)
+	if err != nil {
+		return res, fmt.Errorf("fetching deleted roles: %w", err)
+	}
+
+	if dryRun {
+		return Result{Users: len(userIDs), Roles: len(roleIDs)}, nil
+	}
+
+	tx, err := db.Ent.Tx(ctx)
+	if err != nil {
+		return res, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	res, err = purge(ctx, tx, userIDs, roleIDs)
+	if err != nil {
+		_ = tx.Rollback()
+		return Result{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return Result{}, fmt.Errorf("committing purge: %w", err)
+	}
+
+	return res, nil
+}
+
+// purge deletes the users with their sessions and memberships, organization
+// and team, then the roles
+func purge(ctx context.Context, tx *ent.Tx, userIDs, roleIDs []int) (Result, error) {
+	var res Result
+
+	if len(userIDs) > 0 {
+		if _, err := tx.Session.Delete().Where(entsession.UserIDIn(userIDs...)).Exec(ctx); err != nil {
+			return res, fmt.Errorf("deleting sessions: %w", err)
+		}
+		if _, err := tx.Membership.Delete().Where(membership.UserIDIn(userIDs...)).Exec(ctx); err != nil {
+			return res, fmt.Errorf("deleting memberships: %w", err)
+		}
+		err := tx.Team.Update().
+			Where(team.HasMembersWith(user.IDIn(userIDs...))).
+			RemoveMemberIDs(userIDs...).
+			Exec(ctx)
+		if err != nil {
+			return res, fmt.Errorf("deleting team memberships: %w", err)
+		}
+		n, err := tx.User.Delete().Where(user.IDIn(userIDs...)).Exec(ctx)
+		if err != nil {
+			return res, fmt.Errorf("deleting users: %w", err)
+		}
+		res.Users = n
+	}
+
+	if len(roleIDs) > 0 {
+		n, err := tx.Role.Delete().Where(role.IDIn(roleIDs...)).Exec(ctx)
+		if err != nil {
+			return res, fmt.Errorf("deleting roles: %w", err)
+		}
+		res.Roles = n
+	}
+
+	return res, nil
+}
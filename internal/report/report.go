@@ -0,0 +1,162 @@
+// Package report computes the user and role reports of administrators from
+// named raw queries, and runs the bulk actions offered next to them.
+package report
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	"go-template/internal/audit"
+	"go-template/internal/database"
+	"strconv"
+	"time"
+)
+
+// ErrRoleNotFound is returned for actions on roles that do not exist
+var ErrRoleNotFound = errors.New("role not found")
+
+// Config holds reporting configuration
+type Config struct {
+	Timeout time.Duration `mapstructure:"timeout"` // Time a report or action may take, 0 for the database statement timeout
+}
+
+// UserStats are the user counts by status and role
+type UserStats struct {
+	TotalUsers     int         `json:"total_users" db:"total_users"`
+	ActiveUsers    int         `json:"active_users" db:"active_users"`
+	DisabledUsers  int         `json:"disabled_users" db:"disabled_users"`
+	NewestUserDate *time.Time  `json:"newest_user_date" db:"-"`
+	UsersPerRole   []RoleCount `json:"users_per_role" db:"-"`
+}
+
+// RoleCount is the number of users of a role
+type RoleCount struct {
+	Role  string `json:"role" db:"role"`
+	Count int    `json:"count" db:"count"`
+}
+
+// RoleUserCount is a role with its number of users
+type RoleUserCount struct {
+	RoleID      int    `db:"role_id"`
+	RoleName    string `db:"role_name"`
+	Description string `db:"description"`
+	UserCount   int    `db:"user_count"`
+}
+
+// Service runs reports in read-only transactions and audited actions
+type Service struct {
+	db      *database.Client
+	auditor *audit.Recorder
+	cfg     Config
+}
+
+// NewService creates a new report service
+func NewService(db *database.Client, auditor *audit.Recorder, cfg Config) *Service {
+	return &Service{db: db, auditor: auditor, cfg: cfg}
+}
+
+// UserStats counts the users by status and role
+func (s *Service) UserStats(ctx context.Context) (*UserStats, error) {
+	var stats UserStats
+	err := s.read(ctx, func(ctx context.Context) error {
+		var err error
+		if stats, err = database.NamedOne[UserStats](ctx, s.db, "user_stats", nil); err != nil {
+			return err
+		}
+
+		newest, err := database.NamedOne[time.Time](ctx, s.db, "newest_user_date", nil)
+		switch {
+		case err == nil:
+			stats.NewestUserDate = &newest
+		case !errors.Is(err, sql.ErrNoRows):
+			return err
+		}
+
+		stats.UsersPerRole, err = database.NamedAll[RoleCount](ctx, s.db, "users_per_role", nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("computing user stats: %w", err)
+	}
+	return &stats, nil
+}
+
+// RoleUserCounts counts the users of each shared role, most used first
+func (s *Service) RoleUserCounts(ctx context.Context) ([]RoleUserCount, error) {
+	var counts []RoleUserCount
+	err := s.read(ctx, func(ctx context.Context) error {
+		var err error
+		counts, err = database.NamedAll[RoleUserCount](ctx, s.db, "role_user_counts", nil)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("counting role users: %w", err)
+	}
+	return counts, nil
+}
+
+// ActivateRoleUsers activates the disabled users of a shared role and
+// returns how many were activated and how many users of the role are now
+// active. The change and its audit entry, completed with the role, are
+// committed together.
+func (s *Service) ActivateRoleUsers(ctx context.Context, roleName string, entry audit.Entry) (activated int64, active int, err error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err = s.db.WithTx(ctx, nil, func(ctx context.Context, _ *ent.Tx) error {
+		roleID, err := database.NamedOne[int](ctx, s.db, "role_id_by_name", database.Params{"role_name": roleName})
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrRoleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var updatedBy any
+		if entry.ActorID != 0 {
+			updatedBy = entry.ActorID
+		}
+		res, err := s.db.NamedExec(ctx, "activate_role_users", database.Params{"role_id": roleID, "updated_by": updatedBy})
+		if err != nil {
+			return err
+		}
+		if activated, err = res.RowsAffected(); err != nil {
+			return err
+		}
+
+		if active, err = database.NamedOne[int](ctx, s.db, "active_users_by_role", database.Params{"role_id": roleID}); err != nil {
+			return err
+		}
+
+		entry.TargetID = strconv.Itoa(roleID)
+		entry.Metadata = map[string]interface{}{
+			"role":      roleName,
+			"activated": activated,
+		}
+		return s.auditor.Record(ctx, entry)
+	})
+	if err != nil && !errors.Is(err, ErrRoleNotFound) {
+		err = fmt.Errorf("activating role users: %w", err)
+	}
+	return activated, active, err
+}
+
+// read runs fn in a read-only transaction bounded by the report timeout
+func (s *Service) read(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.db.WithTx(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, _ *ent.Tx) error {
+		return fn(ctx)
+	})
+}
+
+// withTimeout bounds the context by the report timeout, if any
+func (s *Service) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.cfg.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, s.cfg.Timeout)
+}
@@ -0,0 +1,239 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	entsession "go-template/ent/session"
+	"go-template/internal/database"
+	"go-template/pkg/logger"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("session not found")
+	ErrRevoked  = errors.New("session revoked or expired")
+)
+
+// Config holds session tracking configuration
+type Config struct {
+	CacheTTL      time.Duration `mapstructure:"cache_ttl"`      // How long a revocation check result is cached
+	TouchInterval time.Duration `mapstructure:"touch_interval"` // Minimum interval between last-seen updates
+}
+
+// Device describes where a session was created from
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// sweepThreshold is the cache size above which stale entries are swept
+const sweepThreshold = 1024
+
+// cacheEntry caches the result of an active-session check
+type cacheEntry struct {
+	active    bool
+	checkedAt time.Time
+	touchedAt time.Time
+}
+
+// Store manages user sessions and caches revocation checks
+type Store struct {
+	db    *database.Client
+	cfg   Config
+	mu    sync.Mutex
+	cache map[int]*cacheEntry
+}
+
+// NewStore creates a new session store
+func NewStore(db *database.Client, cfg Config) *Store {
+	return &Store{
+		db:    db,
+		cfg:   cfg,
+		cache: make(map[int]*cacheEntry),
+	}
+}
+
+// Create records a new session for a user
+func (s *Store) Create(ctx context.Context, userID int, device Device, expiresAt time.Time) (*ent.Session, error) {
+	sess, err := s.db.Ent.Session.Create().
+		SetUserID(userID).
+		SetUserAgent(device.UserAgent).
+		SetIP(device.IP).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating session: %w", err)
+	}
+	return sess, nil
+}
+
+// ListActive returns the active sessions of a user, most recently used first
+func (s *Store) ListActive(ctx context.Context, userID int) ([]*ent.Session, error) {
+	return s.db.Ent.Session.Query().
+		Where(
+			entsession.UserID(userID),
+			entsession.RevokedAtIsNil(),
+			entsession.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(entsession.FieldLastSeenAt)).
+		All(ctx)
+}
+
+// Extend validates that a session of the user is still active and pushes
+// its expiry forward, as done on token refresh
+func (s *Store) Extend(ctx context.Context, userID, id int, expiresAt time.Time) error {
+	n, err := s.db.Ent.Session.Update().
+		Where(
+			entsession.ID(id),
+			entsession.UserID(userID),
+			entsession.RevokedAtIsNil(),
+			entsession.ExpiresAtGT(time.Now()),
+		).
+		SetExpiresAt(expiresAt).
+		SetLastSeenAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("extending session: %w", err)
+	}
+	if n == 0 {
+		return ErrRevoked
+	}
+	return nil
+}
+
+// Revoke revokes an active session owned by the user
+func (s *Store) Revoke(ctx context.Context, userID, id int) error {
+	n, err := s.db.Ent.Session.Update().
+		Where(
+			entsession.ID(id),
+			entsession.UserID(userID),
+			entsession.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("revoking session: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	s.invalidate(id)
+	return nil
+}
+
+// RevokeAll revokes every active session of the user except keepID,
+// returning the number of sessions revoked. Pass zero to revoke all.
+func (s *Store) RevokeAll(ctx context.Context, userID, keepID int) (int, error) {
+	ids, err := s.db.Ent.Session.Query().
+		Where(
+			entsession.UserID(userID),
+			entsession.IDNEQ(keepID),
+			entsession.RevokedAtIsNil(),
+		).
+		IDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing sessions: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	n, err := s.db.Ent.Session.Update().
+		Where(entsession.IDIn(ids...)).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("revoking sessions: %w", err)
+	}
+
+	for _, id := range ids {
+		s.invalidate(id)
+	}
+	return n, nil
+}
+
+// IsActive reports whether the session is neither revoked nor expired.
+// Results are cached for CacheTTL, so a revocation made on another instance
+// takes at most that long to be seen. The session's last-seen time is
+// updated at most once per TouchInterval.
+func (s *Store) IsActive(ctx context.Context, id int) (bool, error) {
+	now := time.Now()
+
+	// Entries are only read and changed under the lock, so a copy is taken
+	s.mu.Lock()
+	var entry cacheEntry
+	cached, ok := s.cache[id]
+	if ok {
+		entry = *cached
+	}
+	s.mu.Unlock()
+
+	if !ok || now.Sub(entry.checkedAt) >= s.cfg.CacheTTL {
+		// Replicas may not have a session created or revoked just before
+		sess, err := s.db.Ent.Session.Get(database.WithPrimary(ctx), id)
+		if err != nil && !ent.IsNotFound(err) {
+			return false, fmt.Errorf("checking session: %w", err)
+		}
+
+		active := sess != nil && sess.RevokedAt == nil && sess.ExpiresAt.After(now)
+		entry = cacheEntry{active: active, checkedAt: now}
+		if sess != nil {
+			entry.touchedAt = sess.LastSeenAt
+		}
+		s.store(id, entry)
+	}
+
+	if entry.active && s.claimTouch(id, now) {
+		s.touch(ctx, id, now)
+	}
+
+	return entry.active, nil
+}
+
+// claimTouch reports whether the last-seen time of a cached session is due
+// for an update, and marks it updated so that concurrent requests do not
+// update it too
+func (s *Store) claimTouch(id int, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.cache[id]
+	if !ok || now.Sub(entry.touchedAt) < s.cfg.TouchInterval {
+		return false
+	}
+	entry.touchedAt = now
+	return true
+}
+
+// touch updates the last-seen time of a session
+func (s *Store) touch(ctx context.Context, id int, now time.Time) {
+	if err := s.db.Ent.Session.UpdateOneID(id).SetLastSeenAt(now).Exec(ctx); err != nil {
+		logger.Warnf("Failed to update last seen time of session %d: %v", id, err)
+	}
+}
+
+// store caches a check result, sweeping stale entries once the cache grows large
+func (s *Store) store(id int, entry cacheEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.cache) >= sweepThreshold {
+		for key, e := range s.cache {
+			if entry.checkedAt.Sub(e.checkedAt) >= s.cfg.CacheTTL {
+				delete(s.cache, key)
+			}
+		}
+	}
+	s.cache[id] = &entry
+}
+
+// invalidate drops the cached check result of a session
+func (s *Store) invalidate(id int) {
+	s.mu.Lock()
+	delete(s.cache, id)
+	s.mu.Unlock()
+}
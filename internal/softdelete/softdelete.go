@@ -0,0 +1,32 @@
+// Package softdelete carries soft-delete options through a context. Like the
+// tenant package it holds no ent code, so schemas can import it.
+package softdelete
+
+import "context"
+
+type includeDeletedKey struct{}
+
+type hardDeleteKey struct{}
+
+// IncludeDeleted returns a context whose queries also return soft-deleted rows
+func IncludeDeleted(ctx context.Context) context.Context {
+	return context.WithValue(ctx, includeDeletedKey{}, true)
+}
+
+// IncludesDeleted reports whether queries made with ctx return soft-deleted rows
+func IncludesDeleted(ctx context.Context) bool {
+	v, _ := ctx.Value(includeDeletedKey{}).(bool)
+	return v
+}
+
+// Hard returns a context whose deletes remove rows permanently. It also
+// includes soft-deleted rows, so they can be purged.
+func Hard(ctx context.Context) context.Context {
+	return context.WithValue(IncludeDeleted(ctx), hardDeleteKey{}, true)
+}
+
+// IsHard reports whether deletes made with ctx remove rows permanently
+func IsHard(ctx context.Context) bool {
+	v, _ := ctx.Value(hardDeleteKey{}).(bool)
+	return v
+}
@@ -0,0 +1,224 @@
+// Package sqlconsole runs the ad-hoc read-only SQL of admins: single SELECT
+// statements on allowed tables, in read-only transactions bounded in time
+// and rows, recorded in a per-admin history.
+package sqlconsole
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/consolequery"
+	"go-template/internal/database"
+	"go-template/pkg/logger"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect"
+)
+
+// Config holds SQL console configuration
+type Config struct {
+	AllowedTables []string      `mapstructure:"allowed_tables"` // Tables queries may read, e.g. "roles" or "public.roles"; none when empty
+	MaxRows       int           `mapstructure:"max_rows"`       // Rows returned at most by a query
+	Timeout       time.Duration `mapstructure:"timeout"`        // Time a query may take
+}
+
+// Output receives the result of a query as its rows are read
+type Output interface {
+	// Columns is called with the columns of the result before any row
+	Columns(columns []database.Column) error
+	// Row is called with the normalized values of each row
+	Row(values []any) error
+}
+
+// Result summarizes a query that ran
+type Result struct {
+	ID        int
+	RowCount  int
+	Truncated bool // More rows than the row cap were available
+	Duration  time.Duration
+}
+
+// Service runs console queries
+type Service struct {
+	db      *database.Client
+	cfg     Config
+	allowed map[string]bool
+}
+
+// NewService creates a new SQL console service
+func NewService(db *database.Client, cfg Config) *Service {
+	allowed := make(map[string]bool, len(cfg.AllowedTables))
+	for _, table := range cfg.AllowedTables {
+		allowed[strings.ToLower(table)] = true
+	}
+	return &Service{db: db, cfg: cfg, allowed: allowed}
+}
+
+// MaxRows returns the row cap of queries
+func (s *Service) MaxRows() int {
+	return s.cfg.MaxRows
+}
+
+// Run checks a query of an admin and runs it, passing the columns and at
+// most maxRows rows to out, or the configured cap when maxRows is zero or
+// above it. The query is recorded in the admin's history before it runs;
+// refused queries are recorded too and return an error wrapping
+// ErrRejected.
+func (s *Service) Run(ctx context.Context, userID int, query, format string, maxRows int, out Output) (*Result, error) {
+	if maxRows <= 0 || maxRows > s.cfg.MaxRows {
+		maxRows = s.cfg.MaxRows
+	}
+
+	stmt, err := s.check(query)
+	if err != nil {
+		s.record(ctx, userID, query, format, consolequery.StatusRejected, err)
+		return nil, err
+	}
+
+	// Nothing runs unless it is in the history
+	entry, err := s.db.Ent.ConsoleQuery.Create().
+		SetUserID(userID).
+		SetQuery(query).
+		SetFormat(consolequery.Format(format)).
+		SetStatus(consolequery.StatusRunning).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("recording console query: %w", err)
+	}
+
+	result := &Result{ID: entry.ID}
+	start := time.Now()
+	err = s.run(ctx, stmt, maxRows, out, result)
+	result.Duration = time.Since(start)
+
+	status, message := consolequery.StatusSucceeded, ""
+	if err != nil {
+		status, message = consolequery.StatusFailed, err.Error()
+	}
+	// The history is updated even when the request was cancelled
+	if updateErr := s.db.Ent.ConsoleQuery.UpdateOneID(entry.ID).
+		SetStatus(status).
+		SetError(message).
+		SetRowCount(result.RowCount).
+		SetTruncated(result.Truncated).
+		SetDurationMs(result.Duration.Milliseconds()).
+		Exec(context.WithoutCancel(ctx)); updateErr != nil {
+		logger.Errorf("Failed to update console query %d: %v", entry.ID, updateErr)
+	}
+	return result, err
+}
+
+// check parses a query and checks that it only reads allowed tables
+func (s *Service) check(query string) (*Statement, error) {
+	stmt, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	for _, table := range stmt.Tables {
+		if !s.allowed[table] {
+			return nil, rejectf("table %s is not allowed", table)
+		}
+	}
+	return stmt, nil
+}
+
+// run runs a statement in a read-only transaction bounded by the timeout
+func (s *Service) run(ctx context.Context, stmt *Statement, maxRows int, out Output, result *Result) error {
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+
+	return s.db.WithTx(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, _ *ent.Tx) error {
+		switch s.db.Dialect() {
+		case dialect.Postgres:
+			// The server stops the query too if the client goes away
+			if s.cfg.Timeout > 0 {
+				if _, err := s.db.ExecContext(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", s.cfg.Timeout.Milliseconds())); err != nil {
+					return err
+				}
+			}
+		case dialect.SQLite:
+			// SQLite ignores read-only transactions, but not read-only connections
+			if _, err := s.db.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+				return err
+			}
+			defer func() {
+				if _, err := s.db.ExecContext(context.WithoutCancel(ctx), "PRAGMA query_only = OFF"); err != nil {
+					logger.Errorf("Failed to restore SQLite connection: %v", err)
+				}
+			}()
+		}
+
+		rows, err := s.db.QueryContext(ctx, stmt.SQL)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		reader, err := database.NewRowReader(rows.Rows)
+		if err != nil {
+			return err
+		}
+		if err := out.Columns(reader.Columns()); err != nil {
+			return err
+		}
+		for reader.Next() {
+			if result.RowCount == maxRows {
+				result.Truncated = true
+				break
+			}
+			values, err := reader.Values()
+			if err != nil {
+				return err
+			}
+			if err := out.Row(values); err != nil {
+				return err
+			}
+			result.RowCount++
+		}
+		return reader.Err()
+	})
+}
+
+// record adds a query that did not run to the history
+func (s *Service) record(ctx context.Context, userID int, query, format string, status consolequery.Status, cause error) {
+	err := s.db.Ent.ConsoleQuery.Create().
+		SetUserID(userID).
+		SetQuery(query).
+		SetFormat(consolequery.Format(format)).
+		SetStatus(status).
+		SetError(cause.Error()).
+		Exec(ctx)
+	if err != nil {
+		logger.Errorf("Failed to record console query: %v", err)
+	}
+}
+
+// History returns a page of the console queries of an admin, newest first,
+// and their total
+func (s *Service) History(ctx context.Context, userID, offset, limit int) ([]*ent.ConsoleQuery, int, error) {
+	query := s.db.Ent.ConsoleQuery.Query().Where(consolequery.UserID(userID))
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("counting console queries: %w", err)
+	}
+	entries, err := query.
+		Order(ent.Desc(consolequery.FieldCreatedAt), ent.Desc(consolequery.FieldID)).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing console queries: %w", err)
+	}
+	return entries, total, nil
+}
+
+// IsRejected reports whether a query was refused before running
+func IsRejected(err error) bool {
+	return errors.Is(err, ErrRejected)
+}
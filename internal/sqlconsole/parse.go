@@ -0,0 +1,362 @@
+package sqlconsole
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRejected wraps the reasons console queries are refused
+var ErrRejected = errors.New("query rejected")
+
+// rejectf returns an error refusing a query
+func rejectf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrRejected, fmt.Sprintf(format, args...))
+}
+
+// tokenKind is the kind of a lexical token of a query
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // Keyword or unquoted identifier, upper-cased
+	tokenQuoted                  // Quoted identifier, e.g. "users" or `users`
+	tokenString                  // String literal
+	tokenNumber
+	tokenSymbol // Punctuation and operators
+)
+
+// token is a lexical token of a query
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// deniedWords are keywords that write, lock, change settings or reach
+// outside the database, and table references the table check cannot follow.
+// The read-only transaction catches writes too; these are refused before
+// anything runs.
+var deniedWords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true,
+	"REPLACE": true, "CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true,
+	"RENAME": true, "GRANT": true, "REVOKE": true, "COPY": true, "CALL": true,
+	"EXEC": true, "EXECUTE": true, "DO": true, "SET": true, "RESET": true,
+	"LOCK": true, "UNLOCK": true, "INTO": true, "OUTFILE": true, "DUMPFILE": true,
+	"ATTACH": true, "DETACH": true, "PRAGMA": true, "VACUUM": true, "LISTEN": true,
+	"NOTIFY": true, "PREPARE": true, "DEALLOCATE": true, "HANDLER": true,
+	// Table references
+	"TABLE": true, "LATERAL": true, "ONLY": true,
+}
+
+// allowedFunctions are the functions queries may call. Functions can read
+// tables, run SQL given as text or act on the server, so any other is
+// refused.
+var allowedFunctions = map[string]bool{
+	// Aggregates
+	"COUNT": true, "SUM": true, "AVG": true, "MIN": true, "MAX": true,
+	"STRING_AGG": true, "GROUP_CONCAT": true, "ARRAY_AGG": true, "JSON_AGG": true,
+	"JSONB_AGG": true, "BOOL_AND": true, "BOOL_OR": true, "EVERY": true,
+	"STDDEV": true, "STDDEV_POP": true, "STDDEV_SAMP": true, "VARIANCE": true,
+	"VAR_POP": true, "VAR_SAMP": true, "PERCENTILE_CONT": true, "PERCENTILE_DISC": true,
+	// Window functions
+	"ROW_NUMBER": true, "RANK": true, "DENSE_RANK": true, "PERCENT_RANK": true,
+	"CUME_DIST": true, "NTILE": true, "LAG": true, "LEAD": true,
+	"FIRST_VALUE": true, "LAST_VALUE": true, "NTH_VALUE": true,
+	// Conditionals
+	"COALESCE": true, "NULLIF": true, "IFNULL": true, "IF": true, "IIF": true,
+	"GREATEST": true, "LEAST": true,
+	// Strings
+	"LOWER": true, "UPPER": true, "LENGTH": true, "CHAR_LENGTH": true,
+	"CHARACTER_LENGTH": true, "OCTET_LENGTH": true, "SUBSTR": true, "SUBSTRING": true,
+	"TRIM": true, "LTRIM": true, "RTRIM": true, "BTRIM": true, "CONCAT": true,
+	"CONCAT_WS": true, "POSITION": true, "STRPOS": true, "INSTR": true, "LEFT": true,
+	"RIGHT": true, "LPAD": true, "RPAD": true, "SPLIT_PART": true, "REVERSE": true,
+	"MD5": true,
+	// Numbers
+	"ABS": true, "ROUND": true, "FLOOR": true, "CEIL": true, "CEILING": true,
+	"MOD": true, "POWER": true, "SQRT": true, "SIGN": true, "TRUNC": true,
+	// Dates and times
+	"NOW": true, "EXTRACT": true, "DATE_TRUNC": true, "DATE_PART": true, "DATE": true,
+	"TIME": true, "DATETIME": true, "STRFTIME": true, "JULIANDAY": true, "AGE": true,
+	"DATE_FORMAT": true, "DATEDIFF": true, "TIMESTAMPDIFF": true, "TO_CHAR": true,
+	"TO_DATE": true, "TO_TIMESTAMP": true, "UNIX_TIMESTAMP": true, "FROM_UNIXTIME": true,
+	// JSON and arrays
+	"JSON_EXTRACT": true, "JSON_UNQUOTE": true, "JSON_VALUE": true, "JSON_TYPEOF": true,
+	"JSONB_TYPEOF": true, "JSON_ARRAY_LENGTH": true, "JSONB_ARRAY_LENGTH": true,
+	"JSON_EXTRACT_PATH_TEXT": true, "JSONB_EXTRACT_PATH_TEXT": true,
+	"ARRAY_LENGTH": true, "CARDINALITY": true,
+	// Conversions and types with a length or precision
+	"CAST": true, "CONVERT": true, "VARCHAR": true, "CHAR": true, "CHARACTER": true,
+	"VARYING": true, "DECIMAL": true, "NUMERIC": true, "TIMESTAMP": true,
+}
+
+// syntaxWords are the keywords that may be followed by a parenthesis without
+// calling a function
+var syntaxWords = map[string]bool{
+	"SELECT": true, "WHERE": true, "HAVING": true, "BY": true, "ON": true,
+	"USING": true, "AND": true, "OR": true, "NOT": true, "IN": true, "EXISTS": true,
+	"ANY": true, "ALL": true, "SOME": true, "IS": true, "LIKE": true, "ILIKE": true,
+	"BETWEEN": true, "ESCAPE": true, "CASE": true, "WHEN": true, "THEN": true,
+	"ELSE": true, "AS": true, "DISTINCT": true, "OVER": true, "FILTER": true,
+	"WITHIN": true, "ARRAY": true, "ROW": true, "VALUES": true, "UNION": true,
+	"EXCEPT": true, "INTERSECT": true, "LIMIT": true, "OFFSET": true,
+}
+
+// fromFunctions are the functions whose arguments use FROM, as in
+// EXTRACT(YEAR FROM created_at)
+var fromFunctions = map[string]bool{
+	"EXTRACT": true, "SUBSTRING": true, "TRIM": true, "POSITION": true,
+}
+
+// clauseWords end the FROM clause of a query
+var clauseWords = map[string]bool{
+	"WHERE": true, "GROUP": true, "HAVING": true, "WINDOW": true, "ORDER": true,
+	"LIMIT": true, "OFFSET": true, "FETCH": true, "UNION": true, "EXCEPT": true,
+	"INTERSECT": true,
+}
+
+// Statement is a console query that passed the checks
+type Statement struct {
+	SQL    string   // Query without its trailing semicolon
+	Tables []string // Tables the query reads, lower-cased
+}
+
+// scope is a query or a parenthesis being parsed
+type scope struct {
+	fromFunc bool // Arguments of one of fromFunctions
+	inFrom   bool // In the FROM clause, where commas separate tables
+}
+
+// Parse checks that a query is a single SELECT statement and returns the
+// tables it reads. Every FROM, JOIN and comma of a FROM clause must be
+// followed by a possibly qualified table name or a subquery, so that no
+// table is read unchecked; table functions, parenthesized joins and common
+// table expressions are not supported, and only allowedFunctions may be
+// called. Where databases disagree on the syntax, such as comments and
+// escapes, the reading that finds more to check is used or the query is
+// refused.
+func Parse(query string) (*Statement, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if n := len(tokens); n > 0 && tokens[n-1].is(";") {
+		tokens = tokens[:n-1]
+	}
+	if len(tokens) == 0 {
+		return nil, rejectf("empty query")
+	}
+	if tokens[0].isWord("WITH") {
+		return nil, rejectf("common table expressions are not supported")
+	}
+	if !tokens[0].isWord("SELECT") {
+		return nil, rejectf("only SELECT statements are allowed")
+	}
+
+	stmt := &Statement{SQL: strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(query), ";"))}
+	seen := make(map[string]bool)
+	// table reads the table reference at i
+	table := func(i int) error {
+		if i < len(tokens) && tokens[i].is("(") {
+			// Subqueries are checked as the loop reaches them
+			if i+1 < len(tokens) && tokens[i+1].isWord("SELECT") {
+				return nil
+			}
+			return rejectf("parenthesized joins are not supported")
+		}
+		name, next, ok := tableName(tokens, i)
+		if !ok {
+			return rejectf("expected a table name or a subquery")
+		}
+		if next < len(tokens) && tokens[next].is("(") {
+			return rejectf("table functions are not allowed")
+		}
+		if !seen[name] {
+			seen[name] = true
+			stmt.Tables = append(stmt.Tables, name)
+		}
+		return nil
+	}
+
+	scopes := []*scope{{}}
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		top := scopes[len(scopes)-1]
+		call := i+1 < len(tokens) && tokens[i+1].is("(")
+		switch {
+		case t.is("("):
+			scopes = append(scopes, &scope{fromFunc: i > 0 && tokens[i-1].kind == tokenWord && fromFunctions[tokens[i-1].text]})
+		case t.is(")"):
+			if len(scopes) == 1 {
+				return nil, rejectf("unbalanced parentheses")
+			}
+			scopes = scopes[:len(scopes)-1]
+		case t.is(";"):
+			return nil, rejectf("only a single statement is allowed")
+		case t.kind == tokenWord && deniedWords[t.text]:
+			return nil, rejectf("%s is not allowed", t.text)
+		case t.isWord("FOR") && i+1 < len(tokens) && (tokens[i+1].isWord("UPDATE") || tokens[i+1].isWord("SHARE") ||
+			tokens[i+1].isWord("NO") || tokens[i+1].isWord("KEY")):
+			return nil, rejectf("locking reads are not allowed")
+		case t.isWord("FROM"):
+			// FROM also appears in function arguments and IS [NOT] DISTINCT FROM
+			if top.fromFunc || i >= 2 && tokens[i-1].isWord("DISTINCT") && (tokens[i-2].isWord("IS") || tokens[i-2].isWord("NOT")) {
+				continue
+			}
+			top.inFrom = true
+			if err := table(i + 1); err != nil {
+				return nil, err
+			}
+		case t.isWord("JOIN") || t.isWord("STRAIGHT_JOIN"):
+			top.inFrom = true
+			if err := table(i + 1); err != nil {
+				return nil, err
+			}
+		case t.is(",") && top.inFrom:
+			if err := table(i + 1); err != nil {
+				return nil, err
+			}
+		case t.kind == tokenWord && clauseWords[t.text]:
+			top.inFrom = false
+		case call && t.kind == tokenQuoted:
+			return nil, rejectf("function %s is not allowed", t.text)
+		case call && t.kind == tokenWord && !syntaxWords[t.text]:
+			if !allowedFunctions[t.text] || i > 0 && tokens[i-1].is(".") {
+				return nil, rejectf("function %s is not allowed", strings.ToLower(t.text))
+			}
+		}
+	}
+	if len(scopes) != 1 {
+		return nil, rejectf("unbalanced parentheses")
+	}
+	return stmt, nil
+}
+
+// tableName reads a possibly qualified table name at i, returning it and
+// the index after it
+func tableName(tokens []token, i int) (string, int, bool) {
+	if i >= len(tokens) || !tokens[i].isName() {
+		return "", i, false
+	}
+	parts := []string{tokens[i].name()}
+	i++
+	for i+1 < len(tokens) && tokens[i].is(".") && tokens[i+1].isName() {
+		parts = append(parts, tokens[i+1].name())
+		i += 2
+	}
+	return strings.Join(parts, "."), i, true
+}
+
+func (t token) is(symbol string) bool {
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+func (t token) isWord(word string) bool {
+	return t.kind == tokenWord && t.text == word
+}
+
+func (t token) isName() bool {
+	return t.kind == tokenWord || t.kind == tokenQuoted
+}
+
+// name returns the identifier of a name token, lower-cased unless quoted
+func (t token) name() string {
+	if t.kind == tokenQuoted {
+		return t.text
+	}
+	return strings.ToLower(t.text)
+}
+
+// tokenize splits a query into tokens, dropping comments
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case isSpace(c):
+			i++
+		case strings.HasPrefix(query[i:], "--") && (i+2 == len(query) || isSpace(query[i+2])):
+			// MySQL only starts comments with "-- ", so "--1" is left to the
+			// symbols and checked like the rest of the query
+			end := strings.IndexAny(query[i:], "\n\r")
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+		case strings.HasPrefix(query[i:], "/*!") || strings.HasPrefix(query[i:], "/*+"):
+			// MySQL runs the content of these comments
+			return nil, rejectf("executable comments are not allowed")
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return nil, rejectf("unterminated comment")
+			}
+			i += end + 4
+		case c == '\'':
+			end, err := closing(query, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: query[i : end+1]})
+			i = end + 1
+		case c == '"' || c == '`':
+			end, err := closing(query, i, c)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, text: strings.ToLower(query[i+1 : end])})
+			i = end + 1
+		case c == '$' && i+1 < len(query) && (query[i+1] == '$' || isWordByte(query[i+1]) && !isDigit(query[i+1])):
+			// PostgreSQL dollar-quoted strings could hide statements
+			return nil, rejectf("dollar-quoted strings are not allowed")
+		case isWordByte(c) && !isDigit(c):
+			j := i + 1
+			for j < len(query) && (isWordByte(query[j]) || query[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: strings.ToUpper(query[i:j])})
+			i = j
+		case isDigit(c):
+			j := i + 1
+			for j < len(query) && (isWordByte(query[j]) || query[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: query[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// closing returns the index of the quote closing the one at start. Doubled
+// quotes are part of the quoted text. Backslashes escape quotes on MySQL but
+// not on PostgreSQL, so they are refused rather than guessed.
+func closing(query string, start int, quote byte) (int, error) {
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			return 0, rejectf("backslashes in quoted text are not allowed")
+		case quote:
+			if i+1 < len(query) && query[i+1] == quote {
+				i++
+				continue
+			}
+			return i, nil
+		}
+	}
+	return 0, rejectf("unterminated quoted text")
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,89 @@
+package sqlconsole
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTables(t *testing.T) {
+	tests := []struct {
+		query  string
+		tables []string
+	}{
+		{"SELECT 1", nil},
+		{"SELECT id, name FROM roles", []string{"roles"}},
+		{"select * from Roles;", []string{"roles"}},
+		{`SELECT * FROM public."Roles" r`, []string{"public.roles"}},
+		{"SELECT * FROM roles r, teams t", []string{"roles", "teams"}},
+		{"SELECT * FROM roles r JOIN teams t ON t.id = r.id, users u", []string{"roles", "teams", "users"}},
+		{"SELECT * FROM roles STRAIGHT_JOIN users", []string{"roles", "users"}},
+		{"SELECT * FROM (SELECT id FROM roles) r, users", []string{"roles", "users"}},
+		{"SELECT (SELECT COUNT(*) FROM users) FROM roles", []string{"users", "roles"}},
+		{"SELECT * FROM roles WHERE id IN (SELECT role_id FROM users)", []string{"roles", "users"}},
+		{"SELECT EXTRACT(YEAR FROM created_at) FROM roles", []string{"roles"}},
+		{"SELECT SUBSTRING(name FROM 1 FOR 2), TRIM(BOTH ' ' FROM name) FROM roles", []string{"roles"}},
+		{"SELECT * FROM roles WHERE name IS NOT DISTINCT FROM description", []string{"roles"}},
+		{"SELECT name, COUNT(*) FROM roles GROUP BY name, id ORDER BY 2, 1", []string{"roles"}},
+		{"SELECT CAST(id AS VARCHAR(10)), id::numeric(10, 2) FROM roles", []string{"roles"}},
+		{"SELECT * FROM roles -- trailing comment\n;", []string{"roles"}},
+		{"SELECT '; DROP TABLE users' FROM roles", []string{"roles"}},
+		{"SELECT a FROM roles UNION SELECT b, c FROM teams", []string{"roles", "teams"}},
+	}
+	for _, tt := range tests {
+		stmt, err := Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if !reflect.DeepEqual(stmt.Tables, tt.tables) {
+			t.Errorf("Parse(%q) tables = %v, want %v", tt.query, stmt.Tables, tt.tables)
+		}
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	queries := []string{
+		"",
+		";",
+		"DELETE FROM users",
+		"UPDATE users SET name = 'x'",
+		"WITH u AS (SELECT * FROM users) SELECT * FROM u",
+		"SELECT * FROM roles; DELETE FROM roles",
+		"SELECT * FROM roles FOR UPDATE",
+		"SELECT * INTO OUTFILE '/tmp/x' FROM roles",
+		"SELECT (1",
+		"SELECT 1)",
+		"SELECT 'unterminated",
+		"SELECT /*! 1 */ 1",
+		"SELECT $$x$$",
+		`SELECT 'a\' FROM users`,
+		// Tables the table check could not follow
+		"SELECT * FROM (users CROSS JOIN roles)",
+		"SELECT * FROM roles JOIN (users) ON true",
+		"SELECT (SELECT email FROM (TABLE users) u LIMIT 1) FROM roles",
+		"TABLE users",
+		"SELECT * FROM roles, LATERAL (SELECT * FROM users) u",
+		"SELECT * FROM ONLY users",
+		"SELECT * FROM generate_series(1, 10)",
+		"SELECT * FROM pragma_table_info('users')",
+		"SELECT * FROM",
+		// Functions that run SQL given as text or act on the server
+		"SELECT query_to_xml('select * from users', true, true, '') FROM roles",
+		"SELECT table_to_xml('users', true, true, '')",
+		"SELECT database_to_xml(true, true, '')",
+		"SELECT cursor_to_xml('c', 1, true, true, '')",
+		"SELECT pg_catalog.lower('x')",
+		`SELECT "query_to_xml"('select 1', true, true, '')`,
+		"SELECT pg_sleep(10)",
+		"SELECT pg_read_file('/etc/passwd')",
+		"SELECT load_extension('x')",
+		"SELECT dblink('host=x', 'select 1')",
+		"SELECT set_config('x', 'y', false)",
+	}
+	for _, query := range queries {
+		if _, err := Parse(query); !errors.Is(err, ErrRejected) {
+			t.Errorf("Parse(%q) = %v, want ErrRejected", query, err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrMissingTenant is returned when tenant-owned data is accessed without a tenant
+	ErrMissingTenant = errors.New("tenant required to access tenant-owned data")
+	// ErrForeignTenant is returned when writing rows owned by another tenant
+	ErrForeignTenant = errors.New("cannot write data of another tenant")
+)
+
+type (
+	tenantKey struct{}
+	systemKey struct{}
+)
+
+// NewContext returns a context scoped to the organization
+func NewContext(ctx context.Context, orgID int) context.Context {
+	return context.WithValue(ctx, tenantKey{}, orgID)
+}
+
+// FromContext returns the organization the context is scoped to
+func FromContext(ctx context.Context) (int, bool) {
+	orgID, ok := ctx.Value(tenantKey{}).(int)
+	return orgID, ok && orgID != 0
+}
+
+// WithSystem returns a context that bypasses tenant scoping. It is meant
+// for trusted code paths such as resolving a user's memberships, never for
+// code handling tenant data on behalf of a request.
+func WithSystem(ctx context.Context) context.Context {
+	return context.WithValue(ctx, systemKey{}, true)
+}
+
+// IsSystem reports whether the context bypasses tenant scoping
+func IsSystem(ctx context.Context) bool {
+	system, _ := ctx.Value(systemKey{}).(bool)
+	return system
+}
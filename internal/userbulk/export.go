@@ -0,0 +1,111 @@
+package userbulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"go-template/ent"
+	"go-template/pkg/publicid"
+	"io"
+	"time"
+)
+
+// ExportRow is a user record of an export file. Export files can be
+// imported again; the columns an import does not know are ignored.
+type ExportRow struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Role      string     `json:"role"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// exportColumns is the header of CSV exports
+var exportColumns = []string{"id", "name", "email", "role", "status", "created_at", "deleted_at"}
+
+// NewExportRow converts a user, with its role loaded, to an export record
+func NewExportRow(u *ent.User) ExportRow {
+	row := ExportRow{
+		ID:        publicid.Encode(publicid.User, u.ID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Status:    string(u.Status),
+		CreatedAt: u.CreatedAt,
+		DeletedAt: u.DeletedAt,
+	}
+	if u.Edges.Role != nil {
+		row.Role = u.Edges.Role.Name
+	}
+	return row
+}
+
+// Writer writes export records in a file format
+type Writer struct {
+	format  Format
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+// NewWriter creates a writer of the format
+func NewWriter(w io.Writer, format Format) (*Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &Writer{format: format, csv: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &Writer{format: format, json: json.NewEncoder(w)}, nil
+	}
+	return nil, ErrFormat
+}
+
+// ContentType returns the media type of the writer's format
+func (w *Writer) ContentType() string {
+	if w.format == FormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/x-ndjson"
+}
+
+// Write writes a record, preceded by the header for the first CSV record
+func (w *Writer) Write(row ExportRow) error {
+	if w.format == FormatNDJSON {
+		return w.json.Encode(row)
+	}
+
+	if !w.started {
+		w.started = true
+		if err := w.csv.Write(exportColumns); err != nil {
+			return err
+		}
+	}
+	deletedAt := ""
+	if row.DeletedAt != nil {
+		deletedAt = row.DeletedAt.Format(time.RFC3339)
+	}
+	return w.csv.Write([]string{
+		row.ID,
+		row.Name,
+		row.Email,
+		row.Role,
+		row.Status,
+		row.CreatedAt.Format(time.RFC3339),
+		deletedAt,
+	})
+}
+
+// Flush writes buffered records to the underlying writer
+func (w *Writer) Flush() error {
+	if w.format == FormatNDJSON {
+		return nil
+	}
+	if !w.started {
+		// Empty exports still carry the header
+		w.started = true
+		if err := w.csv.Write(exportColumns); err != nil {
+			return err
+		}
+	}
+	w.csv.Flush()
+	return w.csv.Error()
+}
@@ -0,0 +1,504 @@
+// Package userbulk imports users from and exports users to CSV and
+// newline-delimited JSON files
+package userbulk
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-template/ent"
+	"go-template/ent/role"
+	"go-template/ent/user"
+	"go-template/internal/database"
+	"go-template/internal/pii"
+	"go-template/internal/softdelete"
+	"go-template/pkg/password"
+	"go-template/pkg/publicid"
+	"io"
+	"net/mail"
+	"strings"
+)
+
+// Format of an import or export file
+type Format string
+
+// Supported file formats
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+)
+
+// Import modes
+const (
+	ModeCreate = "create" // Rows for existing emails fail
+	ModeUpsert = "upsert" // Rows for existing emails update those users
+)
+
+// Row actions reported by an import
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionError  = "error"
+)
+
+// MaxRows bounds the number of rows of an import file
+const MaxRows = 10000
+
+// defaultRole is assigned to created users whose row names no role
+const defaultRole = "user"
+
+var (
+	ErrFormat      = errors.New("unsupported file format")
+	ErrInvalidFile = errors.New("invalid import file")
+	ErrTooMany     = fmt.Errorf("%w: files are limited to %d rows", ErrInvalidFile, MaxRows)
+)
+
+// ParseFormat returns the format with the given name
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case FormatCSV, FormatNDJSON:
+		return f, nil
+	}
+	return "", ErrFormat
+}
+
+// Row is a user record of an import file. Empty fields of rows updating an
+// existing user keep the current value.
+type Row struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`   // Name of a role shared by all organizations
+	Status   string `json:"status"` // active or disabled
+}
+
+// Options controls how an import runs
+type Options struct {
+	Mode      string // ModeCreate or ModeUpsert
+	DryRun    bool   // Validate and report without writing
+	BatchSize int    // Rows per transaction, 0 for a single transaction
+}
+
+// RowResult reports the outcome of one row
+type RowResult struct {
+	Line   int    `json:"line"` // Line of the row in the file
+	Email  string `json:"email"`
+	Action string `json:"action" enums:"create,update,error"`
+	UserID string `json:"user_id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report summarizes an import. In a dry run, actions are what would happen.
+type Report struct {
+	DryRun  bool        `json:"dry_run"`
+	Total   int         `json:"total"`
+	Created int         `json:"created"`
+	Updated int         `json:"updated"`
+	Failed  int         `json:"failed"`
+	Rows    []RowResult `json:"rows"`
+}
+
+// record is a parsed row with its position and parse error
+type record struct {
+	line int
+	row  Row
+	err  error
+}
+
+// Import parses the file and creates or updates its users. Invalid rows are
+// reported and skipped. Valid rows are written in batches, each in its own
+// transaction; a failed write rolls back and fails its whole batch.
+func Import(ctx context.Context, db *database.Client, r io.Reader, format Format, opts Options) (*Report, error) {
+	records, err := parse(r, format)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]RowResult, len(records))
+	for i, rec := range records {
+		results[i] = RowResult{Line: rec.line, Email: rec.row.Email}
+		if rec.err != nil {
+			results[i].Action, results[i].Error = ActionError, rec.err.Error()
+		}
+	}
+
+	existing, err := existingUsers(ctx, db, records)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := sharedRoles(ctx, db, records)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan each row against the database and the rows before it
+	plans := make([]*plan, len(records))
+	seen := make(map[string]int, len(records))
+	for i, rec := range records {
+		if rec.err != nil {
+			continue
+		}
+		email := strings.ToLower(rec.row.Email)
+		if line, ok := seen[email]; ok {
+			results[i].Action, results[i].Error = ActionError, fmt.Sprintf("duplicate of line %d", line)
+			continue
+		}
+		seen[email] = rec.line
+
+		p, err := newPlan(rec.row, existing[email], roles, opts.Mode)
+		if err != nil {
+			results[i].Action, results[i].Error = ActionError, err.Error()
+			continue
+		}
+		plans[i] = p
+		results[i].Action = p.action
+		if p.user != nil {
+			results[i].UserID = publicid.Encode(publicid.User, p.user.ID)
+		}
+	}
+
+	if !opts.DryRun {
+		if err := write(ctx, db, plans, results, opts.BatchSize); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &Report{DryRun: opts.DryRun, Total: len(results), Rows: results}
+	for _, res := range results {
+		switch res.Action {
+		case ActionCreate:
+			report.Created++
+		case ActionUpdate:
+			report.Updated++
+		default:
+			report.Failed++
+		}
+	}
+	return report, nil
+}
+
+// plan is the validated change of a row
+type plan struct {
+	action string
+	row    Row
+	user   *ent.User // Existing user, for updates
+	roleID int       // Zero to keep the current role
+}
+
+// newPlan validates a row and decides whether it creates or updates a user
+func newPlan(row Row, existing *ent.User, roles map[string]int, mode string) (*plan, error) {
+	p := &plan{action: ActionCreate, row: row, user: existing}
+
+	if existing != nil {
+		if existing.DeletedAt != nil {
+			return nil, errors.New("email belongs to a deleted user")
+		}
+		if mode != ModeUpsert {
+			return nil, errors.New("email is already taken")
+		}
+		p.action = ActionUpdate
+	} else {
+		if row.Name == "" {
+			return nil, errors.New("name is required")
+		}
+		if row.Password == "" {
+			return nil, errors.New("password is required for new users")
+		}
+		if row.Role == "" {
+			row.Role = defaultRole
+		}
+	}
+
+	if row.Password != "" {
+		if err := password.Validate(row.Password); err != nil {
+			return nil, err
+		}
+	}
+	if row.Status != "" {
+		if err := user.StatusValidator(user.Status(row.Status)); err != nil {
+			return nil, fmt.Errorf("invalid status %q", row.Status)
+		}
+	}
+	if row.Role != "" {
+		id, ok := roles[row.Role]
+		if !ok {
+			return nil, fmt.Errorf("role %q not found", row.Role)
+		}
+		p.roleID = id
+	}
+	return p, nil
+}
+
+// write applies the plans in batches and records the outcome in results
+func write(ctx context.Context, db *database.Client, plans []*plan, results []RowResult, batchSize int) error {
+	var pending []int
+	for i, p := range plans {
+		if p != nil {
+			pending = append(pending, i)
+		}
+	}
+	if batchSize <= 0 {
+		batchSize = len(pending)
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		end := start + batchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+		batch := pending[start:end]
+
+		failed, err := writeBatch(ctx, db, plans, results, batch)
+		if err == nil {
+			continue
+		}
+		if failed < 0 {
+			return err
+		}
+
+		// Nothing of the batch was written
+		for _, i := range batch {
+			results[i].Action, results[i].UserID = ActionError, ""
+			results[i].Error = fmt.Sprintf("batch rolled back: line %d failed", results[failed].Line)
+		}
+		results[failed].Error = err.Error()
+	}
+	return nil
+}
+
+// writeBatch writes the rows of a batch in a transaction. On failure it
+// returns the index of the failed row, or -1 if the transaction itself failed.
+func writeBatch(ctx context.Context, db *database.Client, plans []*plan, results []RowResult, batch []int) (int, error) {
+	tx, err := db.Ent.Tx(ctx)
+	if err != nil {
+		return -1, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	for _, i := range batch {
+		u, err := apply(ctx, tx.Client(), plans[i])
+		if err != nil {
+			_ = tx.Rollback()
+			return i, err
+		}
+		results[i].UserID = publicid.Encode(publicid.User, u.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return -1, fmt.Errorf("committing import: %w", err)
+	}
+	return 0, nil
+}
+
+// apply creates or updates the user of a plan
+func apply(ctx context.Context, client *ent.Client, p *plan) (*ent.User, error) {
+	var hashed string
+	if p.row.Password != "" {
+		var err error
+		if hashed, err = password.Hash(p.row.Password); err != nil {
+			return nil, fmt.Errorf("hashing password: %w", err)
+		}
+	}
+
+	if p.action == ActionCreate {
+		create := client.User.Create().
+			SetName(p.row.Name).
+			SetEmail(p.row.Email).
+			SetPassword(hashed).
+			SetRoleID(p.roleID)
+		if p.row.Status != "" {
+			create = create.SetStatus(user.Status(p.row.Status))
+		}
+		u, err := create.Save(ctx)
+		if ent.IsConstraintError(err) {
+			return nil, errors.New("email is already taken")
+		}
+		return u, err
+	}
+
+	update := client.User.UpdateOneID(p.user.ID)
+	if p.row.Name != "" {
+		update = update.SetName(p.row.Name)
+	}
+	if hashed != "" {
+		update = update.SetPassword(hashed)
+	}
+	if p.row.Status != "" {
+		update = update.SetStatus(user.Status(p.row.Status))
+	}
+	if p.roleID != 0 {
+		update = update.SetRoleID(p.roleID)
+	}
+	return update.Save(ctx)
+}
+
+// existingUsers returns the users, deleted ones included, with the emails of
+// the rows, by lowercased email
+func existingUsers(ctx context.Context, db *database.Client, records []record) (map[string]*ent.User, error) {
+	var emails []string
+	for _, rec := range records {
+		if rec.err == nil {
+			emails = append(emails, rec.row.Email)
+		}
+	}
+
+	users, err := db.Ent.User.Query().
+		Where(pii.EmailIn(emails...)).
+		All(softdelete.IncludeDeleted(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("fetching existing users: %w", err)
+	}
+
+	byEmail := make(map[string]*ent.User, len(users))
+	for _, u := range users {
+		byEmail[strings.ToLower(u.Email)] = u
+	}
+	return byEmail, nil
+}
+
+// sharedRoles returns the IDs of the shared roles named by the rows, by name
+func sharedRoles(ctx context.Context, db *database.Client, records []record) (map[string]int, error) {
+	names := []string{defaultRole}
+	for _, rec := range records {
+		if rec.err == nil && rec.row.Role != "" {
+			names = append(names, rec.row.Role)
+		}
+	}
+
+	roles, err := db.Ent.Role.Query().
+		Where(role.NameIn(names...), role.OrganizationIDIsNil()).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching roles: %w", err)
+	}
+
+	byName := make(map[string]int, len(roles))
+	for _, r := range roles {
+		byName[r.Name] = r.ID
+	}
+	return byName, nil
+}
+
+// parse reads the rows of a file. Rows that cannot be read are returned
+// with their error; only unreadable files fail as a whole.
+func parse(r io.Reader, format Format) ([]record, error) {
+	var records []record
+	var err error
+	switch format {
+	case FormatCSV:
+		records, err = parseCSV(r)
+	case FormatNDJSON:
+		records, err = parseNDJSON(r)
+	default:
+		return nil, ErrFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range records {
+		if records[i].err == nil {
+			records[i].row, records[i].err = normalize(records[i].row)
+		}
+	}
+	return records, nil
+}
+
+// normalize trims the fields of a row and checks its email
+func normalize(row Row) (Row, error) {
+	row.Name = strings.TrimSpace(row.Name)
+	row.Email = strings.TrimSpace(row.Email)
+	row.Role = strings.TrimSpace(row.Role)
+	row.Status = strings.ToLower(strings.TrimSpace(row.Status))
+
+	if row.Email == "" {
+		return row, errors.New("email is required")
+	}
+	if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+		return row, fmt.Errorf("invalid email %q", row.Email)
+	}
+	return row, nil
+}
+
+// parseCSV reads a CSV file with a header row. Columns are matched by name
+// and unknown columns, such as those of an export, are ignored.
+func parseCSV(r io.Reader) ([]record, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading CSV header: %v", ErrInvalidFile, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, fmt.Errorf("%w: CSV header must include an email column", ErrInvalidFile)
+	}
+
+	var records []record
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading CSV: %v", ErrInvalidFile, err)
+		}
+		if len(records) == MaxRows {
+			return nil, ErrTooMany
+		}
+
+		line, _ := cr.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return fields[i]
+			}
+			return ""
+		}
+		records = append(records, record{
+			line: line,
+			row: Row{
+				Name:     get("name"),
+				Email:    get("email"),
+				Password: get("password"),
+				Role:     get("role"),
+				Status:   get("status"),
+			},
+		})
+	}
+	return records, nil
+}
+
+// parseNDJSON reads a file with one JSON object per line. Blank lines are skipped.
+func parseNDJSON(r io.Reader) ([]record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var records []record
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		if len(records) == MaxRows {
+			return nil, ErrTooMany
+		}
+
+		rec := record{line: line}
+		if err := json.Unmarshal(data, &rec.row); err != nil {
+			rec.err = fmt.Errorf("invalid JSON: %v", err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading NDJSON: %v", ErrInvalidFile, err)
+	}
+	return records, nil
+}
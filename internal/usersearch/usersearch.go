@@ -0,0 +1,218 @@
+// Package usersearch finds users by partial name or email, ranked by
+// relevance. It uses the tsvector column and trigram indexes created by the
+// database package, and falls back to ILIKE when they are missing. While
+// personal data is encrypted, only exact email matches can be found.
+package usersearch
+
+import (
+	"context"
+	"errors"
+	"go-template/ent"
+	"go-template/internal/database"
+	"go-template/internal/pii"
+	"go-template/pkg/fieldcrypt"
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ErrEmptyQuery is returned for queries without letters or digits
+var ErrEmptyQuery = errors.New("search query is empty")
+
+// Hit is a user matching a search
+type Hit struct {
+	User *ent.User
+	// Highlights holds the matched fields with the matches wrapped in
+	// <mark> tags. Values are HTML-escaped.
+	Highlights map[string]string
+}
+
+// Search returns a page of the users of query matching text, most relevant
+// first, and the number of all matches
+func Search(ctx context.Context, db *database.Client, query *ent.UserQuery, text string, offset, limit int) ([]Hit, int, error) {
+	text = strings.TrimSpace(text)
+	terms := tokenize(text)
+	if len(terms) == 0 {
+		return nil, 0, ErrEmptyQuery
+	}
+
+	var m matcher = likeMatcher{text: text}
+	switch {
+	case fieldcrypt.Enabled():
+		m = emailMatcher{email: text}
+	case db.FullTextSearch():
+		m = fullTextMatcher{text: text, terms: terms}
+	}
+
+	query = query.Where(m.match)
+	total, err := query.Clone().Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	users, err := query.
+		Order(m.rank, func(s *sql.Selector) { s.OrderBy(s.C("id")) }).
+		Offset(offset).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	hits := make([]Hit, len(users))
+	for i, u := range users {
+		hits[i] = Hit{User: u, Highlights: make(map[string]string)}
+		for field, value := range map[string]string{"name": u.Name, "email": u.Email} {
+			if marked, ok := highlight(value, terms); ok {
+				hits[i].Highlights[field] = marked
+			}
+		}
+	}
+	return hits, total, nil
+}
+
+// matcher filters and orders users for a search
+type matcher interface {
+	match(*sql.Selector)
+	rank(*sql.Selector)
+}
+
+// fullTextMatcher matches prefixes of the query terms against the search
+// column, and the whole query by trigram word similarity to catch typos
+type fullTextMatcher struct {
+	text  string
+	terms []string
+}
+
+// tsquery returns the query terms as a prefix query, e.g. "jo:* & doe:*".
+// Terms only hold letters and digits, so they need no quoting.
+func (m fullTextMatcher) tsquery() string {
+	prefixes := make([]string, len(m.terms))
+	for i, term := range m.terms {
+		prefixes[i] = term + ":*"
+	}
+	return strings.Join(prefixes, " & ")
+}
+
+func (m fullTextMatcher) match(s *sql.Selector) {
+	s.Where(sql.P(func(b *sql.Builder) {
+		b.Wrap(func(b *sql.Builder) {
+			b.WriteString(s.C(database.SearchColumn)).WriteString(" @@ to_tsquery('simple', ").Arg(m.tsquery()).WriteString(")")
+			b.WriteString(" OR ").Arg(m.text).WriteString(" <% ").WriteString(s.C("name"))
+			b.WriteString(" OR ").Arg(m.text).WriteString(" <% ").WriteString(s.C("email"))
+		})
+	}))
+}
+
+func (m fullTextMatcher) rank(s *sql.Selector) {
+	s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+		b.WriteString("ts_rank(").WriteString(s.C(database.SearchColumn)).WriteString(", to_tsquery('simple', ").Arg(m.tsquery()).WriteString("))")
+		b.WriteString(" + GREATEST(word_similarity(").Arg(m.text).WriteString(", ").WriteString(s.C("name")).WriteString(")")
+		b.WriteString(", word_similarity(").Arg(m.text).WriteString(", ").WriteString(s.C("email")).WriteString("))")
+		b.WriteString(" DESC")
+	}))
+}
+
+// emailMatcher matches the user with the exact email through its blind
+// index, as encrypted columns cannot be searched
+type emailMatcher struct {
+	email string
+}
+
+func (m emailMatcher) match(s *sql.Selector) {
+	pii.EmailIs(m.email)(s)
+}
+
+func (m emailMatcher) rank(*sql.Selector) {}
+
+// likeMatcher matches the whole query as a substring of the name or email,
+// ranking exact matches first and then prefix matches
+type likeMatcher struct {
+	text string
+}
+
+// likeEscape escapes LIKE wildcards; "!" works as escape character on all
+// dialects, unlike a backslash
+var likeEscape = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// like writes a case-insensitive LIKE of the column against the pattern
+func like(b *sql.Builder, column, pattern string) {
+	if b.Dialect() == dialect.Postgres {
+		b.WriteString(column).WriteString(" ILIKE ").Arg(pattern)
+	} else {
+		b.WriteString("LOWER(").WriteString(column).WriteString(") LIKE ").Arg(strings.ToLower(pattern))
+	}
+	b.WriteString(" ESCAPE '!'")
+}
+
+func (m likeMatcher) match(s *sql.Selector) {
+	pattern := "%" + likeEscape.Replace(m.text) + "%"
+	s.Where(sql.P(func(b *sql.Builder) {
+		b.Wrap(func(b *sql.Builder) {
+			like(b, s.C("name"), pattern)
+			b.WriteString(" OR ")
+			like(b, s.C("email"), pattern)
+		})
+	}))
+}
+
+func (m likeMatcher) rank(s *sql.Selector) {
+	exact, prefix := likeEscape.Replace(m.text), likeEscape.Replace(m.text)+"%"
+	s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+		b.WriteString("CASE WHEN ")
+		like(b, s.C("name"), exact)
+		b.WriteString(" OR ")
+		like(b, s.C("email"), exact)
+		b.WriteString(" THEN 0 WHEN ")
+		like(b, s.C("name"), prefix)
+		b.WriteString(" OR ")
+		like(b, s.C("email"), prefix)
+		b.WriteString(" THEN 1 ELSE 2 END")
+	}))
+}
+
+// tokenize splits a query into lowercase terms of letters and digits
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlight wraps the case-insensitive occurrences of the terms in value in
+// <mark> tags and escapes the rest. It reports whether any term occurred.
+func highlight(value string, terms []string) (string, bool) {
+	marked := make([]bool, len(value))
+	found := false
+	for _, term := range terms {
+		for i := 0; i+len(term) <= len(value); i++ {
+			if utf8.RuneStart(value[i]) && strings.EqualFold(value[i:i+len(term)], term) {
+				found = true
+				for j := i; j < i+len(term); j++ {
+					marked[j] = true
+				}
+			}
+		}
+	}
+	if !found {
+		return "", false
+	}
+
+	var sb strings.Builder
+	for start := 0; start < len(value); {
+		end := start
+		for end < len(value) && marked[end] == marked[start] {
+			end++
+		}
+		if marked[start] {
+			sb.WriteString("<mark>" + html.EscapeString(value[start:end]) + "</mark>")
+		} else {
+			sb.WriteString(html.EscapeString(value[start:end]))
+		}
+		start = end
+	}
+	return sb.String(), true
+}
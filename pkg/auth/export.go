@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-template/pkg/publicid"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// exportAudience marks data export download tokens so they are never accepted as access tokens
+const exportAudience = "export"
+
+// ExportClaims represents the data export download token claims structure
+type ExportClaims struct {
+	ExportID int `json:"-"` // Decoded from the subject
+	jwt.RegisteredClaims
+}
+
+// GenerateExportToken creates a signed download token for a data export that expires at expiresAt
+func GenerateExportToken(exportID int, expiresAt time.Time, config JWTConfig) (string, error) {
+	now := time.Now()
+
+	claims := ExportClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    config.Issuer,
+			Subject:   publicid.Encode(publicid.DataExport, exportID),
+			Audience:  jwt.ClaimStrings{exportAudience},
+		},
+	}
+
+	return signClaims(claims, config)
+}
+
+// ParseExportToken parses and validates a data export download token
+func ParseExportToken(tokenString string, secret string) (*ExportClaims, error) {
+	claims := ExportClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(exportAudience))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !token.Valid {
+		return nil, ErrInvalidToken
+	}
+	if claims.ExportID, err = publicid.Decode(publicid.DataExport, claims.Subject); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	return &claims, nil
+}
@@ -0,0 +1,78 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go-template/pkg/publicid"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// invitationAudience marks invitation tokens so they are never accepted as access tokens
+const invitationAudience = "invitation"
+
+// InvitationClaims represents the invitation token claims structure
+type InvitationClaims struct {
+	InvitationID       int    `json:"-"` // Decoded from inv
+	OrgID              int    `json:"-"` // Decoded from org_id
+	Email              string `json:"-"` // Parsed from the subject
+	PublicInvitationID string `json:"inv"`
+	PublicOrgID        string `json:"org_id"`
+	Version            int    `json:"ver"`
+	jwt.RegisteredClaims
+}
+
+// GenerateInvitationToken creates a signed token for an invitation that expires at expiresAt
+func GenerateInvitationToken(invitationID, version, orgID int, email string, expiresAt time.Time, config JWTConfig) (string, error) {
+	now := time.Now()
+
+	claims := InvitationClaims{
+		PublicInvitationID: publicid.Encode(publicid.Invitation, invitationID),
+		PublicOrgID:        publicid.Encode(publicid.Organization, orgID),
+		Version:            version,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    config.Issuer,
+			Subject:   email,
+			Audience:  jwt.ClaimStrings{invitationAudience},
+		},
+	}
+
+	return signClaims(claims, config)
+}
+
+// ParseInvitationToken parses and validates an invitation token
+func ParseInvitationToken(tokenString string, secret string) (*InvitationClaims, error) {
+	claims := InvitationClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		// Validate signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(secret), nil
+	}, jwt.WithAudience(invitationAudience))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	if !token.Valid || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	if claims.InvitationID, err = publicid.Decode(publicid.Invitation, claims.PublicInvitationID); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.OrgID, err = publicid.Decode(publicid.Organization, claims.PublicOrgID); err != nil {
+		return nil, ErrInvalidToken
+	}
+	claims.Email = claims.Subject
+
+	return &claims, nil
+}
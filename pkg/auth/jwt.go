@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"go-template/pkg/publicid"
+
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -15,54 +17,119 @@ var (
 
 // JWTConfig holds JWT configuration
 type JWTConfig struct {
-	Secret        string        `mapstructure:"secret"`
-	Issuer        string        `mapstructure:"issuer"`
-	Expiration    time.Duration `mapstructure:"expiration"`
-	RefreshExpire time.Duration `mapstructure:"refresh_expire"`
+	Secret              string        `mapstructure:"secret"`
+	Issuer              string        `mapstructure:"issuer"`
+	Expiration          time.Duration `mapstructure:"expiration"`
+	RefreshExpire       time.Duration `mapstructure:"refresh_expire"`
+	ImpersonationExpire time.Duration `mapstructure:"impersonation_expire"`
+}
+
+// Identity describes who a token is issued to
+type Identity struct {
+	UserID    int
+	Username  string
+	Role      string // Global role
+	SessionID int    // Login session the token belongs to
+	OrgID     int    // Selected organization, zero for none
 }
 
-// Claims represents the JWT claims structure
+// Claims represents the JWT claims structure. Identifiers travel as public
+// IDs: the user in the subject, the session in "sid" and the organization
+// in "org_id". They are decoded into the integer fields on parse.
 type Claims struct {
-	UserID   int    `json:"user_id"`
+	UserID          int    `json:"-"`
+	SessionID       int    `json:"-"`
+	OrgID           int    `json:"-"`
+	Username        string `json:"username"`
+	Role            string `json:"role"`
+	PublicSessionID string `json:"sid,omitempty"`
+	PublicOrgID     string `json:"org_id,omitempty"`
+	Actor           *Actor `json:"act,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// Actor identifies the user acting on behalf of the token's user,
+// carried in the "act" claim of impersonation tokens (RFC 8693)
+type Actor struct {
+	UserID   int    `json:"-"` // Decoded from the subject
+	Subject  string `json:"sub"`
 	Username string `json:"username"`
-	Role     string `json:"role"`
+}
+
+// RefreshClaims represents the refresh token claims structure
+type RefreshClaims struct {
+	UserID          int    `json:"-"` // Decoded from the subject
+	SessionID       int    `json:"-"`
+	OrgID           int    `json:"-"`
+	PublicSessionID string `json:"sid,omitempty"`
+	PublicOrgID     string `json:"org_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken creates a new JWT token for a user
-func GenerateToken(userID int, username, role string, config JWTConfig) (string, error) {
-	now := time.Now()
-	expireAt := now.Add(config.Expiration)
+// GenerateToken creates a new JWT token for a user session
+func GenerateToken(id Identity, config JWTConfig) (string, error) {
+	return signClaims(newClaims(id, config.Expiration, config), config)
+}
 
-	claims := Claims{
-		UserID:   userID,
-		Username: username,
-		Role:     role,
+// GenerateImpersonationToken creates a short-lived token that lets the actor act as the user.
+// The token is bound to the actor's session, so revoking that session ends the impersonation.
+func GenerateImpersonationToken(id Identity, actor Actor, config JWTConfig) (string, error) {
+	claims := newClaims(id, config.ImpersonationExpire, config)
+	actor.Subject = publicid.Encode(publicid.User, actor.UserID)
+	claims.Actor = &actor
+	return signClaims(claims, config)
+}
+
+// newClaims builds access token claims expiring after ttl
+func newClaims(id Identity, ttl time.Duration, config JWTConfig) Claims {
+	now := time.Now()
+	expireAt := now.Add(ttl)
+
+	return Claims{
+		UserID:          id.UserID,
+		SessionID:       id.SessionID,
+		OrgID:           id.OrgID,
+		Username:        id.Username,
+		Role:            id.Role,
+		PublicSessionID: encodeOptional(publicid.Session, id.SessionID),
+		PublicOrgID:     encodeOptional(publicid.Organization, id.OrgID),
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expireAt),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    config.Issuer,
+			Subject:   publicid.Encode(publicid.User, id.UserID),
 		},
 	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(config.Secret))
 }
 
-// GenerateRefreshToken creates a new refresh token for a user
-func GenerateRefreshToken(userID int, username string, config JWTConfig) (string, error) {
+// refreshAudience marks refresh tokens so they are never accepted as access
+// tokens, and access tokens never as refresh tokens
+const refreshAudience = "refresh"
+
+// GenerateRefreshToken creates a new refresh token for a user session
+func GenerateRefreshToken(id Identity, config JWTConfig) (string, error) {
 	now := time.Now()
 	expireAt := now.Add(config.RefreshExpire)
 
-	claims := jwt.RegisteredClaims{
-		ExpiresAt: jwt.NewNumericDate(expireAt),
-		IssuedAt:  jwt.NewNumericDate(now),
-		NotBefore: jwt.NewNumericDate(now),
-		Issuer:    config.Issuer,
-		Subject:   fmt.Sprintf("%d", userID),
+	claims := RefreshClaims{
+		PublicSessionID: encodeOptional(publicid.Session, id.SessionID),
+		PublicOrgID:     encodeOptional(publicid.Organization, id.OrgID),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expireAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    config.Issuer,
+			Subject:   publicid.Encode(publicid.User, id.UserID),
+			Audience:  jwt.ClaimStrings{refreshAudience},
+		},
 	}
 
+	return signClaims(claims, config)
+}
+
+// signClaims signs claims with the configured secret
+func signClaims(claims jwt.Claims, config JWTConfig) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(config.Secret))
 }
@@ -89,36 +156,76 @@ func ParseToken(tokenString string, secret string) (*Claims, error) {
 		return nil, ErrInvalidToken
 	}
 
+	// Access tokens carry no audience; other token kinds must not pass as one
+	if len(claims.Audience) > 0 {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.UserID, err = publicid.Decode(publicid.User, claims.Subject); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.SessionID, err = decodeOptional(publicid.Session, claims.PublicSessionID); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.OrgID, err = decodeOptional(publicid.Organization, claims.PublicOrgID); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.Actor != nil {
+		if claims.Actor.UserID, err = publicid.Decode(publicid.User, claims.Actor.Subject); err != nil {
+			return nil, ErrInvalidToken
+		}
+	}
+
 	return claims, nil
 }
 
 // ParseRefreshToken parses and validates a refresh token
-func ParseRefreshToken(tokenString string, secret string) (int, error) {
-	claims := jwt.RegisteredClaims{}
+func ParseRefreshToken(tokenString string, secret string) (*RefreshClaims, error) {
+	claims := RefreshClaims{}
 	token, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
 		// Validate signing method
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return []byte(secret), nil
-	})
+	}, jwt.WithAudience(refreshAudience))
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenExpired) {
-			return 0, ErrExpiredToken
+			return nil, ErrExpiredToken
 		}
-		return 0, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
 	}
 
 	if !token.Valid {
-		return 0, ErrInvalidToken
+		return nil, ErrInvalidToken
 	}
 
-	// Extract user ID from subject
-	var userID int
-	if _, err := fmt.Sscanf(claims.Subject, "%d", &userID); err != nil {
-		return 0, ErrInvalidToken
+	if claims.UserID, err = publicid.Decode(publicid.User, claims.Subject); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.SessionID, err = decodeOptional(publicid.Session, claims.PublicSessionID); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if claims.OrgID, err = decodeOptional(publicid.Organization, claims.PublicOrgID); err != nil {
+		return nil, ErrInvalidToken
 	}
 
-	return userID, nil
+	return &claims, nil
+}
+
+// encodeOptional encodes an identifier, leaving zero values empty
+func encodeOptional(kind publicid.Kind, id int) string {
+	if id == 0 {
+		return ""
+	}
+	return publicid.Encode(kind, id)
+}
+
+// decodeOptional decodes an identifier, treating empty values as zero
+func decodeOptional(kind publicid.Kind, s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return publicid.Decode(kind, s)
 }
@@ -17,6 +17,7 @@ const (
 const (
 	ResourceNotFound  = "resource.not_found"
 	ResourceForbidden = "resource.forbidden"
+	ResourceModified  = "resource.modified"
 )
 
 // Parameter validation error codes
@@ -31,13 +32,23 @@ const (
 	UserRegisterError = "user.register.error"
 	UserLoginError    = "user.login.error"
 	UserDisabled      = "user.disabled"
+	UserPasswordWeak  = "user.password.weak"
+	UserPasswordWrong = "user.password.wrong"
+	UserEmailTaken    = "user.email.taken"
 )
 
 // Authentication error codes
 const (
-	AuthTokenInvalid = "auth.token.invalid"
-	AuthTokenExpired = "auth.token.expired"
-	AuthAccessDenied = "auth.access.denied"
+	AuthTokenInvalid   = "auth.token.invalid"
+	AuthTokenExpired   = "auth.token.expired"
+	AuthAccessDenied   = "auth.access.denied"
+	AuthSessionInvalid = "auth.session.invalid"
+	AuthImpersonation  = "auth.impersonation.forbidden"
+)
+
+// Session related error codes
+const (
+	SessionNotFound = "session.not_found"
 )
 
 // Role related error codes
@@ -46,6 +57,42 @@ const (
 	RoleInUse    = "role.in_use"
 )
 
+// Organization related error codes
+const (
+	OrgNotFound       = "org.not_found"
+	OrgAccessDenied   = "org.access.denied"
+	OrgMemberExists   = "org.member.exists"
+	OrgMemberNotFound = "org.member.not_found"
+	OrgSlugTaken      = "org.slug.taken"
+)
+
+// Team related error codes
+const (
+	TeamNotFound       = "team.not_found"
+	TeamNameTaken      = "team.name.taken"
+	TeamMemberExists   = "team.member.exists"
+	TeamMemberNotFound = "team.member.not_found"
+)
+
+// Invitation related error codes
+const (
+	InvitationNotFound = "invitation.not_found"
+	InvitationPending  = "invitation.pending"
+	InvitationInvalid  = "invitation.invalid"
+)
+
+// Data export related error codes
+const (
+	ExportNotFound = "export.not_found"
+	ExportInvalid  = "export.invalid"
+)
+
+// SQL console related error codes
+const (
+	SQLRejected = "sql.rejected"
+	SQLFailed   = "sql.failed"
+)
+
 // Error represents an error with a code and message
 type Error struct {
 	Code    string `json:"code"`    // Error code
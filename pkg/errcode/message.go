@@ -11,6 +11,7 @@ var messages = map[string]string{
 
 	ResourceNotFound:  "资源不存在",
 	ResourceForbidden: "禁止访问此资源",
+	ResourceModified:  "资源已被修改，请刷新后重试",
 
 	InvalidParams: "无效的参数",
 
@@ -19,13 +20,41 @@ var messages = map[string]string{
 	UserRegisterError: "用户注册失败",
 	UserLoginError:    "用户登录失败",
 	UserDisabled:      "用户已被禁用",
+	UserPasswordWeak:  "密码不符合安全策略",
+	UserPasswordWrong: "密码错误",
+	UserEmailTaken:    "邮箱已被使用",
 
-	AuthTokenInvalid: "无效的认证令牌",
-	AuthTokenExpired: "认证令牌已过期",
-	AuthAccessDenied: "拒绝访问",
+	AuthTokenInvalid:   "无效的认证令牌",
+	AuthTokenExpired:   "认证令牌已过期",
+	AuthAccessDenied:   "拒绝访问",
+	AuthSessionInvalid: "会话已失效，请重新登录",
+	AuthImpersonation:  "模拟登录状态下禁止此操作",
+
+	SessionNotFound: "会话不存在",
 
 	RoleNotFound: "角色不存在",
 	RoleInUse:    "角色正在使用中，无法删除",
+
+	OrgNotFound:       "组织不存在",
+	OrgAccessDenied:   "不是该组织的成员",
+	OrgMemberExists:   "用户已是该组织成员",
+	OrgMemberNotFound: "组织成员不存在",
+	OrgSlugTaken:      "组织标识已被使用",
+
+	TeamNotFound:       "团队不存在",
+	TeamNameTaken:      "团队名称已被使用",
+	TeamMemberExists:   "用户已是该团队成员",
+	TeamMemberNotFound: "团队成员不存在",
+
+	InvitationNotFound: "邀请不存在",
+	InvitationPending:  "该邮箱已有待处理的邀请",
+	InvitationInvalid:  "邀请无效或已过期",
+
+	ExportNotFound: "数据导出不存在",
+	ExportInvalid:  "下载链接无效或已过期",
+
+	SQLRejected: "查询被拒绝",
+	SQLFailed:   "查询执行失败",
 }
 
 // GetMessage returns the message for a given error code
@@ -0,0 +1,362 @@
+// Package fieldcrypt encrypts database fields holding personal data.
+//
+// Values are encrypted with envelope encryption: each value gets a random
+// data key that encrypts it with AES-GCM, and the data key is itself
+// encrypted with a key-encryption key from a local keyring file. Stored
+// values look like "enc:v1:<key id>:<encrypted data key>:<encrypted value>",
+// so rotating keys only needs the small data keys of old values rewrapped,
+// and values written before encryption was enabled are still read as-is.
+//
+// Encrypted values cannot be compared in queries. Fields that need equality
+// lookups get a blind index: a keyed HMAC of the normalized value stored in
+// a separate column.
+package fieldcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"entgo.io/ent/schema/field"
+)
+
+// prefix marks encrypted values
+const prefix = "enc:v1:"
+
+// reloadInterval limits keyring reloads triggered by unknown key IDs
+const reloadInterval = time.Second
+
+// ErrMalformed is returned for encrypted values that cannot be parsed
+var ErrMalformed = errors.New("malformed encrypted value")
+
+// Config holds field encryption configuration
+type Config struct {
+	KeyringFile string `mapstructure:"keyring_file"` // Keyring path; empty stores fields in plaintext
+}
+
+// Cipher encrypts and decrypts field values with the keys of a keyring
+type Cipher struct {
+	path     string
+	mu       sync.RWMutex
+	ring     *Keyring
+	loadedAt time.Time
+}
+
+// New creates a cipher from configuration. Without a keyring file the
+// cipher is disabled: values are stored in plaintext.
+func New(cfg Config) (*Cipher, error) {
+	c := &Cipher{path: cfg.KeyringFile}
+	if c.path == "" {
+		return c, nil
+	}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Enabled reports whether new values are encrypted
+func (c *Cipher) Enabled() bool {
+	return c.keyring() != nil
+}
+
+// Encrypt encrypts a value of the column with the primary key. The column
+// name is authenticated, so values cannot be moved between columns.
+func (c *Cipher) Encrypt(column, plaintext string) (string, error) {
+	ring := c.keyring()
+	if ring == nil {
+		return plaintext, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("generating data key: %w", err)
+	}
+	wrapped, err := seal(ring.Keys[ring.Primary], dataKey, []byte(ring.Primary))
+	if err != nil {
+		return "", err
+	}
+	data, err := seal(dataKey, []byte(plaintext), []byte(column))
+	if err != nil {
+		return "", err
+	}
+	return prefix + ring.Primary + ":" + b64.EncodeToString(wrapped) + ":" + b64.EncodeToString(data), nil
+}
+
+// Decrypt decrypts a stored value of the column. Values without the
+// encryption prefix were stored in plaintext and are returned unchanged.
+func (c *Cipher) Decrypt(column, stored string) (string, error) {
+	keyID, wrapped, data, ok := parse(stored)
+	if !ok {
+		if strings.HasPrefix(stored, prefix) {
+			return "", ErrMalformed
+		}
+		return stored, nil
+	}
+
+	kek, err := c.key(keyID)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := open(kek, wrapped, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("decrypting data key: %w", err)
+	}
+	plaintext, err := open(dataKey, data, []byte(column))
+	if err != nil {
+		return "", fmt.Errorf("decrypting %s: %w", column, err)
+	}
+	return string(plaintext), nil
+}
+
+// Rotate returns a stored value of the column encrypted under the primary
+// key. Encrypted values only get their data key rewrapped; plaintext values
+// are encrypted.
+func (c *Cipher) Rotate(column, stored string) (string, error) {
+	ring := c.keyring()
+	if ring == nil {
+		return "", ErrNoPrimary
+	}
+	keyID, wrapped, data, ok := parse(stored)
+	if !ok {
+		if strings.HasPrefix(stored, prefix) {
+			return "", ErrMalformed
+		}
+		return c.Encrypt(column, stored)
+	}
+	if keyID == ring.Primary {
+		return stored, nil
+	}
+
+	kek, err := c.key(keyID)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := open(kek, wrapped, []byte(keyID))
+	if err != nil {
+		return "", fmt.Errorf("decrypting data key: %w", err)
+	}
+	if wrapped, err = seal(ring.Keys[ring.Primary], dataKey, []byte(ring.Primary)); err != nil {
+		return "", err
+	}
+	return prefix + ring.Primary + ":" + b64.EncodeToString(wrapped) + ":" + b64.EncodeToString(data), nil
+}
+
+// NeedsRotation reports whether a stored value is not encrypted with the
+// current primary key, including values stored in plaintext
+func (c *Cipher) NeedsRotation(stored string) bool {
+	ring := c.keyring()
+	if ring == nil {
+		return false
+	}
+	keyID, _, _, ok := parse(stored)
+	return !ok || keyID != ring.Primary
+}
+
+// defaultIndexKey keys blind indexes while encryption is disabled. Enabling
+// encryption requires recomputing them with the keyring's index key.
+var defaultIndexKey = []byte("go-template-blind-index")
+
+// BlindIndex returns the blind index of a value. Values are normalized by
+// trimming and lowercasing, so lookups are case-insensitive.
+func (c *Cipher) BlindIndex(value string) string {
+	key := defaultIndexKey
+	if ring := c.keyring(); ring != nil {
+		key = ring.IndexKey
+	}
+	return blindIndex(key, value)
+}
+
+// BlindIndexes returns the blind indexes a stored value may have: the
+// current one and, while rows written before encryption was enabled are
+// not rotated yet, the one computed without a keyring
+func (c *Cipher) BlindIndexes(value string) []string {
+	indexes := []string{c.BlindIndex(value)}
+	if c.Enabled() {
+		indexes = append(indexes, blindIndex(defaultIndexKey, value))
+	}
+	return indexes
+}
+
+// blindIndex returns the HMAC of the normalized value
+func blindIndex(key []byte, value string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(value))))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// keyring returns the current keyring, nil when encryption is disabled
+func (c *Cipher) keyring() *Keyring {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ring
+}
+
+// key returns the key-encryption key with the ID. Unknown IDs reload the
+// keyring file, since another process may have rotated keys.
+func (c *Cipher) key(id string) ([]byte, error) {
+	if ring := c.keyring(); ring != nil {
+		if key, ok := ring.Keys[id]; ok {
+			return key, nil
+		}
+	}
+
+	c.mu.RLock()
+	stale := c.path != "" && time.Since(c.loadedAt) > reloadInterval
+	c.mu.RUnlock()
+	if stale {
+		if err := c.reload(); err != nil {
+			return nil, err
+		}
+		if key, ok := c.keyring().Keys[id]; ok {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownKey, id)
+}
+
+// reload reads the keyring file
+func (c *Cipher) reload() error {
+	ring, err := LoadKeyring(c.path)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Now()
+	if err != nil {
+		return err
+	}
+	c.ring = ring
+	return nil
+}
+
+// parse splits an encrypted value into its parts
+func parse(stored string) (keyID string, wrapped, data []byte, ok bool) {
+	body, found := strings.CutPrefix(stored, prefix)
+	if !found {
+		return "", nil, nil, false
+	}
+	parts := strings.Split(body, ":")
+	if len(parts) != 3 {
+		return "", nil, nil, false
+	}
+	wrapped, err := b64.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, false
+	}
+	data, err = b64.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, false
+	}
+	return parts[0], wrapped, data, true
+}
+
+// seal encrypts with AES-GCM, prefixing the random nonce
+func seal(key, plaintext, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, aad), nil
+}
+
+// open decrypts the output of seal
+func open(key, sealed, aad []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformed
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, aad)
+}
+
+// newGCM creates an AES-GCM cipher
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+var (
+	std   *Cipher
+	stdMu sync.RWMutex
+)
+
+// Init initializes the package-level cipher with configuration
+func Init(cfg Config) error {
+	c, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	stdMu.Lock()
+	std = c
+	stdMu.Unlock()
+	return nil
+}
+
+// Default returns the package-level cipher, disabled until Init is called
+func Default() *Cipher {
+	stdMu.RLock()
+	c := std
+	stdMu.RUnlock()
+	if c != nil {
+		return c
+	}
+
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		std = &Cipher{}
+	}
+	return std
+}
+
+// Enabled reports whether the package-level cipher encrypts new values
+func Enabled() bool {
+	return Default().Enabled()
+}
+
+// BlindIndex returns the blind index of a value with the package-level cipher
+func BlindIndex(value string) string {
+	return Default().BlindIndex(value)
+}
+
+// BlindIndexes returns the blind indexes a value may have with the
+// package-level cipher
+func BlindIndexes(value string) []string {
+	return Default().BlindIndexes(value)
+}
+
+// String returns an ent value scanner that encrypts string values of the
+// column with the package-level cipher when writing and decrypts them when
+// reading, e.g. field.String("email").ValueScanner(fieldcrypt.String("users.email")).
+func String(column string) field.TypeValueScanner[string] {
+	return field.ValueScannerFunc[string, *sql.NullString]{
+		V: func(s string) (driver.Value, error) {
+			return Default().Encrypt(column, s)
+		},
+		S: func(ns *sql.NullString) (string, error) {
+			if !ns.Valid {
+				return "", nil
+			}
+			return Default().Decrypt(column, ns.String)
+		},
+	}
+}
@@ -0,0 +1,118 @@
+package fieldcrypt
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// keySize is the size of key-encryption, data and index keys (AES-256)
+const keySize = 32
+
+var (
+	ErrNoPrimary  = errors.New("keyring has no primary key")
+	ErrKeySize    = fmt.Errorf("keyring keys must be %d bytes", keySize)
+	ErrUnknownKey = errors.New("unknown encryption key")
+)
+
+// Keyring holds the key-encryption keys and the blind index key. New values
+// are encrypted with the primary key; the others only decrypt older values.
+// The index key never changes, since blind indexes of all rows depend on it.
+type Keyring struct {
+	Primary  string            `json:"primary"`
+	IndexKey []byte            `json:"index_key"`
+	Keys     map[string][]byte `json:"keys"` // Keys by ID, base64 encoded in the file
+}
+
+// LoadKeyring reads a keyring file
+func LoadKeyring(path string) (*Keyring, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading keyring: %w", err)
+	}
+	var k Keyring
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("parsing keyring: %w", err)
+	}
+	if err := k.validate(); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// NewKeyring creates a keyring with a random index key and no keys
+func NewKeyring() (*Keyring, error) {
+	indexKey, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Keyring{IndexKey: indexKey, Keys: make(map[string][]byte)}, nil
+}
+
+// AddPrimary adds a random key and makes it the primary key, returning its ID
+func (k *Keyring) AddPrimary() (string, error) {
+	key, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+	id := make([]byte, 4)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("generating key id: %w", err)
+	}
+	k.Primary = "k" + hex.EncodeToString(id)
+	k.Keys[k.Primary] = key
+	return k.Primary, nil
+}
+
+// Save writes the keyring file, readable by the owner only. The file is
+// replaced atomically so running servers never read a partial keyring.
+func (k *Keyring) Save(path string) error {
+	if err := k.validate(); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating keyring directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("writing keyring: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// validate checks the keyring is usable
+func (k *Keyring) validate() error {
+	if len(k.IndexKey) != keySize {
+		return ErrKeySize
+	}
+	for _, key := range k.Keys {
+		if len(key) != keySize {
+			return ErrKeySize
+		}
+	}
+	if _, ok := k.Keys[k.Primary]; !ok {
+		return ErrNoPrimary
+	}
+	return nil
+}
+
+// randomKey returns a new random key
+func randomKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	return key, nil
+}
+
+// b64 is the encoding of binary parts of encrypted values
+var b64 = base64.RawURLEncoding
@@ -0,0 +1,119 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go-template/pkg/logger"
+)
+
+var ErrUnknownDriver = errors.New("unknown mail driver")
+
+// Config holds mail delivery configuration
+type Config struct {
+	Driver   string `mapstructure:"driver"`   // Delivery driver: log, smtp
+	From     string `mapstructure:"from"`     // Sender address
+	Host     string `mapstructure:"host"`     // SMTP host
+	Port     int    `mapstructure:"port"`     // SMTP port
+	Username string `mapstructure:"username"` // SMTP username, empty for no authentication
+	Password string `mapstructure:"password"` // SMTP password
+}
+
+// Message is a plain text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers email messages
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// New creates a mailer for the configured driver
+func New(cfg Config) (Mailer, error) {
+	switch strings.ToLower(cfg.Driver) {
+	case "", "log":
+		return &logMailer{from: cfg.From}, nil
+	case "smtp":
+		return &smtpMailer{cfg: cfg}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDriver, cfg.Driver)
+	}
+}
+
+var (
+	std   Mailer
+	stdMu sync.RWMutex
+)
+
+// Init initializes the package-level mailer with configuration
+func Init(cfg Config) error {
+	m, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	stdMu.Lock()
+	std = m
+	stdMu.Unlock()
+	return nil
+}
+
+// Default returns the package-level mailer, which logs messages until Init is called
+func Default() Mailer {
+	stdMu.RLock()
+	defer stdMu.RUnlock()
+	if std == nil {
+		return &logMailer{}
+	}
+	return std
+}
+
+// logMailer writes messages to the application log instead of sending them.
+// It is meant for development, where no mail server is available.
+type logMailer struct {
+	from string
+}
+
+// Send implements Mailer
+func (m *logMailer) Send(_ context.Context, msg Message) error {
+	if l := logger.With("from", m.from, "to", msg.To, "subject", msg.Subject); l != nil {
+		l.Info(msg.Body)
+	}
+	return nil
+}
+
+// smtpMailer sends messages through an SMTP server
+type smtpMailer struct {
+	cfg Config
+}
+
+// Send implements Mailer
+func (m *smtpMailer) Send(_ context.Context, msg Message) error {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+
+	var a smtp.Auth
+	if m.cfg.Username != "" {
+		a = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	if err := smtp.SendMail(addr, a, m.cfg.From, []string{msg.To}, []byte(b.String())); err != nil {
+		return fmt.Errorf("sending mail to %s: %w", msg.To, err)
+	}
+	return nil
+}
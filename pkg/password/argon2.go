@@ -0,0 +1,145 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// AlgorithmArgon2id is the PHC identifier of Argon2id
+const AlgorithmArgon2id = "argon2id"
+
+// Argon2Params holds the Argon2id cost parameters
+type Argon2Params struct {
+	Memory      uint32 `mapstructure:"memory"`      // Memory in KiB
+	Iterations  uint32 `mapstructure:"iterations"`  // Number of passes over the memory
+	Parallelism uint8  `mapstructure:"parallelism"` // Number of threads
+	SaltLength  uint32 `mapstructure:"salt_length"` // Salt length in bytes
+	KeyLength   uint32 `mapstructure:"key_length"`  // Derived key length in bytes
+}
+
+// DefaultArgon2Params returns the recommended Argon2id parameters
+func DefaultArgon2Params() Argon2Params {
+	return Argon2Params{
+		Memory:      64 * 1024,
+		Iterations:  3,
+		Parallelism: 2,
+		SaltLength:  16,
+		KeyLength:   32,
+	}
+}
+
+// Argon2idHasher hashes passwords with Argon2id
+type Argon2idHasher struct {
+	params Argon2Params
+}
+
+// NewArgon2idHasher creates an Argon2id hasher, filling zero parameters with defaults
+func NewArgon2idHasher(params Argon2Params) *Argon2idHasher {
+	def := DefaultArgon2Params()
+	if params.Memory == 0 {
+		params.Memory = def.Memory
+	}
+	if params.Iterations == 0 {
+		params.Iterations = def.Iterations
+	}
+	if params.Parallelism == 0 {
+		params.Parallelism = def.Parallelism
+	}
+	if params.SaltLength == 0 {
+		params.SaltLength = def.SaltLength
+	}
+	if params.KeyLength == 0 {
+		params.KeyLength = def.KeyLength
+	}
+	return &Argon2idHasher{params: params}
+}
+
+// ID implements Hasher
+func (h *Argon2idHasher) ID() string {
+	return AlgorithmArgon2id
+}
+
+// Hash implements Hasher
+func (h *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generating salt: %w", err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.Iterations, h.params.Memory, h.params.Parallelism, h.params.KeyLength)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		AlgorithmArgon2id,
+		argon2.Version,
+		h.params.Memory,
+		h.params.Iterations,
+		h.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify implements Hasher
+func (h *Argon2idHasher) Verify(password, encoded string) (bool, error) {
+	params, salt, key, err := decodeArgon2id(encoded)
+	if err != nil {
+		return false, err
+	}
+
+	other := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+	return subtle.ConstantTimeCompare(key, other) == 1, nil
+}
+
+// NeedsRehash implements Hasher
+func (h *Argon2idHasher) NeedsRehash(encoded string) bool {
+	params, _, _, err := decodeArgon2id(encoded)
+	if err != nil {
+		return true
+	}
+	return params.Memory < h.params.Memory ||
+		params.Iterations < h.params.Iterations ||
+		params.Parallelism < h.params.Parallelism ||
+		params.SaltLength < h.params.SaltLength ||
+		params.KeyLength < h.params.KeyLength
+}
+
+// decodeArgon2id parses a PHC-format Argon2id hash
+func decodeArgon2id(encoded string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("%w: unsupported argon2 version %d", ErrMalformedHash, version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrMalformedHash
+	}
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
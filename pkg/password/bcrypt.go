@@ -0,0 +1,74 @@
+package password
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AlgorithmBcrypt is the identifier used for bcrypt hashes ($2a$, $2b$, $2y$)
+const AlgorithmBcrypt = "bcrypt"
+
+// DefaultBcryptCost is the bcrypt cost used when none is configured
+const DefaultBcryptCost = bcrypt.DefaultCost
+
+// BcryptMaxBytes is the longest password bcrypt hashes, in bytes
+const BcryptMaxBytes = 72
+
+// BcryptHasher hashes passwords with bcrypt. It is mainly kept to verify
+// legacy hashes created before Argon2id became the default.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher creates a bcrypt hasher with the given cost
+func NewBcryptHasher(cost int) *BcryptHasher {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		cost = DefaultBcryptCost
+	}
+	return &BcryptHasher{cost: cost}
+}
+
+// ID implements Hasher
+func (h *BcryptHasher) ID() string {
+	return AlgorithmBcrypt
+}
+
+// Hash implements Hasher
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
+
+// Verify implements Hasher
+func (h *BcryptHasher) Verify(password, encoded string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+	return false, ErrMalformedHash
+}
+
+// NeedsRehash implements Hasher
+func (h *BcryptHasher) NeedsRehash(encoded string) bool {
+	cost, err := bcrypt.Cost([]byte(encoded))
+	if err != nil {
+		return true
+	}
+	return cost < h.cost
+}
+
+// isBcryptID reports whether the PHC identifier belongs to a bcrypt variant
+func isBcryptID(id string) bool {
+	switch id {
+	case "2a", "2b", "2y":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package password
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownAlgorithm = errors.New("unknown password hash algorithm")
+	ErrMalformedHash    = errors.New("malformed password hash")
+)
+
+// Hasher hashes and verifies passwords encoded as PHC strings
+// ($<id>$<params>$<salt>$<hash>)
+type Hasher interface {
+	// ID returns the PHC identifier of the algorithm
+	ID() string
+	// Hash returns the encoded hash of the password
+	Hash(password string) (string, error)
+	// Verify reports whether the password matches the encoded hash
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash reports whether the encoded hash was produced with
+	// parameters weaker than the hasher's current ones
+	NeedsRehash(encoded string) bool
+}
+
+// Config holds password hashing and policy configuration
+type Config struct {
+	Algorithm  string       `mapstructure:"algorithm"`   // Hash algorithm for new passwords: argon2id, bcrypt
+	Argon2     Argon2Params `mapstructure:"argon2"`      // Argon2id parameters
+	BcryptCost int          `mapstructure:"bcrypt_cost"` // bcrypt cost factor
+	Policy     PolicyConfig `mapstructure:"policy"`      // Password policy
+}
+
+// NewDefaultConfig returns a default password configuration
+func NewDefaultConfig() *Config {
+	return &Config{
+		Algorithm:  AlgorithmArgon2id,
+		Argon2:     DefaultArgon2Params(),
+		BcryptCost: DefaultBcryptCost,
+		Policy: PolicyConfig{
+			MinLength: 8,
+			MaxLength: 128,
+		},
+	}
+}
+
+// Manager hashes new passwords with the configured algorithm and verifies
+// hashes produced by any supported algorithm
+type Manager struct {
+	hasher    Hasher
+	verifiers map[string]Hasher
+	policy    *Policy
+}
+
+// New creates a password manager from configuration
+func New(cfg *Config) (*Manager, error) {
+	argon := NewArgon2idHasher(cfg.Argon2)
+	bc := NewBcryptHasher(cfg.BcryptCost)
+
+	m := &Manager{
+		verifiers: map[string]Hasher{
+			argon.ID(): argon,
+			bc.ID():    bc,
+		},
+	}
+
+	switch strings.ToLower(cfg.Algorithm) {
+	case "", AlgorithmArgon2id:
+		m.hasher = argon
+	case AlgorithmBcrypt:
+		m.hasher = bc
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, cfg.Algorithm)
+	}
+
+	policy, err := NewPolicy(&cfg.Policy)
+	if err != nil {
+		return nil, err
+	}
+	// bcrypt refuses longer passwords, which max_length in characters does
+	// not rule out
+	if m.hasher == bc {
+		policy.maxBytes = BcryptMaxBytes
+	}
+	m.policy = policy
+
+	return m, nil
+}
+
+// Hash hashes the password with the configured algorithm
+func (m *Manager) Hash(password string) (string, error) {
+	return m.hasher.Hash(password)
+}
+
+// Verify checks the password against an encoded hash of any supported algorithm
+func (m *Manager) Verify(password, encoded string) (bool, error) {
+	h, err := m.verifierFor(encoded)
+	if err != nil {
+		return false, err
+	}
+	return h.Verify(password, encoded)
+}
+
+// NeedsRehash reports whether the encoded hash should be replaced with a
+// fresh hash from the configured algorithm
+func (m *Manager) NeedsRehash(encoded string) bool {
+	h, err := m.verifierFor(encoded)
+	if err != nil || h.ID() != m.hasher.ID() {
+		return true
+	}
+	return m.hasher.NeedsRehash(encoded)
+}
+
+// Validate checks the password against the configured policy
+func (m *Manager) Validate(password string) error {
+	return m.policy.Validate(password)
+}
+
+// verifierFor returns the hasher able to verify the encoded hash
+func (m *Manager) verifierFor(encoded string) (Hasher, error) {
+	id := algorithmID(encoded)
+	if id == "" {
+		return nil, ErrMalformedHash
+	}
+	h, ok := m.verifiers[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownAlgorithm, id)
+	}
+	return h, nil
+}
+
+// algorithmID extracts the PHC identifier from an encoded hash
+func algorithmID(encoded string) string {
+	if !strings.HasPrefix(encoded, "$") {
+		return ""
+	}
+	id, _, _ := strings.Cut(encoded[1:], "$")
+	if isBcryptID(id) {
+		return AlgorithmBcrypt
+	}
+	return id
+}
+
+var (
+	std   *Manager
+	stdMu sync.RWMutex
+)
+
+// Init initializes the package-level password manager with configuration
+func Init(cfg *Config) error {
+	m, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	stdMu.Lock()
+	std = m
+	stdMu.Unlock()
+	return nil
+}
+
+// Default returns the package-level password manager
+func Default() *Manager {
+	stdMu.RLock()
+	m := std
+	stdMu.RUnlock()
+	if m != nil {
+		return m
+	}
+
+	stdMu.Lock()
+	defer stdMu.Unlock()
+	if std == nil {
+		// The default configuration never fails to build
+		std, _ = New(NewDefaultConfig())
+	}
+	return std
+}
+
+// Hash hashes the password with the package-level manager
+func Hash(password string) (string, error) {
+	return Default().Hash(password)
+}
+
+// Verify checks the password with the package-level manager
+func Verify(password, encoded string) (bool, error) {
+	return Default().Verify(password, encoded)
+}
+
+// NeedsRehash reports whether the encoded hash is outdated for the package-level manager
+func NeedsRehash(encoded string) bool {
+	return Default().NeedsRehash(encoded)
+}
+
+// Validate checks the password against the package-level policy
+func Validate(password string) error {
+	return Default().Validate(password)
+}
@@ -0,0 +1,136 @@
+package password
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrTooShort      = errors.New("password is too short")
+	ErrTooLong       = errors.New("password is too long")
+	ErrMissingUpper  = errors.New("password must contain an uppercase letter")
+	ErrMissingLower  = errors.New("password must contain a lowercase letter")
+	ErrMissingDigit  = errors.New("password must contain a digit")
+	ErrMissingSymbol = errors.New("password must contain a symbol")
+	ErrBreached      = errors.New("password appears in a list of breached passwords")
+)
+
+// PolicyConfig holds password policy rules
+type PolicyConfig struct {
+	MinLength     int    `mapstructure:"min_length"`     // Minimum number of characters
+	MaxLength     int    `mapstructure:"max_length"`     // Maximum number of characters, 0 for unlimited
+	RequireUpper  bool   `mapstructure:"require_upper"`  // Require an uppercase letter
+	RequireLower  bool   `mapstructure:"require_lower"`  // Require a lowercase letter
+	RequireDigit  bool   `mapstructure:"require_digit"`  // Require a digit
+	RequireSymbol bool   `mapstructure:"require_symbol"` // Require a punctuation or symbol character
+	BreachedList  string `mapstructure:"breached_list"`  // Path to a file of breached passwords, one per line
+}
+
+// Policy validates passwords against configured rules
+type Policy struct {
+	cfg      PolicyConfig
+	maxBytes int // Longest password the hash algorithm accepts, 0 for unlimited
+	breached map[string]struct{}
+}
+
+// NewPolicy creates a policy and loads the breached password list if configured
+func NewPolicy(cfg *PolicyConfig) (*Policy, error) {
+	p := &Policy{cfg: *cfg}
+	if cfg.BreachedList != "" {
+		breached, err := loadBreachedList(cfg.BreachedList)
+		if err != nil {
+			return nil, err
+		}
+		p.breached = breached
+	}
+	return p, nil
+}
+
+// Validate returns the first policy rule the password violates, or nil
+func (p *Policy) Validate(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		return fmt.Errorf("%w: minimum %d characters", ErrTooShort, p.cfg.MinLength)
+	}
+	if p.cfg.MaxLength > 0 && length > p.cfg.MaxLength {
+		return fmt.Errorf("%w: maximum %d characters", ErrTooLong, p.cfg.MaxLength)
+	}
+	if p.maxBytes > 0 && len(password) > p.maxBytes {
+		return fmt.Errorf("%w: maximum %d bytes", ErrTooLong, p.maxBytes)
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	if p.cfg.RequireUpper && !hasUpper {
+		return ErrMissingUpper
+	}
+	if p.cfg.RequireLower && !hasLower {
+		return ErrMissingLower
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		return ErrMissingDigit
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		return ErrMissingSymbol
+	}
+
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		return ErrBreached
+	}
+
+	return nil
+}
+
+// IsPolicyError reports whether err is a password policy violation
+func IsPolicyError(err error) bool {
+	for _, target := range []error{
+		ErrTooShort, ErrTooLong, ErrMissingUpper, ErrMissingLower,
+		ErrMissingDigit, ErrMissingSymbol, ErrBreached,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadBreachedList reads a breached password file, skipping blank lines and # comments
+func loadBreachedList(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening breached password list: %w", err)
+	}
+	defer f.Close()
+
+	breached := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		breached[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading breached password list: %w", err)
+	}
+
+	return breached, nil
+}